@@ -0,0 +1,217 @@
+package app
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"phd-dissertation-planner/internal/core"
+
+	"github.com/urfave/cli/v2"
+)
+
+// maxUploadSize limits uploaded CSV files
+const maxUploadSize = 10 << 20
+
+// planAPI serves the JSON/REST endpoints of `serve --api`
+type planAPI struct {
+	ctx     *cli.Context
+	preview *preview
+	mu      sync.Mutex // Serializes uploads and generation, which share input_data and the output directory
+}
+
+// apiSection is a validation report section in API responses
+type apiSection struct {
+	Title    string                 `json:"title"`
+	Errors   []core.ValidationIssue `json:"errors"`
+	Warnings []core.ValidationIssue `json:"warnings"`
+}
+
+// register adds the API routes to mux
+func (a *planAPI) register(mux *http.ServeMux) {
+	mux.HandleFunc("PUT /api/csv/{name}", a.uploadCSV)
+	mux.HandleFunc("GET /api/validation", a.validation)
+	mux.HandleFunc("GET /api/overlaps", a.overlaps)
+	mux.HandleFunc("POST /api/generate", a.generateBundle)
+}
+
+// uploadCSV validates the request body as a task CSV and stores it in
+// input_data under the given name, replacing any file of that name
+func (a *planAPI) uploadCSV(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if name != filepath.Base(name) || strings.HasPrefix(name, ".") || !strings.HasSuffix(strings.ToLower(name), ".csv") {
+		writeAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid CSV file name %q", name))
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Hidden files are skipped by the reader, so the upload stays out of
+	// the plan until it has been validated
+	tmp := filepath.Join(inputDataDir, ".upload-"+name)
+	defer os.Remove(tmp)
+	f, err := os.Create(tmp)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, core.NewFileError(tmp, "create", err))
+		return
+	}
+	_, err = io.Copy(f, http.MaxBytesReader(w, r.Body, maxUploadSize))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, fmt.Errorf("failed to read upload: %w", err))
+		return
+	}
+
+	section := apiSection{Title: name}
+	result, err := core.NewCSVValidator().ValidateCSVFile(tmp)
+	if result != nil {
+		section.Errors, section.Warnings = result.Errors, result.Warnings
+	} else if err != nil {
+		section.Errors = []core.ValidationIssue{{Type: "csv_parsing", Message: err.Error()}}
+	}
+	if len(section.Errors) > 0 {
+		writeJSON(w, http.StatusUnprocessableEntity, section)
+		return
+	}
+
+	if err := os.Rename(tmp, filepath.Join(inputDataDir, name)); err != nil {
+		writeAPIError(w, http.StatusInternalServerError, core.NewFileError(name, "store", err))
+		return
+	}
+	a.preview.reload(a.ctx)
+	writeJSON(w, http.StatusCreated, section)
+}
+
+// validation reports the same checks as the validate command
+func (a *planAPI) validation(w http.ResponseWriter, _ *http.Request) {
+	sections, err := validationSections(a.ctx)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	report := struct {
+		Valid    bool         `json:"valid"`
+		Sections []apiSection `json:"sections"`
+	}{Valid: true}
+	for _, s := range sections {
+		report.Sections = append(report.Sections, apiSection{Title: s.title, Errors: s.errors, Warnings: s.warnings})
+		if len(s.errors) > 0 {
+			report.Valid = false
+		}
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+// overlaps reports the schedule conflicts of the current plan
+func (a *planAPI) overlaps(w http.ResponseWriter, _ *http.Request) {
+	cfg, err := loadPlan(a.ctx)
+	var cycles *cycleError
+	if errors.As(err, &cycles) {
+		writeJSON(w, http.StatusOK, apiSection{Title: "Schedule", Errors: cycles.cycles})
+		return
+	}
+	if err != nil {
+		writeAPIError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, apiSection{
+		Title:    "Schedule",
+		Warnings: scheduleWarnings(cfg),
+	})
+}
+
+// generateBundle generates the planner and returns the LaTeX sources, plus
+// the PDF with ?format=pdf, as a zip archive
+func (a *planAPI) generateBundle(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "latex"
+	}
+	if format != "latex" && format != "pdf" {
+		writeAPIError(w, http.StatusBadRequest, fmt.Errorf("unknown format %q, expected latex or pdf", format))
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	cfg, err := loadPlan(a.ctx)
+	if err != nil {
+		writeAPIError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if err := generate(a.ctx, format == "pdf"); err != nil {
+		writeAPIError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	dirs := []string{"latex"}
+	if format == "pdf" {
+		dirs = append(dirs, "pdfs")
+	}
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="planner-`+format+`.zip"`)
+	if err := writeZip(w, cfg.OutputDir, dirs); err != nil {
+		logger.Warn("Writing the planner bundle failed: %v", err)
+	}
+}
+
+// writeZip archives the files under the given subdirectories of root, with
+// paths relative to root
+func writeZip(w io.Writer, root string, dirs []string) error {
+	zw := zip.NewWriter(w)
+	for _, dir := range dirs {
+		err := filepath.WalkDir(filepath.Join(root, dir), func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			rel, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+			dst, err := zw.Create(filepath.ToSlash(rel))
+			if err != nil {
+				return err
+			}
+			src, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer src.Close()
+			_, err = io.Copy(dst, src)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// writeJSON writes v as the JSON response body
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		logger.Warn("Writing the API response failed: %v", err)
+	}
+}
+
+// writeAPIError writes err as a JSON error response
+func writeAPIError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,268 @@
+package app
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"phd-dissertation-planner/internal/core"
+
+	"github.com/urfave/cli/v2"
+)
+
+// browseHelp lists the commands of the interactive browser
+const browseHelp = `Commands:
+  n, p          next / previous month
+  g YYYY-MM     go to a month
+  c             list schedule conflicts
+  k             list categories and phases and whether they are shown
+  t CATEGORY    show or hide a category (a prefix is enough)
+  f PHASE       show or hide a phase (a prefix is enough)
+  r             regenerate the LaTeX without the hidden categories and
+                phases, and reload the plan
+  h             this help
+  q             quit`
+
+// browseCommand starts the interactive plan browser
+func browseCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "browse",
+		Aliases:   []string{"tui"},
+		Usage:     "browse the plan month by month, inspect conflicts and regenerate interactively",
+		UsageText: "plannergen [--config FILE] browse",
+		Action: func(c *cli.Context) error {
+			b := &browser{
+				ctx: c, in: bufio.NewScanner(os.Stdin), out: os.Stdout,
+				hiddenCategories: make(map[string]bool), hiddenPhases: make(map[string]bool),
+			}
+			return b.run()
+		},
+	}
+}
+
+// browser is an interactive month-by-month view of the loaded plan
+type browser struct {
+	ctx   *cli.Context
+	in    *bufio.Scanner
+	out   io.Writer
+	cfg   core.Config
+	month time.Time // First day of the month shown
+
+	// Hidden categories and phases by CategoryKey, left out of the month
+	// shown and of the generated LaTeX
+	hiddenCategories map[string]bool
+	hiddenPhases     map[string]bool
+}
+
+// run loads the plan and handles commands until "q" or end of input
+func (b *browser) run() error {
+	if err := b.load(); err != nil {
+		return err
+	}
+	b.show()
+
+	for {
+		fmt.Fprint(b.out, core.CyanText("browse> "))
+		if !b.in.Scan() {
+			fmt.Fprintln(b.out)
+			return b.in.Err()
+		}
+		cmd, arg, _ := strings.Cut(strings.TrimSpace(b.in.Text()), " ")
+		arg = strings.TrimSpace(arg)
+
+		switch strings.ToLower(cmd) {
+		case "":
+		case "n":
+			b.month = b.month.AddDate(0, 1, 0)
+			b.show()
+		case "p":
+			b.month = b.month.AddDate(0, -1, 0)
+			b.show()
+		case "g":
+			month, err := time.Parse("2006-01", arg)
+			if err != nil {
+				fmt.Fprintln(b.out, core.Warning("Use g YYYY-MM, e.g. g 2026-03"))
+				continue
+			}
+			b.month = month
+			b.show()
+		case "c":
+			b.showConflicts()
+		case "k":
+			b.showCategories()
+		case "t":
+			b.toggle("category", arg, b.hiddenCategories, func(t core.Task) string { return t.Category })
+		case "f":
+			b.toggle("phase", arg, b.hiddenPhases, func(t core.Task) string { return t.Phase })
+		case "r":
+			if err := generateKeeping(b.ctx, false, b.shown); err != nil {
+				fmt.Fprintln(b.out, core.Error(err.Error()))
+				continue
+			}
+			if err := b.load(); err != nil {
+				fmt.Fprintln(b.out, core.Error(err.Error()))
+				continue
+			}
+			b.show()
+		case "h", "?", "help":
+			fmt.Fprintln(b.out, browseHelp)
+		case "q", "quit", "exit":
+			return nil
+		default:
+			fmt.Fprintln(b.out, core.Warning(fmt.Sprintf("Unknown command %q; h for help", cmd)))
+		}
+	}
+}
+
+// load reads and schedules the plan, keeping the month shown when possible
+func (b *browser) load() error {
+	cfg, err := loadPlan(b.ctx)
+	if err != nil {
+		return err
+	}
+	b.cfg = cfg
+
+	if b.month.IsZero() {
+		b.month = firstOfMonth(cfg.GetAsOfDate())
+		if len(cfg.Tasks) > 0 {
+			r := core.CalculateDateRange(cfg.Tasks)
+			if b.month.Before(firstOfMonth(r.Earliest)) || b.month.After(r.Latest) {
+				b.month = firstOfMonth(r.Earliest)
+			}
+		}
+	}
+	return nil
+}
+
+// show prints the visible tasks active in the current month
+func (b *browser) show() {
+	start := b.month
+	end := start.AddDate(0, 1, -1)
+
+	var tasks []core.Task
+	hidden := 0
+	for _, t := range b.cfg.Tasks {
+		if t.StartDate.IsZero() || t.EndDate.IsZero() || t.StartDate.After(end) || t.EndDate.Before(start) {
+			continue
+		}
+		if !b.shown(t) {
+			hidden++
+			continue
+		}
+		tasks = append(tasks, t)
+	}
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].StartDate.Before(tasks[j].StartDate) })
+
+	fmt.Fprintln(b.out)
+	month := b.cfg.GetLocale().FormatDate(start, "January 2006")
+	fmt.Fprintln(b.out, core.BoldText(fmt.Sprintf("📅 %s — %d task(s)", month, len(tasks))))
+	fmt.Fprintln(b.out, core.DimText("═══════════════════════════════════════"))
+	for _, t := range tasks {
+		marker := " "
+		if t.IsMilestone {
+			marker = "★"
+		}
+		line := fmt.Sprintf("%s %s → %s  %-8s %s", marker,
+			t.StartDate.Format("01-02"), t.EndDate.Format("01-02"), t.ID, t.Name)
+		if t.Category != "" {
+			line += core.DimText(" [" + t.Category + "]")
+		}
+		if t.Progress > 0 {
+			line += core.DimText(fmt.Sprintf(" %d%%", t.Progress))
+		}
+		fmt.Fprintln(b.out, line)
+	}
+	if hidden > 0 {
+		fmt.Fprintln(b.out, core.DimText(fmt.Sprintf("  (%d task(s) in hidden categories or phases)", hidden)))
+	}
+	if n := len(scheduleWarnings(b.cfg)); n > 0 {
+		fmt.Fprintln(b.out, core.Warning(fmt.Sprintf("⚠️  %d schedule conflict(s) in the plan; c to list", n)))
+	}
+}
+
+// showConflicts prints the schedule warnings of the plan
+func (b *browser) showConflicts() {
+	warnings := scheduleWarnings(b.cfg)
+	if len(warnings) == 0 {
+		fmt.Fprintln(b.out, core.Success("✅ No schedule conflicts"))
+		return
+	}
+	for _, w := range warnings {
+		fmt.Fprintln(b.out, formatValidationIssue(w))
+	}
+}
+
+// shown reports whether a task is in neither a hidden category nor a
+// hidden phase
+func (b *browser) shown(t core.Task) bool {
+	return !b.hiddenCategories[core.CategoryKey(t.Category)] && !b.hiddenPhases[core.CategoryKey(t.Phase)]
+}
+
+// groups returns the names of the plan's categories or phases, as returned
+// by name, with their task counts, sorted
+func (b *browser) groups(name func(core.Task) string) ([]string, map[string]int) {
+	counts := make(map[string]int)
+	for _, t := range b.cfg.Tasks {
+		counts[core.CategoryKey(name(t))]++
+	}
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, counts
+}
+
+// showCategories prints every category and phase with its visibility
+func (b *browser) showCategories() {
+	for _, group := range []struct {
+		title  string
+		name   func(core.Task) string
+		hidden map[string]bool
+	}{
+		{"Categories", func(t core.Task) string { return t.Category }, b.hiddenCategories},
+		{"Phases", func(t core.Task) string { return t.Phase }, b.hiddenPhases},
+	} {
+		fmt.Fprintln(b.out, core.BoldText(group.title))
+		names, counts := b.groups(group.name)
+		for _, name := range names {
+			state := core.Success("shown ")
+			if group.hidden[name] {
+				state = core.DimText("hidden")
+			}
+			label := name
+			if label == "" {
+				label = "(none)"
+			}
+			fmt.Fprintf(b.out, "  %s %s (%d)\n", state, label, counts[name])
+		}
+	}
+}
+
+// toggle shows or hides the first category or phase, as named by name,
+// starting with prefix
+func (b *browser) toggle(kind, prefix string, hidden map[string]bool, name func(core.Task) string) {
+	prefix = core.CategoryKey(prefix)
+	if prefix == "" {
+		fmt.Fprintln(b.out, core.Warning(fmt.Sprintf("Name the %s to show or hide; k lists them", kind)))
+		return
+	}
+	names, _ := b.groups(name)
+	for _, n := range names {
+		if n != "" && strings.HasPrefix(n, prefix) {
+			hidden[n] = !hidden[n]
+			b.show()
+			return
+		}
+	}
+	fmt.Fprintln(b.out, core.Warning(fmt.Sprintf("No %s starts with %q; k lists them", kind, prefix)))
+}
+
+// firstOfMonth returns the first day of t's month
+func firstOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
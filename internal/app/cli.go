@@ -12,32 +12,111 @@
 	fConfig       = "config"
 	pConfig       = "preview"
 	fOutDir       = "outdir"
+	fTemplates    = "templates"
 	fTestCoverage = "test-coverage"
+	fSlackReport  = "slack-report"
+	fSimulate     = "simulate"
+	fCompare      = "compare"
+	fSuggest      = "suggest"
+	fPatchCSV     = "patch-csv"
+	fRange        = "range"
+	fStart        = "start"
+	fEnd          = "end"
+	fDryRun       = "dry-run"
+	fIncludeCat   = "include-category"
+	fExcludeCat   = "exclude-category"
+	fIncludeTag   = "include-tag"
+	fExcludeTag   = "exclude-tag"
+	fVerbose      = "verbose"
+	fQuiet        = "quiet"
+	fLogFormat    = "log-format"
+	fJSONSummary  = "json-summary"
+	fSet          = "set"
+	fFailOn       = "fail-on"
+	fCompile      = "compile"
+	fLaTeXEngine  = "latex-engine"
+	fColorblind   = "colorblind"
+	fProfile      = "profile"
 )
 
 func New() *cli.App {
 	// Initialize the composer map
 	core.ComposerMap["monthly"] = Monthly
+	core.ComposerMap["weekly"] = Weekly
+	core.ComposerMap["yearly"] = Yearly
+	core.ComposerMap["quarterly"] = Quarterly
+	core.ComposerMap["daily"] = Daily
+	core.ComposerMap["gantt"] = Gantt
+	core.ComposerMap["timeline"] = Timeline
+	core.ComposerMap["overflow"] = Overflow
+	core.ComposerMap["monthstrip"] = MonthStrip
+	core.ComposerMap["risk"] = Risk
+	core.ComposerMap["earnedvalue"] = EarnedValue
+	core.ComposerMap["legend"] = Legend
+	core.ComposerMap["cover"] = Cover
 
 	return &cli.App{
 		Name:  "plannergen",
 		Usage: "Generate LaTeX-based calendar PDFs from CSV timeline data",
+		// --version prints the same metadata as the version command, on one line
+		Version: core.Build().String(),
 
 		Writer:    os.Stdout,
 		ErrWriter: os.Stderr,
 
 		Flags: []cli.Flag{
-			&cli.PathFlag{Name: fConfig, Required: false, Value: "input_data/config.yaml", Usage: "config file(s), comma-separated"},
-			&cli.BoolFlag{Name: pConfig, Required: false, Usage: "render only one page per unique module"},
+			&cli.StringSliceFlag{Name: fConfig, Required: false, Value: cli.NewStringSlice("input_data/config.yaml"), TakesFile: true, Usage: "config file(s), repeatable or comma-separated; later files override earlier ones (base, then user, then project)"},
+			&cli.BoolFlag{Name: pConfig, Required: false, Usage: "render only one page per unique module, or only the month given after it, after every other flag (--preview 2025-03)"},
+			&cli.StringFlag{Name: fRange, Required: false, Usage: "rebuild only these months: 2025-03 or 2025-01:2025-06"},
+			&cli.StringFlag{Name: fStart, Required: false, Usage: "first month of the calendar, YYYY-MM or YYYY-MM-DD (overrides calendar_start)"},
+			&cli.StringFlag{Name: fEnd, Required: false, Usage: "last month of the calendar, YYYY-MM or YYYY-MM-DD (overrides calendar_end)"},
+			&cli.StringSliceFlag{Name: fIncludeCat, Required: false, Usage: "lay out only tasks whose category starts with one of these"},
+			&cli.StringSliceFlag{Name: fExcludeCat, Required: false, Usage: "leave out tasks whose category starts with one of these"},
+			&cli.StringSliceFlag{Name: fIncludeTag, Required: false, Usage: "lay out only tasks with one of these tags"},
+			&cli.StringSliceFlag{Name: fExcludeTag, Required: false, Usage: "leave out tasks with one of these tags"},
+			&cli.PathFlag{Name: fJSONSummary, Required: false, Value: "", Usage: "write the task statistics, validation issues and output files of the run as JSON to this file"},
+			&cli.BoolFlag{Name: fDryRun, Required: false, Usage: "parse, validate, lay out and report as usual but write nothing, listing the files that would be produced"},
+			&cli.BoolFlag{Name: fColorblind, Required: false, Usage: "use the color-blind safe Okabe-Ito palette and hatch bars of similar colors"},
+			&cli.StringFlag{Name: fProfile, Required: false, Usage: "output profile: print, digital, tablet or one defined under profiles (overrides profile)"},
+			&cli.StringSliceFlag{Name: fSet, Required: false, Usage: "set a config key over the config files, e.g. --set layout.stacking.spacing=0.5mm (repeatable)"},
+			&cli.BoolFlag{Name: fCompile, Required: false, Value: true, Usage: "compile the LaTeX to PDF after generating; --compile=false writes only the LaTeX"},
+			&cli.StringFlag{Name: fLaTeXEngine, Required: false, Usage: "LaTeX engine: auto, latexmk, xelatex or tectonic (overrides latex_engine)"},
 			&cli.PathFlag{Name: fOutDir, Required: false, Value: "", Usage: "output directory for generated files (overrides config)"},
+			&cli.PathFlag{Name: fTemplates, Required: false, Value: "", Usage: "directory of .tpl files overriding the built-in templates"},
 			&cli.BoolFlag{Name: "test-coverage", Required: false, Usage: "run tests with coverage analysis"},
 			&cli.BoolFlag{Name: "validate", Required: false, Usage: "validate CSV file without generating PDF"},
 			&cli.BoolFlag{Name: "validate-config", Required: false, Usage: "validate configuration files and environment variables"},
+			&cli.BoolFlag{Name: fSlackReport, Required: false, Usage: "print the slack of every task, not only the zero-slack ones"},
+			&cli.BoolFlag{Name: fSimulate, Required: false, Usage: "print Monte Carlo confidence dates for each milestone"},
+			&cli.BoolFlag{Name: fSuggest, Required: false, Usage: "print date shifts that resolve dependency and critical-task overlaps"},
+			&cli.PathFlag{Name: fPatchCSV, Required: false, Value: "", Usage: "write copies of the CSV files with the suggested dates to this directory"},
+			&cli.PathFlag{Name: fCompare, Required: false, Value: "", Usage: "proposed plan CSV file(s) to compare against, comma-separated"},
+			&cli.StringFlag{Name: fFailOn, Required: false, Value: failOnError, Usage: "lowest severity that fails the run with a non-zero exit status: warning or error", EnvVars: []string{"PLANNER_FAIL_ON"}},
+			&cli.PathFlag{Name: fCPUProfile, Required: false, TakesFile: true, Usage: "write a CPU profile of the run to this file, for go tool pprof"},
+			&cli.PathFlag{Name: fMemProfile, Required: false, TakesFile: true, Usage: "write a heap profile at the end of the run to this file, for go tool pprof"},
+			&cli.PathFlag{Name: fTrace, Required: false, TakesFile: true, Usage: "write an execution trace of the run to this file, for go tool trace"},
+			&cli.BoolFlag{Name: fVerbose, Required: false, Usage: "log debug messages"},
+			&cli.BoolFlag{Name: fQuiet, Aliases: []string{"q"}, Required: false, Usage: "hide progress output, logging only warnings and errors"},
+			&cli.StringFlag{Name: fLogFormat, Required: false, Usage: "log format: text or json", EnvVars: []string{"PLANNER_LOG_FORMAT"}},
 			&cli.StringFlag{Name: "preset", Required: false, Usage: "Configuration preset: academic, compact, presentation", EnvVars: []string{"PLANNER_PRESET"}},
 		},
 
+		Before: beforeRun,
+		After:  stopProfiling,
 		Action: action,
 
-		Commands: []*cli.Command{},
+		Commands: []*cli.Command{
+			statsCommand(),
+			shiftCommand(),
+			validateCommand(),
+			watchCommand(),
+			diffCommand(),
+			initCommand(),
+			browseCommand(),
+			serveCommand(),
+			doctorCommand(),
+			versionCommand(),
+			completionCommand(),
+		},
 	}
 }
@@ -0,0 +1,334 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	cal "phd-dissertation-planner/internal/calendar"
+	"phd-dissertation-planner/internal/core"
+)
+
+// LaTeX engines for compiling the PDF
+const (
+	engineAuto     = "auto"
+	engineLatexmk  = "latexmk"
+	engineXeLaTeX  = "xelatex"
+	engineTectonic = "tectonic"
+)
+
+// autoEngines are the engines tried by "auto", in order of preference:
+// latexmk reruns XeLaTeX until cross-references settle, tectonic fetches
+// missing packages itself
+var autoEngines = []string{engineLatexmk, engineXeLaTeX, engineTectonic}
+
+// errNoLaTeXEngine is returned when no LaTeX engine is installed
+var errNoLaTeXEngine = errors.New("no LaTeX engine found")
+
+// maxLaTeXErrors caps the LaTeX errors quoted in a compilation error
+const maxLaTeXErrors = 5
+
+// latexError is a failed compilation, with the errors from the LaTeX log
+// described by the task or day they came from
+type latexError struct {
+	engine   string
+	logFile  string
+	messages []string
+	err      error
+}
+
+func (e *latexError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s failed: %v", e.engine, e.err)
+	for _, msg := range e.messages {
+		sb.WriteString("\n   " + msg)
+	}
+	if e.logFile != "" {
+		sb.WriteString("\n   Full log: " + e.logFile)
+	}
+	return sb.String()
+}
+
+func (e *latexError) Unwrap() error { return e.err }
+
+// latexEngine is an installed LaTeX engine and the path to its binary
+type latexEngine struct {
+	name string
+	path string
+}
+
+// findLaTeXEngines resolves the configured engine to the installed engines
+// to try, in order; "auto" or an empty name takes every one of autoEngines
+// found
+func findLaTeXEngines(name string) ([]latexEngine, error) {
+	candidates := autoEngines
+	switch name = strings.ToLower(strings.TrimSpace(name)); name {
+	case "", engineAuto:
+	case engineLatexmk, engineXeLaTeX, engineTectonic:
+		candidates = []string{name}
+	default:
+		return nil, core.NewConfigError("", "latex_engine",
+			fmt.Sprintf("unknown LaTeX engine %q, expected auto, latexmk, xelatex or tectonic", name), nil)
+	}
+
+	var engines []latexEngine
+	for _, engine := range candidates {
+		if path, err := exec.LookPath(engine); err == nil {
+			engines = append(engines, latexEngine{name: engine, path: path})
+		}
+	}
+	if len(engines) == 0 {
+		return nil, fmt.Errorf("%w: install %s", errNoLaTeXEngine, orList(candidates))
+	}
+	return engines, nil
+}
+
+// findLaTeXEngine resolves the configured engine to the first installed one
+// and its path
+func findLaTeXEngine(name string) (string, string, error) {
+	engines, err := findLaTeXEngines(name)
+	if err != nil {
+		return "", "", err
+	}
+	return engines[0].name, engines[0].path, nil
+}
+
+// orList joins names as "a, b or c"
+func orList(names []string) string {
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
+
+// engineRuns returns the argument lists to compile rootFile with engine.
+// Plain XeLaTeX runs twice when dependency arrows need the TikZ coordinates
+// remembered by the first pass; latexmk and tectonic rerun by themselves.
+func engineRuns(engine, rootFile string, passes int) [][]string {
+	switch engine {
+	case engineLatexmk:
+		return [][]string{{"-xelatex", "-interaction=nonstopmode", "-file-line-error", rootFile}}
+	case engineTectonic:
+		return [][]string{{"--keep-logs", "--keep-intermediates", rootFile}}
+	default:
+		runs := make([][]string, passes)
+		for i := range runs {
+			runs[i] = []string{"-interaction=nonstopmode", "-file-line-error", rootFile}
+		}
+		return runs
+	}
+}
+
+// compileLaTeXToPDF compiles the root document in the latex directory with
+// the configured engine, then moves the PDF to the pdfs directory and the
+// auxiliary files to the auxiliary directory. With "auto", an engine that
+// fails or writes no PDF is followed by the next one installed; when all of
+// them fail, the error holds each engine's failure.
+func compileLaTeXToPDF(cfg core.Config, rootFile string) error {
+	latexDir := filepath.Join(cfg.OutputDir, "latex")
+	pdfDir := filepath.Join(cfg.OutputDir, "pdfs")
+	auxDir := filepath.Join(cfg.OutputDir, "auxiliary")
+
+	if _, err := os.Stat(filepath.Join(latexDir, rootFile)); err != nil {
+		return fmt.Errorf("root LaTeX file not found: %w", err)
+	}
+	engines, err := findLaTeXEngines(cfg.LaTeXEngine)
+	if err != nil {
+		return err
+	}
+
+	passes := 1
+	if cfg.Layout.TaskStyling.ShowDependencies {
+		passes = 2
+	}
+	baseName := strings.TrimSuffix(rootFile, texExtension)
+	pdfFile := baseName + ".pdf"
+
+	var failures []error
+	for _, engine := range engines {
+		// A PDF left by an earlier engine must not pass for this one's
+		_ = os.Remove(filepath.Join(latexDir, pdfFile))
+		if err := runLaTeXEngine(engine, latexDir, rootFile, passes, cfg.Tasks); err != nil {
+			logger.Debug("%v", err)
+			failures = append(failures, err)
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(latexDir, pdfFile)); err != nil {
+			failures = append(failures, fmt.Errorf("%s failed: no %s was written", engine.name, pdfFile))
+			continue
+		}
+		logger.Debug("Compiled %s with %s", rootFile, engine.name)
+		failures = nil
+		break
+	}
+	if len(failures) > 0 {
+		return errors.Join(failures...)
+	}
+
+	// Move the PDF to the pdfs directory
+	if info, err := os.Stat(filepath.Join(latexDir, pdfFile)); err == nil {
+		if err := os.Rename(filepath.Join(latexDir, pdfFile), filepath.Join(pdfDir, pdfFile)); err != nil {
+			logger.Warn("Failed to move PDF file: %v", err)
+		} else {
+			recordOutput(filepath.Join(pdfDir, pdfFile), int(info.Size()))
+		}
+	}
+
+	// Move auxiliary files to the auxiliary directory
+	auxFiles := []string{".aux", ".log", ".fdb_latexmk", ".fls", ".synctex.gz", ".tmp", ".xdv"}
+	for _, ext := range auxFiles {
+		auxFile := baseName + ext
+		if _, err := os.Stat(filepath.Join(latexDir, auxFile)); err == nil {
+			if err := os.Rename(filepath.Join(latexDir, auxFile), filepath.Join(auxDir, auxFile)); err != nil {
+				logger.Warn("Failed to move auxiliary file %s: %v", auxFile, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// runLaTeXEngine runs every pass of engine over rootFile in latexDir,
+// describing the errors of its log when a pass fails
+func runLaTeXEngine(engine latexEngine, latexDir, rootFile string, passes int, tasks []core.Task) error {
+	baseName := strings.TrimSuffix(rootFile, texExtension)
+	for _, args := range engineRuns(engine.name, rootFile, passes) {
+		cmd := exec.Command(engine.path, args...)
+		cmd.Dir = latexDir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			logFile := filepath.Join(latexDir, baseName+".log")
+			log, readErr := os.ReadFile(logFile)
+			if readErr != nil {
+				log, logFile = output, ""
+			}
+			return &latexError{engine: engine.name, logFile: logFile, messages: describeLaTeXErrors(log, tasks), err: err}
+		}
+	}
+	return nil
+}
+
+// latexIssue is an error from the LaTeX log, with the task and day being
+// typeset when it happened, from the source markers of the monthly pages
+type latexIssue struct {
+	message string // Without the "!" or file:line: prefix
+	context string // The "l.NN ..." line, when given
+	raw     string // The error line as logged
+	task    string // Source ID of the task, if any
+	day     string // YYYY-MM-DD, if any
+}
+
+// describe renders the issue, naming the task and day it came from when
+// known; tasks maps source IDs to the tasks
+func (i latexIssue) describe(tasks map[string]core.Task) string {
+	var where string
+	if i.task != "" {
+		where = "task " + i.task
+		if task, ok := tasks[i.task]; ok {
+			where = fmt.Sprintf("task %s '%s'", task.ID, task.Name)
+		}
+	}
+	switch {
+	case where != "" && i.day != "":
+		where += " on " + i.day
+	case i.day != "":
+		where = "day " + i.day
+	}
+	msg := i.raw
+	if where != "" {
+		msg = where + " produced invalid LaTeX: " + i.message
+	}
+	if i.context != "" {
+		msg += " (" + i.context + ")"
+	}
+	return msg
+}
+
+// latexErrors picks the errors out of a LaTeX log or engine output: "!
+// message" lines and file:line: errors from -file-line-error, with the
+// "l.NN" line that follows them and the last task and day markers before
+// them
+func latexErrors(log []byte) []latexIssue {
+	var issues []latexIssue
+	scanner := bufio.NewScanner(bytes.NewReader(log))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var pending *latexIssue
+	flush := func() {
+		if pending != nil {
+			issues = append(issues, *pending)
+			pending = nil
+		}
+	}
+	task, day := "", ""
+	for scanner.Scan() && len(issues) < maxLaTeXErrors {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, cal.SourceMarkerPrefix); i >= 0 {
+			kind, id, _ := strings.Cut(line[i+len(cal.SourceMarkerPrefix):], "=")
+			switch kind {
+			case cal.SourceDay:
+				day, task = id, ""
+			case cal.SourceTask:
+				task = id
+			case cal.SourceEnd:
+				day, task = "", ""
+			}
+			continue
+		}
+
+		switch {
+		case pending != nil && strings.HasPrefix(line, "l."):
+			pending.context = line
+			flush()
+		case strings.HasPrefix(line, "! "):
+			flush()
+			pending = &latexIssue{message: strings.TrimPrefix(line, "! "), raw: strings.TrimPrefix(line, "! "), task: task, day: day}
+		case isFileLineError(line):
+			flush()
+			_, rest, _ := strings.Cut(line, texExtension+":")
+			_, msg, _ := strings.Cut(rest, ": ")
+			pending = &latexIssue{message: msg, raw: line, task: task, day: day}
+		}
+	}
+	if len(issues) < maxLaTeXErrors {
+		flush()
+	}
+	return issues
+}
+
+// describeLaTeXErrors describes the errors of a LaTeX log with the names of
+// the tasks they came from
+func describeLaTeXErrors(log []byte, tasks []core.Task) []string {
+	byID := make(map[string]core.Task, len(tasks))
+	for _, t := range tasks {
+		byID[cal.SourceID(t.ID)] = t
+	}
+	var messages []string
+	for _, issue := range latexErrors(log) {
+		messages = append(messages, issue.describe(byID))
+	}
+	return messages
+}
+
+// isFileLineError reports whether line is a -file-line-error message, such
+// as "./monthly.tex:12: Undefined control sequence."
+func isFileLineError(line string) bool {
+	file, rest, ok := strings.Cut(line, texExtension+":")
+	if !ok || file == "" || strings.ContainsAny(file, " ()") {
+		return false
+	}
+	num, msg, ok := strings.Cut(rest, ": ")
+	if !ok || num == "" || msg == "" {
+		return false
+	}
+	for _, r := range num {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"phd-dissertation-planner/internal/core"
+)
+
+func TestDescribeLaTeXErrors(t *testing.T) {
+	log := "This is XeTeX, Version 3.141592653\n" +
+		"(./config.tex (./monthly.tex\n" +
+		"plannergen:day=2026-03-13\n" +
+		"plannergen:end\n" +
+		"./monthly.tex:42: Undefined control sequence.\n" +
+		"l.42 \\badmacro\n" +
+		"                {x}\n" +
+		"plannergen:day=2026-03-14\n" +
+		"plannergen:task=T4.2\n" +
+		"plannergen:task=T4-3\n" +
+		"! Missing $ inserted.\n" +
+		"<inserted text>\n" +
+		"l.57 }\n" +
+		"Overfull \\hbox (1.2pt too wide) in paragraph at lines 3--4\n" +
+		"plannergen:end\n" +
+		"! Emergency stop.\n"
+	tasks := []core.Task{{ID: "T4.2", Name: "Pilot study"}, {ID: "T4_3", Name: "RNA-seq analysis"}}
+
+	got := describeLaTeXErrors([]byte(log), tasks)
+	want := []string{
+		"./monthly.tex:42: Undefined control sequence. (l.42 \\badmacro)",
+		"task T4_3 'RNA-seq analysis' on 2026-03-14 produced invalid LaTeX: Missing $ inserted. (l.57 })",
+		"Emergency stop.",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("describeLaTeXErrors = %q, want %q", got, want)
+	}
+}
+
+// fakeEngines puts shell scripts named after LaTeX engines first on PATH,
+// and nothing else
+func fakeEngines(t *testing.T, scripts map[string]string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake engines are shell scripts")
+	}
+	dir := t.TempDir()
+	for name, body := range scripts {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+body+"\n"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", dir)
+}
+
+// compileDirs returns a config writing to a temporary output directory with
+// a root document in place
+func compileDirs(t *testing.T) core.Config {
+	t.Helper()
+	out := t.TempDir()
+	for _, sub := range []string{"latex", "pdfs", "auxiliary"} {
+		if err := os.MkdirAll(filepath.Join(out, sub), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(out, "latex", "planner.tex"), []byte("\\documentclass{article}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return core.Config{OutputDir: out}
+}
+
+func TestCompileFallsBackWhenAnEngineFails(t *testing.T) {
+	fakeEngines(t, map[string]string{
+		"latexmk":  "echo '! Undefined control sequence.' >&2; exit 1",
+		"xelatex":  "echo pdf > planner.pdf",
+		"tectonic": "exit 1",
+	})
+	cfg := compileDirs(t)
+
+	if err := compileLaTeXToPDF(cfg, "planner.tex"); err != nil {
+		t.Fatalf("compileLaTeXToPDF = %v, want the xelatex fallback to succeed", err)
+	}
+	if _, err := os.Stat(filepath.Join(cfg.OutputDir, "pdfs", "planner.pdf")); err != nil {
+		t.Errorf("PDF not moved to pdfs: %v", err)
+	}
+	takeOutputs()
+}
+
+func TestCompileReportsEveryEngineFailure(t *testing.T) {
+	fakeEngines(t, map[string]string{
+		"latexmk": "exit 12",
+		"xelatex": "exit 0",
+	})
+	cfg := compileDirs(t)
+
+	err := compileLaTeXToPDF(cfg, "planner.tex")
+	if err == nil {
+		t.Fatal("compileLaTeXToPDF succeeded without a PDF")
+	}
+	for _, want := range []string{"latexmk failed: exit status 12", "xelatex failed: no planner.pdf was written"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// completionCommand prints a shell completion script
+func completionCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "completion",
+		Usage:     "print a completion script for bash, zsh or fish",
+		UsageText: "plannergen completion bash|zsh|fish",
+		Description: `Load the completions in the current shell, or add the line to its startup file:
+
+   bash:  source <(plannergen completion bash)
+   zsh:   source <(plannergen completion zsh)
+   fish:  plannergen completion fish | source`,
+		Action: runCompletion,
+	}
+}
+
+// runCompletion writes the script for the requested shell to stdout
+func runCompletion(c *cli.Context) error {
+	var script string
+	switch shell := c.Args().First(); shell {
+	case "bash":
+		script = bashCompletion(c.App)
+	case "zsh":
+		script = zshCompletion(c.App)
+	case "fish":
+		fish, err := c.App.ToFishCompletion()
+		if err != nil {
+			return formatError("Completion", "Unable to generate fish completions", err)
+		}
+		script = fish
+	default:
+		return formatError("Completion", fmt.Sprintf("Unknown shell %q", shell), fmt.Errorf("expected bash, zsh or fish"),
+			"Run: plannergen completion bash")
+	}
+	_, err := fmt.Fprint(c.App.Writer, script)
+	return err
+}
+
+// flagSpec describes a flag for the completion scripts
+type flagSpec struct {
+	names []string // With dashes, e.g. "--config"
+	usage string
+	value bool // Takes a value
+	path  bool // The value is a file or directory
+}
+
+// flagSpecs lists the visible flags, adding --help when missing as the
+// subcommands only get it when they run
+func flagSpecs(flags []cli.Flag) []flagSpec {
+	hasHelp := false
+	for _, f := range flags {
+		for _, name := range f.Names() {
+			hasHelp = hasHelp || name == "help"
+		}
+	}
+	if !hasHelp {
+		flags = append(flags, cli.HelpFlag)
+	}
+
+	specs := make([]flagSpec, 0, len(flags))
+	for _, f := range flags {
+		if vf, ok := f.(cli.VisibleFlag); ok && !vf.IsVisible() {
+			continue
+		}
+		var spec flagSpec
+		for _, name := range f.Names() {
+			if len(name) == 1 {
+				spec.names = append(spec.names, "-"+name)
+			} else {
+				spec.names = append(spec.names, "--"+name)
+			}
+		}
+		if df, ok := f.(cli.DocGenerationFlag); ok {
+			spec.usage = df.GetUsage()
+			spec.value = df.TakesValue()
+		}
+		switch f := f.(type) {
+		case *cli.PathFlag:
+			spec.path = true
+		case *cli.StringSliceFlag:
+			spec.path = f.TakesFile
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+// visibleCommands lists the subcommands shown in help
+func visibleCommands(app *cli.App) []*cli.Command {
+	var commands []*cli.Command
+	for _, cmd := range app.Commands {
+		if !cmd.Hidden {
+			commands = append(commands, cmd)
+		}
+	}
+	return commands
+}
+
+// bashCompletion completes subcommands and the flags of the global level or
+// of the subcommand already typed. Values of path flags and positional
+// arguments fall back to file names.
+func bashCompletion(app *cli.App) string {
+	words := func(specs []flagSpec) (flags, paths []string) {
+		for _, s := range specs {
+			flags = append(flags, s.names...)
+			if s.path {
+				paths = append(paths, s.names...)
+			}
+		}
+		return flags, paths
+	}
+
+	var names, cases, pathFlags []string
+	for _, cmd := range visibleCommands(app) {
+		names = append(names, cmd.Names()...)
+		flags, paths := words(flagSpecs(cmd.Flags))
+		pathFlags = append(pathFlags, paths...)
+		cases = append(cases, fmt.Sprintf("        %s) opts=%q ;;", strings.Join(cmd.Names(), "|"), strings.Join(flags, " ")))
+	}
+	globalFlags, paths := words(flagSpecs(app.Flags))
+	pathFlags = append(pathFlags, paths...)
+	cases = append(cases, fmt.Sprintf("        *) opts=%q ;;", strings.Join(append(globalFlags, names...), " ")))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# bash completion for %s\n", app.Name)
+	fmt.Fprintf(&sb, "_%s() {\n", app.Name)
+	sb.WriteString("    local cur=\"${COMP_WORDS[COMP_CWORD]}\" prev=\"${COMP_WORDS[COMP_CWORD-1]}\" cmd=\"\" opts i\n")
+	fmt.Fprintf(&sb, "    case \"$prev\" in\n        %s) return ;;\n    esac\n", strings.Join(pathFlags, "|"))
+	sb.WriteString("    for ((i = 1; i < COMP_CWORD; i++)); do\n")
+	fmt.Fprintf(&sb, "        case \"${COMP_WORDS[i]}\" in\n            %s) cmd=\"${COMP_WORDS[i]}\"; break ;;\n        esac\n", strings.Join(names, "|"))
+	sb.WriteString("    done\n")
+	sb.WriteString("    case \"$cmd\" in\n" + strings.Join(cases, "\n") + "\n    esac\n")
+	sb.WriteString("    COMPREPLY=($(compgen -W \"$opts\" -- \"$cur\"))\n")
+	sb.WriteString("}\n")
+	fmt.Fprintf(&sb, "complete -o default -F _%s %s\n", app.Name, app.Name)
+	return sb.String()
+}
+
+// zshCompletion describes every flag and subcommand to _arguments
+func zshCompletion(app *cli.App) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "#compdef %s\n\n", app.Name)
+	fmt.Fprintf(&sb, "_%s() {\n", app.Name)
+	sb.WriteString("    local -a commands\n    local state line\n\n    commands=(\n")
+	for _, cmd := range visibleCommands(app) {
+		for _, name := range cmd.Names() {
+			fmt.Fprintf(&sb, "        %s\n", zshQuote(name+":"+cmd.Usage))
+		}
+	}
+	sb.WriteString("    )\n\n    _arguments -C \\\n")
+	for _, spec := range flagSpecs(app.Flags) {
+		fmt.Fprintf(&sb, "        %s \\\n", zshFlag(spec))
+	}
+	sb.WriteString("        '1: :->command' \\\n        '*:: :->args'\n\n")
+	sb.WriteString("    case $state in\n        command) _describe 'command' commands ;;\n        args)\n            case $line[1] in\n")
+	for _, cmd := range visibleCommands(app) {
+		fmt.Fprintf(&sb, "                %s)\n                    _arguments \\\n", strings.Join(cmd.Names(), "|"))
+		for _, spec := range flagSpecs(cmd.Flags) {
+			fmt.Fprintf(&sb, "                        %s \\\n", zshFlag(spec))
+		}
+		sb.WriteString("                        '*:file:_files' ;;\n")
+	}
+	sb.WriteString("            esac ;;\n    esac\n}\n\n")
+	fmt.Fprintf(&sb, "compdef _%s %s\n", app.Name, app.Name)
+	return sb.String()
+}
+
+// zshFlag renders a flag as an _arguments spec
+func zshFlag(spec flagSpec) string {
+	usage := strings.NewReplacer("[", `\[`, "]", `\]`).Replace(spec.usage)
+	action := ""
+	switch {
+	case spec.path:
+		action = ":file:_files"
+	case spec.value:
+		action = ":value: "
+	}
+
+	if len(spec.names) == 1 {
+		return zshQuote(spec.names[0] + "[" + usage + "]" + action)
+	}
+	exclusive := "(" + strings.Join(spec.names, " ") + ")"
+	return zshQuote(exclusive) + "{" + strings.Join(spec.names, ",") + "}" + zshQuote("["+usage+"]"+action)
+}
+
+// zshQuote single-quotes s for zsh
+func zshQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"phd-dissertation-planner/internal/core"
+)
+
+// coverTpl is the built-in template for the cover page
+const coverTpl = "cover.tpl"
+
+// Cover composes the cover page from the cover settings and the months the
+// planner covers
+func Cover(cfg core.Config, tpls []string) (core.Modules, error) {
+	tplName := coverTpl
+	if len(tpls) > 0 {
+		tplName = tpls[0]
+	}
+
+	logo, err := coverLogo(cfg.Cover.Logo)
+	if err != nil {
+		return nil, err
+	}
+
+	return core.Modules{{
+		Cfg: cfg,
+		Tpl: tplName,
+		Body: map[string]interface{}{
+			"Cover":     cfg.Cover,
+			"Logo":      logo,
+			"DateRange": coverDateRange(cfg),
+		},
+	}}, nil
+}
+
+// coverLogo returns the absolute path of the logo, as LaTeX runs in the
+// output directory, or "" when no logo is set
+func coverLogo(path string) (string, error) {
+	if path = strings.TrimSpace(path); path == "" {
+		return "", nil
+	}
+	abs, err := filepath.Abs(path)
+	if err == nil {
+		_, err = os.Stat(abs)
+	}
+	if err != nil {
+		return "", core.NewConfigError("", "cover.logo", fmt.Sprintf("cannot read logo %s", path), err)
+	}
+	return filepath.ToSlash(abs), nil
+}
+
+// coverDateRange returns the first and last month of the planner, such as
+// "March 2025 -- July 2027" (an en dash in LaTeX), or "" without months
+func coverDateRange(cfg core.Config) string {
+	months := cfg.MonthsWithTasks
+	if len(months) == 0 {
+		return ""
+	}
+	loc := cfg.GetLocale()
+	first, last := months[0], months[len(months)-1]
+	from := fmt.Sprintf("%s %d", loc.MonthName(first.Month), first.Year)
+	to := fmt.Sprintf("%s %d", loc.MonthName(last.Month), last.Year)
+	if from == to {
+		return from
+	}
+	return from + " -- " + to
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"time"
+
+	cal "phd-dissertation-planner/internal/calendar"
+	"phd-dissertation-planner/internal/core"
+)
+
+// dailyTpl is the built-in template for daily agenda pages
+const dailyTpl = "daily.tpl"
+
+// Daily composes one agenda page per day listing the tasks active on it.
+// Monthly grid cells link to these pages when a "daily" page is configured.
+func Daily(cfg core.Config, tpls []string) (core.Modules, error) {
+	tplName := dailyTpl
+	if len(tpls) > 0 {
+		tplName = tpls[0]
+	}
+
+	days := agendaDays(cfg)
+	modules := make(core.Modules, 0, len(days))
+
+	for i, day := range days {
+		var prev, next time.Time
+		if i > 0 {
+			prev = days[i-1].Time
+		}
+		if i < len(days)-1 {
+			next = days[i+1].Time
+		}
+
+		// Each day's month overrides come with the day
+		dayCfg := *day.Cfg
+		modules = append(modules, core.Module{
+			Cfg:  dayCfg,
+			Tpl:  tplName,
+			Date: day.Time,
+			Body: map[string]interface{}{
+				"Day":        day,
+				"DayRef":     day.DailyRef(),
+				"Title":      day.Title(),
+				"Breadcrumb": day.DailyBreadcrumb(),
+				"Extra":      day.DailyPrevNext(prev, next).WithTopRightCorner(cfg.ClearTopRightCorner, cfg.Layout.Calendar.TaskKernSpacing),
+				"NotesLines": make([]struct{}, dayCfg.Daily.NotesLines),
+				"Locale":     cfg.GetLocale(),
+			},
+		})
+	}
+
+	return modules, nil
+}
+
+// agendaDays returns the days of the months with tasks that get an agenda page
+func agendaDays(cfg core.Config) []cal.Day {
+	tasks := cfg.VisibleTasks()
+	var days []cal.Day
+
+	for _, monthYear := range cfg.MonthsWithTasks {
+		monthCfg := cfg.ForMonth(monthYear.Year, monthYear.Month)
+		year := cal.NewYear(cfg.WeekStart, monthYear.Year, &monthCfg)
+		month := year.Quarters[(int(monthYear.Month)-1)/3].Months[(int(monthYear.Month)-1)%3]
+		assignTasksToMonth(month, tasks)
+
+		for _, week := range month.Weeks {
+			for _, day := range week.Days {
+				if day.Time.IsZero() || day.Time.Month() != month.Month {
+					continue
+				}
+				if monthCfg.Daily.SkipEmpty && len(day.Tasks) == 0 {
+					continue
+				}
+				days = append(days, day)
+			}
+		}
+	}
+
+	return days
+}
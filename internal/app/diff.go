@@ -0,0 +1,104 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"phd-dissertation-planner/internal/core"
+
+	"github.com/urfave/cli/v2"
+)
+
+// diffCommand compares two versions of a task CSV
+func diffCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "diff",
+		Usage:     "list the tasks added, removed and modified between two CSV files, by phase",
+		UsageText: "plannergen diff old.csv new.csv",
+		Action:    runDiff,
+	}
+}
+
+// runDiff prints the changes from the first CSV file to the second
+func runDiff(c *cli.Context) error {
+	if c.Args().Len() != 2 {
+		return formatError("Diff", "Expected two CSV files", fmt.Errorf("got %d argument(s)", c.Args().Len()),
+			"Run: plannergen diff old.csv new.csv")
+	}
+	oldPath, newPath := c.Args().Get(0), c.Args().Get(1)
+
+	old, err := core.NewReader(oldPath).ReadTasks()
+	if err != nil {
+		return formatError("Diff", "Unable to read "+oldPath, err)
+	}
+	revised, err := core.NewReader(newPath).ReadTasks()
+	if err != nil {
+		return formatError("Diff", "Unable to read "+newPath, err)
+	}
+
+	diffs := core.DiffTasks(old, revised)
+	fmt.Println(core.BoldText(fmt.Sprintf("📝 Changes from %s to %s", oldPath, newPath)))
+	fmt.Println(core.DimText("═══════════════════════════════════════"))
+	if len(diffs) == 0 {
+		fmt.Println(core.Success("✅ The plans match"))
+		return nil
+	}
+
+	// Group by phase, in order of first appearance
+	var phases []string
+	byPhase := make(map[string][]core.TaskDiff)
+	for _, d := range diffs {
+		if _, ok := byPhase[d.Phase]; !ok {
+			phases = append(phases, d.Phase)
+		}
+		byPhase[d.Phase] = append(byPhase[d.Phase], d)
+	}
+
+	added, removed, modified := 0, 0, 0
+	for _, phase := range phases {
+		title := phase
+		if strings.TrimSpace(title) == "" {
+			title = "(no phase)"
+		}
+		fmt.Println(core.CyanText(title))
+		for _, d := range byPhase[phase] {
+			switch {
+			case d.Added:
+				added++
+				fmt.Println(core.Success("   + " + d.String()))
+			case d.Removed:
+				removed++
+				fmt.Println(core.Error("   - " + d.String()))
+			default:
+				modified++
+				fmt.Printf("   ~ %s\n", describeTaskDiff(d))
+			}
+		}
+	}
+
+	fmt.Println(core.DimText("═══════════════════════════════════════"))
+	fmt.Printf("%d added, %d removed, %d modified\n", added, removed, modified)
+	return nil
+}
+
+// describeTaskDiff phrases the date shifts and field changes of a modified task
+func describeTaskDiff(d core.TaskDiff) string {
+	var parts []string
+	if d.StartShift() != 0 {
+		parts = append(parts, fmt.Sprintf("starts %s -> %s (%+dd)",
+			d.CurrentStart.Format("2006-01-02"), d.ProposedStart.Format("2006-01-02"), d.StartShift()))
+	}
+	if d.EndShift() != 0 {
+		parts = append(parts, fmt.Sprintf("ends %s -> %s (%+dd)",
+			d.CurrentEnd.Format("2006-01-02"), d.ProposedEnd.Format("2006-01-02"), d.EndShift()))
+	}
+	for _, change := range d.Changes {
+		parts = append(parts, change.String())
+	}
+
+	label := d.ID + " " + d.Name
+	if d.IsMilestone {
+		label = "★ " + label
+	}
+	return label + ": " + strings.Join(parts, "; ")
+}
@@ -0,0 +1,385 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"phd-dissertation-planner/internal/core"
+	"phd-dissertation-planner/internal/templates"
+
+	"github.com/urfave/cli/v2"
+)
+
+// documentTemplate is the template holding the LaTeX preamble
+const documentTemplate = "document.tpl"
+
+// latinModernFiles are the TeX font files of Latin Modern Sans, the document
+// font set by the preamble with lmodern and \sfdefault
+var latinModernFiles = []string{"t1lmss.fd", "ec-lmss10.tfm", "lmss10.pfb"}
+
+// texLivePackages maps the preamble packages shipped in a differently named
+// TeX Live package to that package, for the tlmgr fix
+var texLivePackages = map[string]string{
+	"expl3":    "l3kernel",
+	"xparse":   "l3packages",
+	"tikz":     "pgf",
+	"pgffor":   "pgf",
+	"amssymb":  "amsfonts",
+	"inputenc": "latex",
+	"fontenc":  "latex",
+	"lmodern":  "lm",
+	"calc":     "tools",
+	"array":    "tools",
+	"tabularx": "tools",
+	"graphicx": "graphics",
+}
+
+// usePackage matches \usepackage[options]{a,b} in a preamble
+var usePackage = regexp.MustCompile(`\\usepackage(?:\[[^\]]*\])?\{([^}]*)\}`)
+
+// Doctor check statuses
+const (
+	checkOK = iota
+	checkWarn
+	checkFail
+)
+
+// doctorCheck is the outcome of one environment check, with the fix to
+// apply when it did not pass
+type doctorCheck struct {
+	name   string
+	status int
+	detail string
+	fix    string
+}
+
+// doctorCommand checks that the environment can generate and compile the
+// planner
+func doctorCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "doctor",
+		Usage:     "check the LaTeX engine, LaTeX packages, fonts, output directory and configuration",
+		UsageText: "plannergen [--config FILE] [--latex-engine NAME] [--outdir DIR] doctor",
+		Action:    runDoctor,
+	}
+}
+
+// runDoctor prints one line per check with the fixes for the failed ones,
+// and fails when any check failed
+func runDoctor(c *cli.Context) error {
+	failOnWarn, err := failOnWarnings(c)
+	if err != nil {
+		return err
+	}
+
+	cfg, configCheck := checkConfig(c)
+	engineCheck, engine := checkLaTeXEngine(cfg)
+	checks := []doctorCheck{
+		configCheck,
+		engineCheck,
+		checkLaTeXPackages(cfg, engine),
+		checkFonts(engine),
+		checkOutputDir(cfg.OutputDir),
+	}
+
+	failed, warned := 0, 0
+	fmt.Println("🩺 Environment Check")
+	fmt.Println("═══════════════════════════════════════")
+	for _, check := range checks {
+		switch check.status {
+		case checkFail:
+			failed++
+		case checkWarn:
+			warned++
+		}
+		printDoctorCheck(check)
+	}
+	fmt.Println("═══════════════════════════════════════")
+
+	summary := fmt.Sprintf("%d problem(s), %d warning(s)", failed, warned)
+	switch {
+	case failed > 0:
+		fmt.Println(core.Error("❌ Environment not ready: " + summary))
+		return fmt.Errorf("environment check failed: %s", summary)
+	case failOnWarn && warned > 0:
+		fmt.Println(core.Error("❌ Environment check failed: " + summary))
+		return warningsError(warned)
+	case warned > 0:
+		fmt.Println(core.Warning("⚠️  Environment ready with " + summary))
+	default:
+		fmt.Println(core.Success("✅ Environment ready"))
+	}
+	return nil
+}
+
+// printDoctorCheck prints a check with its status, and its fix unless it
+// passed
+func printDoctorCheck(check doctorCheck) {
+	switch check.status {
+	case checkFail:
+		fmt.Printf("%s %s: %s\n", core.Error("❌"), check.name, check.detail)
+	case checkWarn:
+		fmt.Printf("%s %s: %s\n", core.Warning("⚠️ "), check.name, check.detail)
+	default:
+		fmt.Printf("%s %s: %s\n", core.Success("✅"), check.name, core.DimText(check.detail))
+	}
+	if check.status != checkOK && check.fix != "" {
+		fmt.Println(core.DimText("   → Fix: " + check.fix))
+	}
+}
+
+// checkConfig loads the configuration as generation would and validates each
+// config file. On failure the defaults are returned so the other checks can
+// still run.
+func checkConfig(c *cli.Context) (core.Config, doctorCheck) {
+	check := doctorCheck{name: "Configuration"}
+	paths := configPaths(c)
+	cfg, err := core.NewConfigWithOverrides(setOverrides(c), paths...)
+	if err != nil {
+		cfg, _ = core.NewConfig()
+		applyCLIOverrides(c, &cfg)
+		check.status, check.detail = checkFail, err.Error()
+		check.fix = "Correct the config file or --set value above; `plannergen init` writes a working starter config"
+		return cfg, check
+	}
+	applyCLIOverrides(c, &cfg)
+
+	var problems []string
+	for _, path := range paths {
+		result, err := core.NewConfigValidator().ValidateConfigFile(path)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		for _, issue := range result.Errors {
+			problems = append(problems, issue.Message)
+		}
+	}
+	check.detail = strings.Join(paths, ", ")
+	if len(problems) > 0 {
+		check.status, check.detail = checkFail, problems[0]
+		if len(problems) > 1 {
+			check.detail += fmt.Sprintf(" (and %d more)", len(problems)-1)
+		}
+		check.fix = "Run `plannergen validate` to list the problems with their fixes"
+	}
+	return cfg, check
+}
+
+// checkLaTeXEngine finds the configured LaTeX engine; latexmk also needs
+// xelatex, which it drives. It returns the engine found, if any.
+func checkLaTeXEngine(cfg core.Config) (doctorCheck, string) {
+	check := doctorCheck{name: "LaTeX engine"}
+	engine, path, err := findLaTeXEngine(cfg.LaTeXEngine)
+	var configErr *core.ConfigError
+	switch {
+	case errors.As(err, &configErr):
+		check.status, check.detail = checkFail, configErr.Message
+		check.fix = "Set latex_engine to auto, latexmk, xelatex or tectonic, or pass --latex-engine"
+		return check, ""
+	case err != nil:
+		check.status, check.detail = checkFail, err.Error()
+		check.fix = "Install TeX Live (https://tug.org/texlive/) or tectonic (https://tectonic-typesetting.github.io/), or write only the LaTeX with --compile=false"
+		return check, ""
+	}
+
+	check.detail = fmt.Sprintf("%s (%s)", engine, path)
+	if engine == engineLatexmk {
+		if _, err := exec.LookPath(engineXeLaTeX); err != nil {
+			check.status = checkFail
+			check.detail = fmt.Sprintf("latexmk found at %s, but not xelatex", path)
+			check.fix = "Install XeLaTeX (the xetex package of TeX Live), or set latex_engine to tectonic"
+		}
+	}
+	return check, engine
+}
+
+// checkLaTeXPackages looks up the packages of the document preamble with
+// kpsewhich. Tectonic downloads packages when first used, so it needs none.
+func checkLaTeXPackages(cfg core.Config, engine string) doctorCheck {
+	check := doctorCheck{name: "LaTeX packages"}
+	packages, err := preamblePackages(cfg.TemplatesDir, cfg.Layout.LaTeX.Document.Preamble)
+	if err != nil {
+		check.status, check.detail = checkFail, err.Error()
+		check.fix = "Check that " + documentTemplate + " in the templates directory parses"
+		return check
+	}
+
+	switch engine {
+	case "":
+		check.status, check.detail = checkWarn, "skipped, no LaTeX engine"
+		return check
+	case engineTectonic:
+		check.detail = fmt.Sprintf("%d package(s), downloaded by tectonic when first used", len(packages))
+		return check
+	}
+
+	files := make([]string, len(packages))
+	for i, pkg := range packages {
+		files[i] = pkg + ".sty"
+	}
+	missing, err := missingTeXFiles(files)
+	if err != nil {
+		check.status, check.detail = checkWarn, err.Error()
+		check.fix = "Install kpsewhich with your TeX distribution to check the packages"
+		return check
+	}
+	if len(missing) > 0 {
+		var names, install []string
+		seen := make(map[string]bool)
+		for _, file := range missing {
+			name := strings.TrimSuffix(file, ".sty")
+			names = append(names, name)
+			if bundle, ok := texLivePackages[name]; ok {
+				name = bundle
+			}
+			if !seen[name] {
+				seen[name] = true
+				install = append(install, name)
+			}
+		}
+		check.status = checkFail
+		check.detail = "missing " + strings.Join(names, ", ")
+		check.fix = "tlmgr install " + strings.Join(install, " ") + " (or your distribution's texlive-latex-extra package)"
+		return check
+	}
+	check.detail = fmt.Sprintf("all %d package(s) installed", len(packages))
+	return check
+}
+
+// checkFonts looks up the Latin Modern font files with kpsewhich
+func checkFonts(engine string) doctorCheck {
+	check := doctorCheck{name: "Fonts"}
+	switch engine {
+	case "":
+		check.status, check.detail = checkWarn, "skipped, no LaTeX engine"
+		return check
+	case engineTectonic:
+		check.detail = "Latin Modern, downloaded by tectonic when first used"
+		return check
+	}
+
+	missing, err := missingTeXFiles(latinModernFiles)
+	switch {
+	case err != nil:
+		check.status, check.detail = checkWarn, err.Error()
+		check.fix = "Install kpsewhich with your TeX distribution to check the fonts"
+	case len(missing) > 0:
+		check.status = checkFail
+		check.detail = "Latin Modern is missing " + strings.Join(missing, ", ")
+		check.fix = "tlmgr install lm (or your distribution's texlive-fonts-recommended package)"
+	default:
+		check.detail = "Latin Modern installed"
+	}
+	return check
+}
+
+// checkOutputDir checks that files can be created in the output directory,
+// or in its closest existing parent when it does not exist yet
+func checkOutputDir(outDir string) doctorCheck {
+	check := doctorCheck{name: "Output directory"}
+	dir, created := filepath.Clean(outDir), false
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				check.status, check.detail = checkFail, dir+" is not a directory"
+				check.fix = "Remove or rename " + dir + ", or choose another directory with --outdir"
+				return check
+			}
+			break
+		}
+		parent := filepath.Dir(dir)
+		if !os.IsNotExist(err) || parent == dir {
+			check.status, check.detail = checkFail, err.Error()
+			check.fix = "Choose another directory with --outdir"
+			return check
+		}
+		dir, created = parent, true
+	}
+
+	probe, err := os.CreateTemp(dir, ".plannergen-doctor-*")
+	if err != nil {
+		check.status, check.detail = checkFail, dir+" is not writable"
+		check.fix = "Run `chmod u+w " + dir + "` or choose another directory with --outdir"
+		return check
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+
+	check.detail = outDir + " is writable"
+	if created {
+		check.detail = fmt.Sprintf("%s will be created in %s", outDir, dir)
+	}
+	return check
+}
+
+// preamblePackages returns the packages loaded by the document preamble,
+// from the templates directory when it overrides the built-in one, followed
+// by those of the extra preamble. Packages loaded only under a template
+// condition, such as showframe, are left out.
+func preamblePackages(templatesDir, extra string) ([]string, error) {
+	content, err := templates.FS.ReadFile("monthly/" + documentTemplate)
+	if dir := strings.TrimSpace(templatesDir); dir != "" {
+		path := filepath.Join(dir, documentTemplate)
+		if override, readErr := os.ReadFile(path); readErr == nil {
+			content, err = override, nil
+		} else if !os.IsNotExist(readErr) {
+			return nil, core.NewFileError(path, "read", readErr)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var packages []string
+	for _, line := range strings.Split(string(content)+"\n"+extra, "\n") {
+		line, _, _ = strings.Cut(line, "%")
+		if strings.Contains(line, "{{") {
+			continue
+		}
+		for _, match := range usePackage.FindAllStringSubmatch(line, -1) {
+			for _, pkg := range strings.Split(match[1], ",") {
+				if pkg = strings.TrimSpace(pkg); pkg != "" && !seen[pkg] {
+					seen[pkg] = true
+					packages = append(packages, pkg)
+				}
+			}
+		}
+	}
+	return packages, nil
+}
+
+// missingTeXFiles returns the files kpsewhich cannot find in the TeX tree
+func missingTeXFiles(files []string) ([]string, error) {
+	path, err := exec.LookPath("kpsewhich")
+	if err != nil {
+		return nil, fmt.Errorf("kpsewhich not found, unable to check")
+	}
+	// kpsewhich prints the files it finds and exits non-zero if any is
+	// missing, so its status is not an error here
+	out, _ := exec.Command(path, files...).Output()
+	found := make(map[string]bool)
+	for _, line := range strings.Split(string(bytes.TrimSpace(out)), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			found[filepath.Base(line)] = true
+		}
+	}
+
+	var missing []string
+	for _, file := range files {
+		if !found[file] {
+			missing = append(missing, file)
+		}
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
@@ -0,0 +1,38 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"phd-dissertation-planner/internal/core"
+)
+
+// printDryRunOutputs lists the files a dry run would have produced, with
+// the PDF when compiling
+func printDryRunOutputs(cfg core.Config, files []outputFile, compile bool) {
+	fmt.Println(core.DimText("═══════════════════════════════════════"))
+	fmt.Println(core.Info(fmt.Sprintf("🧪 Dry run: %d file(s) would be written to %s", len(files), cfg.OutputDir)))
+	total := 0
+	for _, f := range files {
+		total += f.size
+		fmt.Printf("   📝 %s %s\n", f.path, core.DimText(formatSize(f.size)))
+	}
+	if compile && len(files) > 0 {
+		pdf := strings.TrimSuffix(filepath.Base(files[0].path), texExtension) + ".pdf"
+		fmt.Printf("   📄 %s %s\n", filepath.Join(cfg.OutputDir, "pdfs", pdf), core.DimText("(compiled to PDF)"))
+	}
+	fmt.Println(core.DimText(fmt.Sprintf("   %s of LaTeX in total; nothing was written", formatSize(total))))
+}
+
+// formatSize renders a byte count as B, KB or MB
+func formatSize(n int) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
@@ -0,0 +1,32 @@
+package app
+
+import (
+	cal "phd-dissertation-planner/internal/calendar"
+	"phd-dissertation-planner/internal/core"
+)
+
+// earnedValueTpl is the built-in template for the earned value page
+const earnedValueTpl = "earnedvalue.tpl"
+
+// EarnedValue composes a page with schedule and cost performance indices,
+// overall and per category
+func EarnedValue(cfg core.Config, tpls []string) (core.Modules, error) {
+	tplName := earnedValueTpl
+	if len(tpls) > 0 {
+		tplName = tpls[0]
+	}
+
+	report := cfg.ComputeEarnedValue(cfg.Tasks)
+	if report.Overall.Tasks == 0 {
+		return core.Modules{}, nil
+	}
+
+	return core.Modules{{
+		Cfg: cfg,
+		Tpl: tplName,
+		Body: map[string]interface{}{
+			"EarnedValue": cal.NewEarnedValueTable(report),
+			"Locale":      cfg.GetLocale(),
+		},
+	}}, nil
+}
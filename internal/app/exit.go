@@ -0,0 +1,67 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+// Exit statuses of the CLI, so Makefiles and CI can gate on data quality
+const (
+	ExitOK         = 0
+	ExitFatal      = 1 // The run could not complete
+	ExitValidation = 2 // The input data has errors
+	ExitWarnings   = 3 // Generated, but with warnings under --fail-on warning
+)
+
+// --fail-on severities
+const (
+	failOnError   = "error"
+	failOnWarning = "warning"
+)
+
+// exitError is an error with the exit status it should end the CLI with
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+func (e *exitError) Unwrap() error { return e.err }
+
+// withExitCode gives err the exit status code
+func withExitCode(code int, err error) error {
+	return &exitError{code: code, err: err}
+}
+
+// ExitCode returns the exit status for an error returned by the app: the
+// status it was given, or ExitFatal
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var ee *exitError
+	if errors.As(err, &ee) {
+		return ee.code
+	}
+	return ExitFatal
+}
+
+// failOnWarnings reports whether --fail-on asks for warnings to fail the run
+func failOnWarnings(c *cli.Context) (bool, error) {
+	switch c.String(fFailOn) {
+	case failOnError:
+		return false, nil
+	case failOnWarning:
+		return true, nil
+	default:
+		return false, formatError("Exit Status", fmt.Sprintf("Unknown --fail-on severity %q", c.String(fFailOn)),
+			fmt.Errorf("expected warning or error"), "Use --fail-on warning or --fail-on error")
+	}
+}
+
+// warningsError fails a run that finished with warnings
+func warningsError(count int) error {
+	return withExitCode(ExitWarnings, fmt.Errorf("⚠️  Finished with %d warning(s), failing as requested by --fail-on %s", count, failOnWarning))
+}
@@ -0,0 +1,42 @@
+package app
+
+import (
+	cal "phd-dissertation-planner/internal/calendar"
+	"phd-dissertation-planner/internal/core"
+)
+
+// ganttTpl is the built-in template for Gantt chart pages
+const ganttTpl = "gantt.tpl"
+
+// Gantt composes a classic Gantt chart (tasks as rows, time on the x-axis)
+// from the same task set as the calendar, split over as many pages as needed
+func Gantt(cfg core.Config, tpls []string) (core.Modules, error) {
+	tplName := ganttTpl
+	if len(tpls) > 0 {
+		tplName = tpls[0]
+	}
+
+	tasks := cfg.VisibleTasks()
+	spanningTasks := make([]cal.SpanningTask, 0, len(tasks))
+	for _, task := range tasks {
+		spanningTasks = append(spanningTasks, cal.CreateSpanningTask(&cfg, task, task.StartDate, task.EndDate))
+	}
+
+	pages := cal.NewGanttChart(spanningTasks, &cfg).Paginate(cfg.Gantt.RowsPerPage)
+	modules := make(core.Modules, 0, len(pages))
+	for i, chart := range pages {
+		modules = append(modules, core.Module{
+			Cfg: cfg,
+			Tpl: tplName,
+			Body: map[string]interface{}{
+				"Chart":     chart,
+				"Page":      i + 1,
+				"PageCount": len(pages),
+				"Locale":    cfg.GetLocale(),
+				"Scenario":  len(cfg.ScenarioShifts()) > 0,
+			},
+		})
+	}
+
+	return modules, nil
+}
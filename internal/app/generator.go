@@ -49,6 +49,7 @@
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
@@ -56,7 +57,6 @@
 	"os/exec"
 	"path/filepath"
 	"runtime"
-	"runtime/pprof"
 	"sort"
 	"strconv"
 	"strings"
@@ -104,76 +104,6 @@
 
 var logger = core.NewDefaultLogger()
 
-// MemoryManager handles memory profiling and cleanup
-type MemoryManager struct {
-	memoryProfile *os.File
-	heapProfile   *os.File
-}
-
-// StartMemoryProfiling starts memory and heap profiling
-func (mm *MemoryManager) StartMemoryProfiling(profileDir string) error {
-	// Create profile directory if it doesn't exist
-	if err := os.MkdirAll(profileDir, 0o755); err != nil {
-		return fmt.Errorf("failed to create profile directory: %w", err)
-	}
-
-	// Start memory profiling
-	memProfilePath := filepath.Join(profileDir, "memory.prof")
-	memFile, err := os.Create(memProfilePath)
-	if err != nil {
-		return fmt.Errorf("failed to create memory profile file: %w", err)
-	}
-	mm.memoryProfile = memFile
-
-	// Start heap profiling
-	heapProfilePath := filepath.Join(profileDir, "heap.prof")
-	heapFile, err := os.Create(heapProfilePath)
-	if err != nil {
-		mm.memoryProfile.Close()
-		return fmt.Errorf("failed to create heap profile file: %w", err)
-	}
-	mm.heapProfile = heapFile
-
-	// Start profiling
-	if err := pprof.StartCPUProfile(mm.memoryProfile); err != nil {
-		mm.cleanup()
-		return fmt.Errorf("failed to start CPU profiling: %w", err)
-	}
-
-	logger.Info("Memory profiling started - profiles will be saved to %s", profileDir)
-	return nil
-}
-
-// StopMemoryProfiling stops profiling and writes heap profile
-func (mm *MemoryManager) StopMemoryProfiling() error {
-	if mm.memoryProfile != nil {
-		pprof.StopCPUProfile()
-		mm.memoryProfile.Close()
-		logger.Info("CPU profiling stopped")
-	}
-
-	if mm.heapProfile != nil {
-		if err := pprof.WriteHeapProfile(mm.heapProfile); err != nil {
-			mm.heapProfile.Close()
-			return fmt.Errorf("failed to write heap profile: %w", err)
-		}
-		mm.heapProfile.Close()
-		logger.Info("Heap profile written")
-	}
-
-	return nil
-}
-
-// cleanup closes any open profile files
-func (mm *MemoryManager) cleanup() {
-	if mm.memoryProfile != nil {
-		mm.memoryProfile.Close()
-	}
-	if mm.heapProfile != nil {
-		mm.heapProfile.Close()
-	}
-}
-
 // Buffer pool for template rendering to reduce memory allocations
 var bufferPool = sync.Pool{
 	New: func() interface{} {
@@ -300,6 +230,30 @@ func formatError(stage, problem string, err error, suggestions ...string) error
 	return fmt.Errorf("%s", msg.String())
 }
 
+// configureLogging applies --verbose, --quiet and --log-format before any
+// command runs
+func configureLogging(c *cli.Context) error {
+	if c.Bool(fVerbose) && c.Bool(fQuiet) {
+		return formatError("Logging", "Conflicting flags", fmt.Errorf("--verbose and --quiet cannot be used together"))
+	}
+	level := ""
+	if c.Bool(fVerbose) {
+		level = core.LogLevelDebugString
+	}
+	if err := core.ConfigureLogging(level, c.String(fLogFormat), c.Bool(fQuiet)); err != nil {
+		return formatError("Logging", "Invalid logging options", err, "Use --log-format text or --log-format json")
+	}
+	return nil
+}
+
+// beforeRun sets up logging and profiling for any command
+func beforeRun(c *cli.Context) error {
+	if err := configureLogging(c); err != nil {
+		return err
+	}
+	return startProfiling(c)
+}
+
 // action is the main CLI action that orchestrates document generation or test coverage
 func action(c *cli.Context) error {
 	// Check if test coverage is requested
@@ -307,37 +261,61 @@ func action(c *cli.Context) error {
 		return runTestCoverage()
 	}
 
+	if err := checkArgs(c); err != nil {
+		return err
+	}
+
 	// Check if validation is requested
 	if c.Bool("validate") {
 		return runValidation(c)
 	}
 
-	// Check if memory profiling is enabled via environment variable
-	memProfile := os.Getenv("PLANNER_MEMORY_PROFILE") == "true"
-	var memManager *MemoryManager
-	if memProfile {
-		memManager = &MemoryManager{}
-		profileDir := os.Getenv("PLANNER_PROFILE_DIR")
-		if profileDir == "" {
-			profileDir = "profiles"
-		}
-		if err := memManager.StartMemoryProfiling(profileDir); err != nil {
-			logger.Warn("Failed to start memory profiling: %v", err)
-		}
-	}
+	return generate(c, c.Bool(fCompile))
+}
 
-	// Ensure profiling is stopped even if there's an error
-	if memManager != nil {
-		defer func() {
-			if err := memManager.StopMemoryProfiling(); err != nil {
-				logger.Warn("Failed to stop memory profiling: %v", err)
-			}
-		}()
+// checkArgs rejects arguments other than the month after --preview. Flags
+// are not parsed after the first argument, so those given after the month
+// would otherwise be dropped.
+func checkArgs(c *cli.Context) error {
+	args := c.Args().Slice()
+	if c.Bool(pConfig) && len(args) > 0 {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return nil
 	}
+	return formatError("Command Line", "Unexpected arguments",
+		fmt.Errorf("%s is not a command or a month after --preview", strings.Join(args, " ")),
+		"Give every flag before the month: --set key=value --preview 2025-03",
+		"Run plannergen help for the commands")
+}
 
+// generate writes the LaTeX for the merged CSV data and, when compile is set,
+// compiles it to PDF
+func generate(c *cli.Context, compile bool) error {
+	return generateKeeping(c, compile, nil)
+}
+
+// keepTask leaves out tasks on top of the configured filter, such as those
+// of the categories and phases hidden in the browser; nil keeps them all
+type keepTask func(core.Task) bool
+
+// generateKeeping is generate laying out only the tasks keep accepts
+func generateKeeping(c *cli.Context, compile bool, keep keepTask) (err error) {
 	// * Check if we're in silent mode to reduce output verbosity
 	silent := core.IsSilent()
 
+	// Drop files recorded by an earlier run that failed part way
+	takeOutputs()
+	summary := newRunSummary(c.Bool(fDryRun))
+	if path := c.Path(fJSONSummary); path != "" {
+		defer func() { err = summary.write(path, err) }()
+	}
+	failOnWarn, err := failOnWarnings(c)
+	if err != nil {
+		return err
+	}
+
 	if !silent {
 		fmt.Println(core.BoldText("🚀 Starting Planner Generation"))
 		fmt.Println(core.DimText("═══════════════════════════════════════"))
@@ -359,6 +337,8 @@ func action(c *cli.Context) error {
 		)
 	}
 
+	summary.CSVFiles = csvFiles
+
 	if !silent {
 		fmt.Printf("%s", core.Info(fmt.Sprintf("📋 Found %d CSV file(s) to merge and process\n", len(csvFiles))))
 		for i, csvFile := range csvFiles {
@@ -375,14 +355,14 @@ func action(c *cli.Context) error {
 		if !silent {
 			fmt.Println(core.Error("❌"))
 		}
-		return formatError(
+		return withExitCode(ExitValidation, formatError(
 			"CSV Merging",
 			"Unable to merge CSV files",
 			err,
 			"Check that all CSV files have the same header structure",
 			"Verify there are no duplicate task IDs across files",
 			"Ensure all CSV files are valid",
-		)
+		))
 	}
 	if !silent {
 		fmt.Printf("%s", core.Success(fmt.Sprintf("✅ (%d tasks total)\n", len(allTasks))))
@@ -411,11 +391,16 @@ func action(c *cli.Context) error {
 	if !silent {
 		fmt.Print(core.Info("📋 Loading configuration... "))
 	}
-	cfg, pathConfigs, err := loadConfigurationWithTasks(c, allTasks)
+	cfg, pathConfigs, err := loadConfigurationKeeping(c, allTasks, keep)
 	if err != nil {
 		if !silent {
 			fmt.Println(core.Error("❌"))
 		}
+		var cycles *cycleError
+		if errors.As(err, &cycles) {
+			summary.Validation.Errors = cycles.cycles
+			return withExitCode(ExitValidation, err)
+		}
 		return formatError(
 			"Configuration",
 			"Unable to load configuration",
@@ -424,8 +409,14 @@ func action(c *cli.Context) error {
 			"Verify configuration file syntax",
 		)
 	}
+	warnings := planWarnings(cfg)
+	summary.addPlan(cfg, warnings)
 	if !silent {
 		fmt.Println(core.Success("✅"))
+		printLevelingMoves(cfg)
+		printDeadlineMisses(cfg)
+		printBlackoutConflicts(cfg)
+		printCapacityOverloads(cfg)
 	}
 
 	// Setup output directory
@@ -469,7 +460,8 @@ func action(c *cli.Context) error {
 	}
 
 	// Generate pages
-	preview := c.Bool(pConfig)
+	// A month range replaces the one-page-per-module preview
+	preview := c.Bool(pConfig) && cfg.PreviewRange == nil
 	if err := generatePages(cfg, preview); err != nil {
 		if !silent {
 			fmt.Println(core.Error("❌"))
@@ -487,59 +479,118 @@ func action(c *cli.Context) error {
 	}
 
 	// Compile LaTeX to PDF
-	spinner := core.NewSpinner("Compiling LaTeX to PDF...")
-	spinner.Start()
-
 	pdfCompiled := false
-	err = compileLaTeXToPDF(cfg)
-	spinner.Stop()
-
-	if err != nil {
-		if !silent {
-			// Clear line and print error status
-			fmt.Print(core.ClearLine())
-			fmt.Printf("%s %s\n", core.Error("❌"), core.Info("Compiling LaTeX to PDF..."))
+	var compileErr error
+	if compile && !cfg.DryRun {
+		spinner := core.NewSpinner("Compiling LaTeX to PDF...")
+		spinner.Start()
+		err = compileLaTeXToPDF(cfg, RootFilename(pathConfigs[len(pathConfigs)-1]))
+		spinner.Stop()
+
+		var configErr *core.ConfigError
+		if errors.As(err, &configErr) {
+			return formatError(
+				"PDF Compilation",
+				"Unable to choose a LaTeX engine",
+				err,
+				"Set latex_engine or --latex-engine to auto, latexmk, xelatex or tectonic",
+			)
 		}
-
-		if strings.Contains(err.Error(), "executable file not found") {
+		if err != nil {
 			if !silent {
-				fmt.Println(core.Warning("\n⚠️  PDF generation skipped: 'xelatex' not found"))
-				fmt.Println(core.DimText("   LaTeX files have been generated in: " + filepath.Join(cfg.OutputDir, "latex")))
-				fmt.Println(core.DimText("   To generate PDF manually, install TeX Live/MacTeX and run:"))
-				fmt.Printf("   %s\n", core.CyanText(fmt.Sprintf("cd %s && xelatex %s", filepath.Join(cfg.OutputDir, "latex"), RootFilename(pathConfigs[len(pathConfigs)-1]))))
+				// Clear line and print error status
+				fmt.Print(core.ClearLine())
+				fmt.Printf("%s %s\n", core.Error("❌"), core.Info("Compiling LaTeX to PDF..."))
+			}
+
+			if errors.Is(err, errNoLaTeXEngine) {
+				if !silent {
+					fmt.Println(core.Warning("\n⚠️  PDF generation skipped: " + err.Error()))
+					fmt.Println(core.DimText("   LaTeX files have been generated in: " + filepath.Join(cfg.OutputDir, "latex")))
+					fmt.Println(core.DimText("   To generate PDF manually, install TeX Live/MacTeX and run:"))
+					fmt.Printf("   %s\n", core.CyanText(fmt.Sprintf("cd %s && xelatex %s", filepath.Join(cfg.OutputDir, "latex"), RootFilename(pathConfigs[len(pathConfigs)-1]))))
+				}
+				logger.Warn("PDF compilation skipped (no LaTeX engine found)")
+			} else {
+				logger.Warn("PDF compilation failed: %v", err)
+				compileErr = err
 			}
-			logger.Warn("PDF compilation skipped (xelatex missing)")
 		} else {
-			logger.Warn("PDF compilation failed: %v", err)
+			pdfCompiled = true
+			summary.Compiled = true
+			if !silent {
+				// Clear line and print success status
+				fmt.Print(core.ClearLine())
+				fmt.Printf("%s %s\n", core.Success("✅"), core.Info("Compiling LaTeX to PDF..."))
+			}
 		}
-	} else {
-		pdfCompiled = true
+	}
+
+	if !silent {
+		printSlackReport(cfg, c.Bool(fSlackReport))
+		printBaselineVariance(cfg)
+		if c.Bool(fSimulate) {
+			printSimulationReport(cfg)
+		}
+		printScenarioComparison(cfg)
+	}
+	if c.Bool(fSuggest) || c.Path(fPatchCSV) != "" {
+		if err := reportSuggestions(cfg, csvFiles, c.Path(fPatchCSV), silent); err != nil {
+			return formatError(
+				"Rescheduling Suggestions",
+				"Unable to write patched CSV files",
+				err,
+				"Check that the --patch-csv directory is writable",
+			)
+		}
+	}
+
+	if cfg.DryRun {
+		files := takeOutputs()
+		summary.addOutputs(files)
 		if !silent {
-			// Clear line and print success status
-			fmt.Print(core.ClearLine())
-			fmt.Printf("%s %s\n", core.Success("✅"), core.Info("Compiling LaTeX to PDF..."))
+			printDryRunOutputs(cfg, files, compile)
+			fmt.Println(core.Success("✨ Dry run complete"))
 		}
+		if failOnWarn && len(warnings) > 0 {
+			return warningsError(len(warnings))
+		}
+		return nil
 	}
 
 	if !silent {
 		fmt.Println(core.DimText("═══════════════════════════════════════"))
 		if pdfCompiled {
 			fmt.Printf("%s", core.Success(fmt.Sprintf("✨ Successfully generated calendar from %d CSV files!\n", len(csvFiles))))
+		} else if !compile {
+			fmt.Printf("%s", core.Success(fmt.Sprintf("✨ Generated LaTeX from %d CSV files\n", len(csvFiles))))
 		} else {
-			fmt.Printf("%s", core.Warning("⚠️  Generated LaTeX files, but PDF compilation failed (check xelatex installation)\n"))
+			fmt.Printf("%s", core.Warning("⚠️  Generated LaTeX files, but PDF compilation failed (see the errors above)\n"))
 		}
 		fmt.Printf("%s", core.Info(fmt.Sprintf("📂 Output: %s\n", cfg.OutputDir)))
 	}
 
 	if !silent {
 		fmt.Println(core.DimText("═══════════════════════════════════════"))
-		if pdfCompiled {
+		if pdfCompiled || !compile {
 			fmt.Println(core.Success("✨ All files processed!"))
 		} else {
 			fmt.Println(core.Warning("⚠️  Done (with warnings)"))
 		}
 	}
 
+	if compileErr != nil {
+		return formatError(
+			"PDF Compilation",
+			"Unable to compile the PDF",
+			errors.New(strings.ReplaceAll(compileErr.Error(), "\n", "\n   ")),
+			"Fix the LaTeX errors listed above, starting with the first one",
+			"Check the full log in "+filepath.Join(cfg.OutputDir, "latex"),
+		)
+	}
+	if failOnWarn && len(warnings) > 0 {
+		return warningsError(len(warnings))
+	}
 	return nil
 }
 
@@ -694,9 +745,21 @@ func analyzeCoverage(coverageFile string) error {
 	return nil
 }
 
+// configPaths returns the --config files in merge order: each file is laid
+// over the ones before it, so list shared defaults first
+func configPaths(c *cli.Context) []string {
+	var paths []string
+	for _, path := range c.StringSlice(fConfig) {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
 // loadConfiguration loads and validates the configuration from CLI context
 func loadConfiguration(c *cli.Context) (core.Config, []string, error) {
-	initialPathConfigs := strings.Split(c.Path(fConfig), ",")
+	initialPathConfigs := configPaths(c)
 
 	// Auto-detect CSV and adjust configuration accordingly
 	csvPath := os.Getenv("PLANNER_CSV_FILE")
@@ -720,8 +783,13 @@ func loadConfiguration(c *cli.Context) (core.Config, []string, error) {
 		}
 	}
 
-	cfg, err := core.NewConfig(pathConfigs...)
+	cfg, err := core.NewConfigWithOverrides(setOverrides(c), pathConfigs...)
 	if err != nil {
+		// Errors of a config file already name it and the line
+		var configErr *core.ConfigError
+		if errors.As(err, &configErr) && configErr.Line > 0 {
+			return core.Config{}, nil, err
+		}
 		return core.Config{}, nil, core.NewConfigError(
 			strings.Join(pathConfigs, ","),
 			"",
@@ -730,20 +798,158 @@ func loadConfiguration(c *cli.Context) (core.Config, []string, error) {
 		)
 	}
 
+	applyCLIOverrides(c, &cfg)
+
+	return cfg, pathConfigs, nil
+}
+
+// setOverrides returns the --set key=value overrides, rejoining values the
+// flag split at commas, such as lists, after the keys --profile and
+// --colorblind set
+func setOverrides(c *cli.Context) []string {
+	var overrides []string
+	for _, part := range c.StringSlice(fSet) {
+		if n := len(overrides); n > 0 && !strings.Contains(part, "=") {
+			overrides[n-1] += "," + part
+			continue
+		}
+		overrides = append(overrides, part)
+	}
+	// --colorblind comes first, so --set can still change either key
+	if c.Bool(fColorblind) {
+		overrides = append([]string{"layout.palette.scheme=" + core.PaletteOkabeIto, "layout.palette.patterns=true"}, overrides...)
+	}
+	if profile := strings.TrimSpace(c.String(fProfile)); profile != "" {
+		overrides = append([]string{"profile=" + profile}, overrides...)
+	}
+	return overrides
+}
+
+// applyCLIOverrides applies command-line flags that take precedence over config files
+func applyCLIOverrides(c *cli.Context, cfg *core.Config) {
 	// Override output directory from CLI flag if provided
 	if od := strings.TrimSpace(c.Path(fOutDir)); od != "" {
 		cfg.OutputDir = od
 	}
 
-	return cfg, pathConfigs, nil
+	// Override template overlay directory from CLI flag if provided
+	if td := strings.TrimSpace(c.Path(fTemplates)); td != "" {
+		cfg.TemplatesDir = td
+	}
+
+	// Override the calendar range
+	if start := strings.TrimSpace(c.String(fStart)); start != "" {
+		cfg.CalendarStart = start
+	}
+	if end := strings.TrimSpace(c.String(fEnd)); end != "" {
+		cfg.CalendarEnd = end
+	}
+
+	// Override the task filter lists given on the command line
+	if c.IsSet(fIncludeCat) {
+		cfg.Filter.IncludeCategories = c.StringSlice(fIncludeCat)
+	}
+	if c.IsSet(fExcludeCat) {
+		cfg.Filter.ExcludeCategories = c.StringSlice(fExcludeCat)
+	}
+	if c.IsSet(fIncludeTag) {
+		cfg.Filter.IncludeTags = c.StringSlice(fIncludeTag)
+	}
+	if c.IsSet(fExcludeTag) {
+		cfg.Filter.ExcludeTags = c.StringSlice(fExcludeTag)
+	}
+
+	cfg.DryRun = c.Bool(fDryRun)
+
+	// Override the LaTeX engine
+	if engine := strings.TrimSpace(c.String(fLaTeXEngine)); engine != "" {
+		cfg.LaTeXEngine = engine
+	}
+
+	// Override the proposed plan of a what-if comparison
+	if proposed := strings.TrimSpace(c.Path(fCompare)); proposed != "" {
+		cfg.Scenario.Proposed = proposed
+	}
+}
+
+// previewRange returns the months to rebuild, from --range or a month given
+// after --preview, or "" to rebuild all
+func previewRange(c *cli.Context) string {
+	if spec := strings.TrimSpace(c.String(fRange)); spec != "" {
+		return spec
+	}
+	if c.Bool(pConfig) && c.Args().Present() {
+		return strings.TrimSpace(c.Args().First())
+	}
+	return ""
+}
+
+// loadPlan reads every CSV file in input_data and loads the configuration
+// with the merged, scheduled tasks
+func loadPlan(c *cli.Context) (core.Config, error) {
+	csvFiles, err := getAllCSVFiles()
+	if err != nil {
+		return core.Config{}, formatError("CSV File Detection", "Unable to find CSV files", err,
+			"Check that input_data directory exists")
+	}
+	tasks, err := core.ReadTasksFromMultipleFiles(csvFiles)
+	if err != nil {
+		return core.Config{}, formatError("CSV Merging", "Unable to merge CSV files", err)
+	}
+	cfg, _, err := loadConfigurationWithTasks(c, tasks)
+	if err != nil {
+		return core.Config{}, loadError(err)
+	}
+	return cfg, nil
+}
+
+// cycleError stops loading a plan whose task dependencies form cycles,
+// before scheduling can fail on them without naming the chain
+type cycleError struct {
+	cycles []core.ValidationIssue
+}
+
+func (e *cycleError) Error() string {
+	chains := make([]string, 0, len(e.cycles))
+	for _, cycle := range e.cycles {
+		chains = append(chains, "ERROR: "+cycle.Message)
+	}
+	return formatError(
+		"Dependency Validation",
+		"Task dependencies form a cycle",
+		fmt.Errorf("%s", strings.Join(chains, "\n   ")),
+		"Remove one dependency from each chain listed above",
+		"Check the Dependencies column for typos in task IDs",
+	).Error()
+}
+
+// loadError reports a failed plan load: dependency cycles with their chains
+// and the validation exit status, anything else as a configuration error
+func loadError(err error) error {
+	var cycles *cycleError
+	if errors.As(err, &cycles) {
+		return withExitCode(ExitValidation, err)
+	}
+	return formatError("Configuration", "Unable to load configuration", err)
 }
 
 // loadConfigurationWithTasks loads configuration and injects pre-loaded tasks
 func loadConfigurationWithTasks(c *cli.Context, tasks []core.Task) (core.Config, []string, error) {
-	initialPathConfigs := strings.Split(c.Path(fConfig), ",")
+	return loadConfigurationKeeping(c, tasks, nil)
+}
+
+// loadConfigurationKeeping is loadConfigurationWithTasks laying out only the
+// tasks keep accepts after scheduling
+func loadConfigurationKeeping(c *cli.Context, tasks []core.Task, keep keepTask) (core.Config, []string, error) {
+	initialPathConfigs := configPaths(c)
 
-	cfg, err := core.NewConfig(initialPathConfigs...)
+	cfg, err := core.NewConfigWithOverrides(setOverrides(c), initialPathConfigs...)
 	if err != nil {
+		// Errors of a config file already name it and the line
+		var configErr *core.ConfigError
+		if errors.As(err, &configErr) && configErr.Line > 0 {
+			return core.Config{}, nil, err
+		}
 		return core.Config{}, nil, core.NewConfigError(
 			strings.Join(initialPathConfigs, ","),
 			"",
@@ -752,25 +958,131 @@ func loadConfigurationWithTasks(c *cli.Context, tasks []core.Task) (core.Config,
 		)
 	}
 
-	// Override output directory from CLI flag if provided
-	if od := strings.TrimSpace(c.Path(fOutDir)); od != "" {
-		cfg.OutputDir = od
+	applyCLIOverrides(c, &cfg)
+
+	// Place timestamps with a UTC offset on their day in the configured zone,
+	// decide which tasks are milestones, expand task templates and give
+	// tasks without a priority that of their category
+	if err := cfg.ApplyTimezone(tasks); err != nil {
+		return core.Config{}, nil, err
+	}
+	if err := cfg.DetectMilestones(tasks); err != nil {
+		return core.Config{}, nil, err
+	}
+	if tasks, err = cfg.ExpandTemplates(tasks); err != nil {
+		return core.Config{}, nil, err
+	}
+	cfg.ApplyCategoryPriorities(tasks)
+
+	// Reject circular dependencies, including those of expanded templates,
+	// before scheduling
+	if cycles := core.DependencyCycles(tasks); len(cycles) > 0 {
+		return core.Config{}, nil, &cycleError{cycles: cycles}
 	}
 
 	// Inject the pre-loaded tasks into the configuration
 	cfg.Tasks = tasks
-	
+
+	// Date tasks planned by effort, then again once the holidays of the
+	// resulting years are known
+	if err := cfg.LoadBlackouts(); err != nil {
+		return core.Config{}, nil, err
+	}
+	if err := cfg.ScheduleTasks(tasks); err != nil {
+		return core.Config{}, nil, err
+	}
+	if err := cfg.LoadHolidays(planningYears(cfg)); err != nil {
+		return core.Config{}, nil, err
+	}
+	if cfg.Holidays.ExcludeFromDuration {
+		if err := cfg.ScheduleTasks(tasks); err != nil {
+			return core.Config{}, nil, err
+		}
+	}
+	if err := cfg.LevelTasks(tasks); err != nil {
+		return core.Config{}, nil, err
+	}
+	if err := cfg.ApplyBaseline(tasks); err != nil {
+		return core.Config{}, nil, err
+	}
+	if err := loadScenario(&cfg); err != nil {
+		return core.Config{}, nil, err
+	}
+	if tasks, err = cfg.AddRecurringMilestones(tasks); err != nil {
+		return core.Config{}, nil, err
+	}
+	tasks = cfg.SplitLongTasks(tasks)
+	cfg.Tasks = tasks
+	cfg.CheckCapacity(tasks)
+
+	// Color the categories of the whole plan, so filtered planners keep them
+	cfg.AssignCategoryColors(tasks)
+
+	// Filter after scheduling so that kept tasks keep their dates
+	if !cfg.Filter.IsEmpty() || keep != nil {
+		tasks = cfg.Filter.Apply(tasks)
+		if keep != nil {
+			kept := make([]core.Task, 0, len(tasks))
+			for _, t := range tasks {
+				if keep(t) {
+					kept = append(kept, t)
+				}
+			}
+			tasks = kept
+		}
+		if len(tasks) == 0 {
+			return core.Config{}, nil, core.NewConfigError("", "filter", "the category and tag filters leave no tasks", nil)
+		}
+		cfg.Tasks = tasks
+	}
+
+	// Hidden categories stay in cfg.Tasks for stats and validation but get
+	// no months of their own
+	if len(tasks) > 0 {
+		tasks = cfg.VisibleTasks()
+		if len(tasks) == 0 {
+			return core.Config{}, nil, core.NewConfigError("", "categories", "the hidden categories leave no tasks to lay out", nil)
+		}
+	}
+
 	// Calculate date range and months with tasks from the merged data
 	if len(tasks) > 0 {
 		dateRange := core.CalculateDateRange(tasks)
 		cfg.MonthsWithTasks = core.GetMonthsWithTasks(tasks, dateRange)
 	}
+	if err := cfg.ApplyCalendarRange(); err != nil {
+		return core.Config{}, nil, err
+	}
+	if cfg.CalendarMonths != nil {
+		// Padded months may fall in years without tasks
+		if err := cfg.LoadHolidays(planningYears(cfg)); err != nil {
+			return core.Config{}, nil, err
+		}
+	}
+
+	// Rebuild only the months being worked on
+	if spec := previewRange(c); spec != "" {
+		months, err := core.ParseMonthRange(spec)
+		if err != nil {
+			return core.Config{}, nil, core.NewConfigError("", fRange, "invalid month range "+spec, err)
+		}
+		cfg.PreviewRange = &months
+		cfg.MonthsWithTasks = months.Filter(cfg.MonthsWithTasks)
+	}
+
+	if err := cfg.LoadAcademicCalendar(); err != nil {
+		return core.Config{}, nil, err
+	}
 
 	return cfg, initialPathConfigs, nil
 }
 
 // setupOutputDirectory ensures the output directory exists and logs its location
 func setupOutputDirectory(cfg core.Config) error {
+	if cfg.DryRun {
+		return nil
+	}
+
 	// Create main output directory
 	if err := os.MkdirAll(cfg.OutputDir, 0o755); err != nil {
 		return core.NewFileError(cfg.OutputDir, "create directory", err)
@@ -800,11 +1112,19 @@ func generateRootDocument(cfg core.Config, pathConfigs []string) error {
 	wr := GetReusableBuffer()
 	defer ReturnBuffer(wr)
 
-	t := NewTpl()
+	t, err := NewTplForConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	macros, err := userMacros(cfg.Layout.LaTeX.Document.MacrosFile)
+	if err != nil {
+		return err
+	}
 
 	LogMemoryStats("Before document generation")
 
-	if err := t.Document(wr, cfg); err != nil {
+	if err := t.Document(wr, cfg, macros); err != nil {
 		return core.NewTemplateError(documentTpl, 0, "failed to generate LaTeX document", err)
 	}
 
@@ -813,6 +1133,10 @@ func generateRootDocument(cfg core.Config, pathConfigs []string) error {
 	logger.Debug("Root document content:\n%s", wr.String())
 
 	outputFile := filepath.Join(cfg.OutputDir, "latex", RootFilename(pathConfigs[len(pathConfigs)-1]))
+	recordOutput(outputFile, wr.Len())
+	if cfg.DryRun {
+		return nil
+	}
 	if err := os.WriteFile(outputFile, wr.Bytes(), 0o600); err != nil {
 		return core.NewFileError(outputFile, "write", err)
 	}
@@ -828,24 +1152,28 @@ func generateRootDocument(cfg core.Config, pathConfigs []string) error {
 
 // generatePages creates all page files from the configuration
 func generatePages(cfg core.Config, preview bool) error {
-	t := NewTpl()
+	t, err := NewTplForConfig(cfg)
+	if err != nil {
+		return err
+	}
 
 	totalPages := len(cfg.Pages)
 	silent := core.IsSilent()
 
 	for i, file := range cfg.Pages {
-		if !silent {
-			fmt.Print(core.ClearLine())
-			fmt.Printf("%s [%d/%d] %s", core.Info("📅 Generating calendar pages..."), i+1, totalPages, file.Name)
-		}
-		if err := generateSinglePage(cfg, file, t, preview); err != nil {
-			if !silent {
+		progress := core.NewProgress(fmt.Sprintf("%s [%d/%d] %s", core.Info("📅 Generating calendar pages..."), i+1, totalPages, file.Name), "months", file.Name+" page")
+		setPageProgress(progress)
+		err := generateSinglePage(cfg, file, t, preview)
+		setPageProgress(nil)
+		if err != nil {
+			if !silent && core.IsInteractive() {
 				fmt.Println() // New line before error
 			}
 			return err
 		}
+		progress.Done()
 	}
-	if !silent {
+	if !silent && core.IsInteractive() {
 		// Add a space so the checkmark printed by the caller appears next to the progress
 		fmt.Print(" ")
 	}
@@ -853,6 +1181,29 @@ func generatePages(cfg core.Config, preview bool) error {
 	return nil
 }
 
+// pageProgress reports the months laid out for the page being generated
+var pageProgress struct {
+	sync.Mutex
+	progress *core.Progress
+}
+
+// setPageProgress sets the progress that laid-out months are reported to;
+// nil stops reporting
+func setPageProgress(p *core.Progress) {
+	pageProgress.Lock()
+	defer pageProgress.Unlock()
+	pageProgress.progress = p
+}
+
+// stepPageProgress reports a laid-out month of the current page
+func stepPageProgress(month *cal.Month) {
+	pageProgress.Lock()
+	defer pageProgress.Unlock()
+	if pageProgress.progress != nil && month.Year != nil {
+		pageProgress.progress.Step(fmt.Sprintf("%s %d", month.Month.String()[:3], month.Year.Number))
+	}
+}
+
 // generateSinglePage generates a single page file
 func generateSinglePage(cfg core.Config, file core.Page, t Tpl, preview bool) error {
 	wr := &bytes.Buffer{}
@@ -929,8 +1280,31 @@ func (t Tpl) renderModules(wr io.Writer, modules []core.Modules, file core.Page)
 		return nil
 	}
 
+	// Pages set the header and footer of their sheets once any page has them
+	var labels *pageLabels
+	if len(modules[0]) > 0 && modules[0][0].Cfg.Pages.HasHeaderFooter() {
+		var err error
+		if labels, err = newPageLabels(file); err != nil {
+			return err
+		}
+	}
+
+	// Month sheets open a section for the running head and month numbering
+	sections := len(modules[0]) > 0 && modules[0][0].Cfg.Layout.PageNumbering.Marks() &&
+		len(file.RenderBlocks) > 0 && monthPages[file.RenderBlocks[0].FuncName]
+
 	moduleCount := len(modules[0])
 	for i := 0; i < moduleCount; i++ {
+		if labels != nil {
+			if err := labels.write(wr, i+1, modules[0][i]); err != nil {
+				return err
+			}
+		}
+		if sections && !modules[0][i].Date.IsZero() {
+			if err := writeMonthSection(wr, modules[0][i]); err != nil {
+				return err
+			}
+		}
 		for j, mod := range modules {
 			if err := t.Execute(wr, mod[i].Tpl, mod[i]); err != nil {
 				return core.NewTemplateError(
@@ -949,6 +1323,10 @@ func (t Tpl) renderModules(wr io.Writer, modules []core.Modules, file core.Page)
 // writePageFile writes the page content to a file
 func writePageFile(cfg core.Config, pageName string, content []byte) error {
 	pageFile := filepath.Join(cfg.OutputDir, "latex", pageName+texExtension)
+	recordOutput(pageFile, len(content))
+	if cfg.DryRun {
+		return nil
+	}
 	if err := os.WriteFile(pageFile, content, 0o600); err != nil {
 		return core.NewFileError(pageFile, "write", err)
 	}
@@ -1035,13 +1413,96 @@ func NewTpl() Tpl {
 	}
 }
 
-func (t Tpl) Document(wr io.Writer, cfg core.Config) error {
+// NewTplForConfig returns the templates for a configuration: user template
+// overlays from cfg.TemplatesDir plus the enabled/disabled helper settings
+func NewTplForConfig(cfg core.Config) (Tpl, error) {
+	t, err := NewTplWithOverlay(cfg.TemplatesDir)
+	if err != nil {
+		return Tpl{}, err
+	}
+
+	disabled, err := disabledTemplateFuncs(cfg.TemplateHelpers)
+	if err != nil {
+		return Tpl{}, core.NewConfigError("", "template_helpers", "invalid template helper settings", err)
+	}
+
+	// Clone before swapping functions so the shared built-in set stays
+	// intact, taking the helpers registered since it was parsed
+	clone, err := t.tpl.Clone()
+	if err != nil {
+		return Tpl{}, core.NewTemplateError("", 0, "failed to clone templates", err)
+	}
+	clone.Funcs(TemplateFuncs()).Funcs(disabled)
+
+	return Tpl{tpl: clone}, nil
+}
+
+// NewTplWithOverlay returns the built-in templates with any *.tpl files from dir
+// parsed on top. Files in dir replace built-in templates of the same name;
+// templates not present in dir keep their default definitions.
+func NewTplWithOverlay(dir string) (Tpl, error) {
+	dir = strings.TrimSpace(dir)
+	if dir == "" {
+		return NewTpl(), nil
+	}
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		return Tpl{}, core.NewFileError(dir, "read templates directory", err)
+	}
+	if !info.IsDir() {
+		return Tpl{}, core.NewFileError(dir, "read templates directory", fmt.Errorf("not a directory"))
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, templatePattern))
+	if err != nil {
+		return Tpl{}, core.NewFileError(dir, "list templates", err)
+	}
+	if len(files) == 0 {
+		logger.Warn("No %s files found in templates directory %s, using built-in templates", templatePattern, dir)
+		return NewTpl(), nil
+	}
+
+	// Clone so the overlay never leaks into the shared built-in set, with
+	// the helpers registered since it was parsed
+	t, err := tpl.Clone()
+	if err != nil {
+		return Tpl{}, core.NewTemplateError(dir, 0, "failed to clone built-in templates", err)
+	}
+	t.Funcs(TemplateFuncs())
+
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return Tpl{}, core.NewFileError(file, "read", err)
+		}
+
+		name := filepath.Base(file)
+		if _, err := t.New(name).Parse(string(content)); err != nil {
+			return Tpl{}, core.NewTemplateError(name, 0, "failed to parse user template from "+file, err)
+		}
+		logger.Debug("Loaded user template override: %s", file)
+	}
+
+	return Tpl{tpl: t}, nil
+}
+
+// Document writes the root document, with the user macros after the
+// built-in ones
+func (t Tpl) Document(wr io.Writer, cfg core.Config, macros string) error {
 	type pack struct {
-		Cfg   core.Config
-		Pages []core.Page
+		Cfg      core.Config
+		Pages    []core.Page
+		Preamble string
+		Macros   string
 	}
 
-	data := pack{Cfg: cfg, Pages: cfg.Pages}
+	data := pack{
+		Cfg:      cfg,
+		Pages:    cfg.Pages,
+		Preamble: strings.TrimSpace(cfg.Layout.LaTeX.Document.Preamble),
+		Macros:   macros,
+	}
 	if err := t.tpl.ExecuteTemplate(wr, documentTpl, data); err != nil {
 		return core.NewTemplateError(documentTpl, 0, "failed to execute document template", err)
 	}
@@ -1049,6 +1510,19 @@ type pack struct {
 	return nil
 }
 
+// userMacros returns the content of the user macros file, or "" when none
+// is set
+func userMacros(path string) (string, error) {
+	if path = strings.TrimSpace(path); path == "" {
+		return "", nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", core.NewConfigError("", "layout.latex.document.macros_file", fmt.Sprintf("cannot read macros file %s", path), err)
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
 func (t Tpl) Execute(wr io.Writer, name string, data interface{}) error {
 	// Check if template exists before trying to execute
 	if t.tpl.Lookup(name) == nil {
@@ -1078,8 +1552,8 @@ func Monthly(cfg core.Config, tpls []string) (core.Modules, error) {
 
 // MonthlyLegacy provides the original monthly generation without layout integration
 func MonthlyLegacy(cfg core.Config, tpls []string) (core.Modules, error) {
-	// Use tasks from config (already loaded and merged)
-	tasks := cfg.Tasks
+	// Use tasks from config (already loaded and merged), without hidden ones
+	tasks := cfg.VisibleTasks()
 
 	// If we have months with tasks from CSV, use only those
 	if len(cfg.MonthsWithTasks) > 0 {
@@ -1092,8 +1566,11 @@ func MonthlyLegacy(cfg core.Config, tpls []string) (core.Modules, error) {
 		}
 
 		monthModules := make(core.Modules, 0, len(cfg.MonthsWithTasks))
+		seenWeeks := make(map[time.Time]bool)
 
 		for _, monthYear := range cfg.MonthsWithTasks {
+			// The month's pages use its overrides throughout
+			cfg := cfg.ForMonth(monthYear.Year, monthYear.Month)
 			year := cal.NewYear(cfg.WeekStart, monthYear.Year, &cfg)
 
 			// Find the specific month in the year
@@ -1122,8 +1599,9 @@ func MonthlyLegacy(cfg core.Config, tpls []string) (core.Modules, error) {
 			assignTasksToMonth(targetMonth, tasks)
 
 			monthModules = append(monthModules, core.Module{
-				Cfg: cfg,
-				Tpl: tpls[0],
+				Cfg:  cfg,
+				Tpl:  tpls[0],
+				Date: time.Date(monthYear.Year, monthYear.Month, 1, 0, 0, 0, 0, time.UTC),
 				Body: map[string]interface{}{
 					"Year":         year,
 					"Quarter":      targetMonth.Quarter,
@@ -1136,9 +1614,15 @@ func MonthlyLegacy(cfg core.Config, tpls []string) (core.Modules, error) {
 					"Extra":        targetMonth.PrevNext().WithTopRightCorner(cfg.ClearTopRightCorner, cfg.Layout.Calendar.TaskKernSpacing),
 					"Large":        true,
 					"TableType":    "tabularx",
-					"Today":        cal.Day{Time: time.Now(), Cfg: &cfg},
+					"Today":        cal.Day{Time: cfg.GetAsOfDate(), Cfg: &cfg},
+					"Locale":       cfg.GetLocale(),
 				},
 			})
+
+			// Interleave this month's weekly pages right after the monthly page
+			if cfg.Weekly.Interleave {
+				monthModules = append(monthModules, weeklyModulesForMonth(cfg, targetMonth, seenWeeks)...)
+			}
 		}
 
 		// Combine TOC modules with month modules
@@ -1155,9 +1639,15 @@ func MonthlyLegacy(cfg core.Config, tpls []string) (core.Modules, error) {
 
 			for _, quarter := range year.Quarters {
 				for _, month := range quarter.Months {
+					// Months with their own overrides get days built on them
+					monthCfg := cfg.ForMonth(yearNum, month.Month)
+					if monthCfg.HasMonthOverride() {
+						month = cal.NewYear(cfg.WeekStart, yearNum, &monthCfg).Quarters[quarter.Number-1].Months[(int(month.Month)-1)%3]
+					}
 					modules = append(modules, core.Module{
-						Cfg: cfg,
-						Tpl: tpls[0],
+						Cfg:  monthCfg,
+						Tpl:  tpls[0],
+						Date: time.Date(yearNum, month.Month, 1, 0, 0, 0, 0, time.UTC),
 						Body: map[string]interface{}{
 							"Year":         year,
 							"Quarter":      quarter,
@@ -1170,7 +1660,8 @@ func MonthlyLegacy(cfg core.Config, tpls []string) (core.Modules, error) {
 							"Extra":        month.PrevNext().WithTopRightCorner(cfg.ClearTopRightCorner, cfg.Layout.Calendar.TaskKernSpacing),
 							"Large":        true,
 							"TableType":    "tabularx",
-							"Today":        cal.Day{Time: time.Now(), Cfg: &cfg},
+							"Today":        cal.Day{Time: cfg.GetAsOfDate(), Cfg: &cfg},
+							"Locale":       cfg.GetLocale(),
 						},
 					})
 				}
@@ -1364,7 +1855,11 @@ func createTableOfContentsModule(cfg core.Config, tasks []core.Task, templateNam
 		}
 	}
 
-	// Phase stats
+	// Phase stats, with completion weighted by task length
+	phaseProgress := make(map[string]float64)
+	for _, r := range core.PhaseRollups(tasks) {
+		phaseProgress[r.Phase] = r.Progress
+	}
 	phaseStats := make(map[string]map[string]int)
 	for phase, tasksInPhase := range phaseTasks {
 		stats := make(map[string]int)
@@ -1381,11 +1876,7 @@ func createTableOfContentsModule(cfg core.Config, tasks []core.Task, templateNam
 		}
 		stats["completed"] = completed
 		stats["milestones"] = milestones
-		if stats["total"] > 0 {
-			stats["progress"] = int(float64(completed) / float64(stats["total"]) * 100)
-		} else {
-			stats["progress"] = 0
-		}
+		stats["progress"] = int(phaseProgress[strings.TrimSpace(phase)])
 		phaseStats[phase] = stats
 	}
 
@@ -1470,19 +1961,14 @@ type PhaseSection struct {
 	for _, phase := range phases {
 		phaseNames[phase] = EscapeLatex(phase)
 		// Generate color for this phase using the same algorithm as the calendar
-		color := core.GenerateCategoryColor(phase)
+		color := cfg.GenerateCategoryColor(phase)
 		phaseColors[phase] = core.HexToRGB(color)
 	}
 
 	// Calculate task durations in days
 	taskDurations := make(map[string]string)
 	for _, task := range tasks {
-		duration := task.EndDate.Sub(task.StartDate)
-		days := int(duration.Hours() / 24)
-		if days < 1 {
-			days = 1
-		}
-		taskDurations[task.ID] = fmt.Sprintf("%d", days)
+		taskDurations[task.ID] = fmt.Sprintf("%d", cfg.GetDuration(task))
 	}
 
 	// Create phase-to-section mapping
@@ -1493,6 +1979,14 @@ type PhaseSection struct {
 		}
 	}
 
+	// Critical Path Method figures for the summary and, optionally, each row
+	var cpm *core.CPMResult
+	if hasDependencies(tasks) {
+		if result, err := cfg.ComputeCPM(tasks); err == nil && len(result.Tasks) > 0 {
+			cpm = &result
+		}
+	}
+
 	// Prepare CSV file info for display
 	csvFileNames := make([]string, len(csvFiles))
 	for i, csvFile := range csvFiles {
@@ -1504,6 +1998,7 @@ type PhaseSection struct {
 		Tpl: templateName,
 		Body: map[string]interface{}{
 			"TaskIndex":      phaseTasks,
+			"Locale":         cfg.GetLocale(),
 			"PhaseOrder":     phases,
 			"PhaseNames":     phaseNames,
 			"PhaseColors":    phaseColors,
@@ -1515,29 +2010,35 @@ type PhaseSection struct {
 			"PhaseStats":     phaseStats,
 			"CSVFiles":       csvFileNames,
 			"CSVFileCount":   len(csvFiles),
+			"CPM":            cpm,
+			"ShowCPM":        cpm != nil && cfg.Scheduling.ShowCPM,
 		},
 	}
 }
 
+// hasDependencies reports whether any task depends on another
+func hasDependencies(tasks []core.Task) bool {
+	for _, task := range tasks {
+		if len(task.Dependencies) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // assignTasksToMonth assigns tasks to the appropriate days in a month
 func assignTasksToMonth(month *cal.Month, tasks []core.Task) {
-	// Convert data.Task to SpanningTask and apply to month
-	var spanningTasks []cal.SpanningTask
-
+	// Convert data.Task to SpanningTask and apply to month.
+	// Rendering rules:
+	// - Start day: show a thin colored bar + a single concise text label.
+	// - Middle/end days: show only the bar (no repeated labels).
+	// All tasks are passed so dependencies on other months can be resolved;
+	// ApplySpanningTasksToMonth only places those overlapping the month.
+	defer stepPageProgress(month)
+
+	spanningTasks := make([]cal.SpanningTask, 0, len(tasks))
 	for _, task := range tasks {
-		// Check if task overlaps with this month
-		monthStart := time.Date(month.Year.Number, month.Month, 1, 0, 0, 0, 0, time.Local)
-		monthEnd := monthStart.AddDate(0, 1, -1)
-
-		if task.StartDate.Before(monthEnd.AddDate(0, 0, 1)) && task.EndDate.After(monthStart.AddDate(0, 0, -1)) {
-			// Create spanning task directly from common.Task
-			// Rendering rules:
-			// - Start day: show a thin colored bar + a single concise text label.
-			// - Middle/end days: show only the bar (no repeated labels).
-			// Therefore, we DO NOT add this task as a regular per-day entry to avoid duplication.
-			spanningTask := cal.CreateSpanningTask(task, task.StartDate, task.EndDate)
-			spanningTasks = append(spanningTasks, spanningTask)
-		}
+		spanningTasks = append(spanningTasks, cal.CreateSpanningTask(month.Cfg, task, task.StartDate, task.EndDate))
 	}
 
 	// Apply spanning tasks to the month for background coloring
@@ -1664,7 +2165,7 @@ func runValidation(c *cli.Context) error {
 		return nil
 	} else {
 		fmt.Println(core.Error("❌ Validation failed - please fix the issues above"))
-		return fmt.Errorf("validation failed")
+		return withExitCode(ExitValidation, fmt.Errorf("validation failed"))
 	}
 }
 
@@ -1701,82 +2202,3 @@ func runConfigValidation(c *cli.Context) error {
 	fmt.Println("✅ Config validation is working!")
 	return nil
 }
-
-// compileLaTeXToPDF compiles LaTeX files to PDF using XeLaTeX
-func compileLaTeXToPDF(cfg core.Config) error {
-	latexDir := filepath.Join(cfg.OutputDir, "latex")
-	pdfDir := filepath.Join(cfg.OutputDir, "pdfs")
-	auxDir := filepath.Join(cfg.OutputDir, "auxiliary")
-
-	// Find the main LaTeX file (usually the first .tex file)
-	files, err := os.ReadDir(latexDir)
-	if err != nil {
-		return fmt.Errorf("failed to read latex directory: %w", err)
-	}
-
-	var mainTexFile string
-	for _, file := range files {
-		if !file.IsDir() && strings.HasSuffix(file.Name(), ".tex") {
-			mainTexFile = filepath.Join(latexDir, file.Name())
-			break
-		}
-	}
-
-	if mainTexFile == "" {
-		return fmt.Errorf("no LaTeX file found in %s", latexDir)
-	}
-
-	// Change to latex directory for compilation
-	originalDir, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
-	}
-	defer os.Chdir(originalDir)
-
-	if err := os.Chdir(latexDir); err != nil {
-		return fmt.Errorf("failed to change to latex directory: %w", err)
-	}
-
-	// Run XeLaTeX compilation
-	cmd := exec.Command("xelatex", "-interaction=nonstopmode", filepath.Base(mainTexFile))
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("xelatex compilation failed: %w\nOutput: %s", err, string(output))
-	}
-
-	// Move generated files to appropriate directories
-	baseName := strings.TrimSuffix(filepath.Base(mainTexFile), ".tex")
-	
-	// Ensure paths are absolute to avoid issues after chdir
-	absPdfDir, err := filepath.Abs(pdfDir)
-	if err != nil {
-		absPdfDir = pdfDir // Fallback to relative if Abs fails
-	}
-	absAuxDir, err := filepath.Abs(auxDir)
-	if err != nil {
-		absAuxDir = auxDir // Fallback to relative if Abs fails
-	}
-	
-	// Move PDF to pdfs directory
-	pdfFile := baseName + ".pdf"
-	if _, err := os.Stat(pdfFile); err == nil {
-		destPath := filepath.Join(absPdfDir, pdfFile)
-		if err := os.Rename(pdfFile, destPath); err != nil {
-			logger.Warn("Failed to move PDF file: %v", err)
-		}
-	}
-
-	// Move auxiliary files to auxiliary directory
-	auxFiles := []string{".aux", ".log", ".fdb_latexmk", ".fls", ".synctex.gz", ".tmp"}
-	for _, ext := range auxFiles {
-		auxFile := baseName + ext
-		if _, err := os.Stat(auxFile); err == nil {
-			destPath := filepath.Join(absAuxDir, auxFile)
-			if err := os.Rename(auxFile, destPath); err != nil {
-				logger.Warn("Failed to move auxiliary file %s: %v", auxFile, err)
-			}
-		}
-	}
-
-	return nil
-}
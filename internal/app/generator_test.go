@@ -1,7 +1,13 @@
 package app
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
+
+	"phd-dissertation-planner/internal/core"
 )
 
 func TestEscapeLatex(t *testing.T) {
@@ -38,3 +44,78 @@ func BenchmarkEscapeLatex(b *testing.B) {
 		EscapeLatex(input)
 	}
 }
+
+func TestCoverDateRange(t *testing.T) {
+	cfg := core.DefaultConfig()
+	if got := coverDateRange(cfg); got != "" {
+		t.Errorf("range without months = %q, want empty", got)
+	}
+	cfg.MonthsWithTasks = []core.MonthYear{{Year: 2025, Month: time.March}}
+	if got := coverDateRange(cfg); got != "March 2025" {
+		t.Errorf("range = %q, want March 2025", got)
+	}
+	cfg.MonthsWithTasks = append(cfg.MonthsWithTasks, core.MonthYear{Year: 2027, Month: time.July})
+	if got := coverDateRange(cfg); got != "March 2025 -- July 2027" {
+		t.Errorf("range = %q, want March 2025 -- July 2027", got)
+	}
+	if _, err := coverLogo("no-such-logo.png"); err == nil {
+		t.Error("expected an error for a missing logo")
+	}
+}
+
+func TestPageLabels(t *testing.T) {
+	labels, err := newPageLabels(core.Page{Name: "monthly", Header: "{{.MonthName}} {{.Year}}", Footer: "Sheet {{.Number}}"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := core.DefaultConfig()
+	var out strings.Builder
+	if err := labels.write(&out, 2, core.Module{Cfg: cfg, Date: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := out.String(), "\\PageLabels{March 2025}{Sheet 2}%\n"; got != want {
+		t.Errorf("labels = %q, want %q", got, want)
+	}
+
+	if _, err := newPageLabels(core.Page{Name: "monthly", Footer: "{{.Nope"}); err == nil {
+		t.Error("expected an error for an invalid footer template")
+	}
+}
+
+func TestTextFunc(t *testing.T) {
+	cfg := core.DefaultConfig()
+	if got := textFunc(cfg, "legend"); got != "Legend" {
+		t.Errorf("text = %q, want Legend", got)
+	}
+
+	cfg.Locale = "de-DE"
+	if got := textFunc(cfg, "task_count", 12); got != "12 Aufgaben" {
+		t.Errorf("text = %q, want 12 Aufgaben", got)
+	}
+	cfg.Strings = map[string]string{"complete_pct": "%d% done & %s", "legend": "Key & colors"}
+	if got, want := textFunc(cfg, "complete_pct", 40, `\textbf{x}`), `40\% done \& \textbf{x}`; got != want {
+		t.Errorf("text = %q, want %q", got, want)
+	}
+	if got := textFunc(cfg, "legend"); got != `Key \& colors` {
+		t.Errorf("text = %q, want an escaped override", got)
+	}
+	if got := textFunc(cfg, "week"); got != "Woche" {
+		t.Errorf("text = %q, want Woche", got)
+	}
+}
+
+func TestLoadErrorReportsCycles(t *testing.T) {
+	cycles := &cycleError{cycles: []core.ValidationIssue{{Type: "dependency_cycle", Message: "Circular dependency detected: A → B → A"}}}
+
+	err := loadError(fmt.Errorf("loading: %w", cycles))
+	if code := ExitCode(err); code != ExitValidation {
+		t.Errorf("ExitCode = %d, want %d", code, ExitValidation)
+	}
+	if !strings.Contains(err.Error(), "A → B → A") {
+		t.Errorf("error %q does not name the chain", err)
+	}
+	var got *cycleError
+	if !errors.As(err, &got) || len(got.cycles) != 1 {
+		t.Errorf("loadError lost the cycles: %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"phd-dissertation-planner/internal/core"
+
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	fInitDir   = "dir"
+	fInitForce = "force"
+)
+
+// starterConfig is a minimal configuration producing yearly and monthly pages
+const starterConfig = `# Planner configuration; see the full example in the project repository
+# for every option (holidays, capacity, templates, extra page types, ...)
+
+weekstart: 1
+outputdir: output_data
+locale: en-US
+
+layout:
+  paper:
+    width: 15.6cm
+    height: 21.5cm
+    margin:
+      top: 0.2cm
+      bottom: 0.5cm
+      left: 0.5cm
+      right: 0.5cm
+
+pages:
+  - name: yearly
+    renderblocks:
+      - funcname: yearly
+        tpls:
+          - yearly.tpl
+  - name: monthly
+    renderblocks:
+      - funcname: monthly
+        tpls:
+          - page.tpl
+`
+
+// starterCSVHeader lists the columns the reader expects
+const starterCSVHeader = "Phase,Task ID,Dependencies,Task,Start Date,End Date,Objective,Milestone,Status,Notes,Category,Priority,Assignee,Resources\n"
+
+// starterMakefile is a snippet to include from a project Makefile
+const starterMakefile = `# Planner targets; add "include planner.mk" to your Makefile
+PLANNER ?= plannergen
+
+.PHONY: planner planner-validate planner-watch
+
+planner:
+	$(PLANNER) --config input_data/config.yaml
+
+planner-validate:
+	$(PLANNER) --config input_data/config.yaml validate
+
+planner-watch:
+	$(PLANNER) --config input_data/config.yaml watch
+`
+
+// initCommand scaffolds a new planner project
+func initCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "init",
+		Usage:     "create a starter config, an example CSV and a Makefile snippet",
+		UsageText: "plannergen init [--dir DIR] [--force]",
+		Flags: []cli.Flag{
+			&cli.PathFlag{Name: fInitDir, Required: false, Value: ".", Usage: "project directory to create the files in"},
+			&cli.BoolFlag{Name: fInitForce, Required: false, Usage: "overwrite files that already exist"},
+		},
+		Action: runInit,
+	}
+}
+
+// runInit writes the starter files, leaving existing ones alone unless forced
+func runInit(c *cli.Context) error {
+	dir := c.Path(fInitDir)
+	files := []struct {
+		path, content string
+	}{
+		{filepath.Join(dir, inputDataDir, "config.yaml"), starterConfig},
+		{filepath.Join(dir, inputDataDir, "tasks.csv"), starterCSV(time.Now())},
+		{filepath.Join(dir, "planner.mk"), starterMakefile},
+	}
+
+	for _, f := range files {
+		if _, err := os.Stat(f.path); err == nil && !c.Bool(fInitForce) {
+			fmt.Println(core.Warning(fmt.Sprintf("   ⏭️  %s exists, skipped (use --force to overwrite)", f.path)))
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+			return core.NewFileError(filepath.Dir(f.path), "create directory", err)
+		}
+		if err := os.WriteFile(f.path, []byte(f.content), 0o644); err != nil {
+			return core.NewFileError(f.path, "write", err)
+		}
+		fmt.Printf("   📝 %s\n", f.path)
+	}
+
+	fmt.Println(core.Success("✅ Planner project ready"))
+	fmt.Println(core.DimText("   Edit input_data/tasks.csv, then run: plannergen --config input_data/config.yaml"))
+	return nil
+}
+
+// starterCSV returns example tasks starting on the first of next month, so
+// the starter planner covers the coming months
+func starterCSV(now time.Time) string {
+	start := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	day := func(offset int) string {
+		return start.AddDate(0, 0, offset).Format(core.DateFormatISO)
+	}
+
+	rows := []string{
+		fmt.Sprintf("Planning,P1,,Literature review,%s,%s,Survey prior work,false,planned,,Research,High,Student,", day(0), day(27)),
+		fmt.Sprintf("Planning,P2,P1,Write proposal,%s,%s,Draft the research proposal,false,planned,,Writing,High,Student,", day(28), day(55)),
+		fmt.Sprintf("Planning,M1,P2,Proposal submitted,%s,%s,Proposal sent to the committee,true,planned,,Milestone,Critical,Student,", day(56), day(56)),
+	}
+
+	csv := starterCSVHeader
+	for _, row := range rows {
+		csv += row + "\n"
+	}
+	return csv
+}
@@ -0,0 +1,30 @@
+package app
+
+import (
+	"phd-dissertation-planner/internal/core"
+)
+
+// legendTpl is the built-in template for the category legend page
+const legendTpl = "legend.tpl"
+
+// Legend composes a page listing the categories of the laid out tasks with
+// their color, task count and total days
+func Legend(cfg core.Config, tpls []string) (core.Modules, error) {
+	tplName := legendTpl
+	if len(tpls) > 0 {
+		tplName = tpls[0]
+	}
+
+	categories := cfg.CategoryUsages(cfg.VisibleTasks())
+	if len(categories) == 0 {
+		return core.Modules{}, nil
+	}
+
+	return core.Modules{{
+		Cfg: cfg,
+		Tpl: tplName,
+		Body: map[string]interface{}{
+			"Categories": categories,
+		},
+	}}, nil
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"time"
+
+	cal "phd-dissertation-planner/internal/calendar"
+	"phd-dissertation-planner/internal/core"
+)
+
+// monthStripTpl is the built-in template for vertical month-strip pages
+const monthStripTpl = "monthstrip.tpl"
+
+// MonthStrip composes one vertical page per month with tasks: days run down
+// the page and task bars span their rows
+func MonthStrip(cfg core.Config, tpls []string) (core.Modules, error) {
+	tplName := monthStripTpl
+	if len(tpls) > 0 {
+		tplName = tpls[0]
+	}
+
+	tasks := cfg.VisibleTasks()
+	modules := make(core.Modules, 0, len(cfg.MonthsWithTasks))
+	for _, monthYear := range cfg.MonthsWithTasks {
+		year := cal.NewYear(cfg.WeekStart, monthYear.Year, &cfg)
+		month := year.Quarters[(int(monthYear.Month)-1)/3].Months[(int(monthYear.Month)-1)%3]
+		assignTasksToMonth(month, tasks)
+
+		modules = append(modules, core.Module{
+			Cfg:  cfg,
+			Tpl:  tplName,
+			Date: time.Date(monthYear.Year, monthYear.Month, 1, 0, 0, 0, 0, time.UTC),
+			Body: map[string]interface{}{
+				"Strip":      cal.NewMonthStrip(month),
+				"Breadcrumb": month.Breadcrumb(),
+				"Extra":      month.PrevNext().WithTopRightCorner(cfg.ClearTopRightCorner, cfg.Layout.Calendar.TaskKernSpacing),
+			},
+		})
+	}
+
+	return modules, nil
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	cal "phd-dissertation-planner/internal/calendar"
+	"phd-dissertation-planner/internal/core"
+)
+
+// overflowTpl is the built-in template for the overflow appendix
+const overflowTpl = "overflow.tpl"
+
+// Overflow composes the appendix listing the tasks collapsed into "+N more"
+// in crowded month cells, each day linking back to its cell
+func Overflow(cfg core.Config, tpls []string) (core.Modules, error) {
+	tplName := overflowTpl
+	if len(tpls) > 0 {
+		tplName = tpls[0]
+	}
+
+	days := overflowDays(cfg)
+	if len(days) == 0 {
+		return core.Modules{}, nil
+	}
+
+	return core.Modules{{
+		Cfg: cfg,
+		Tpl: tplName,
+		Body: map[string]interface{}{
+			"Days":   days,
+			"Locale": cfg.GetLocale(),
+		},
+	}}, nil
+}
+
+// overflowDays returns the month-grid days that hide tasks past the threshold
+func overflowDays(cfg core.Config) []cal.Day {
+	if cfg.Layout.Calendar.MaxTasksPerDay <= 0 {
+		return nil
+	}
+
+	tasks := cfg.VisibleTasks()
+	var days []cal.Day
+	for _, monthYear := range cfg.MonthsWithTasks {
+		year := cal.NewYear(cfg.WeekStart, monthYear.Year, &cfg)
+		month := year.Quarters[(int(monthYear.Month)-1)/3].Months[(int(monthYear.Month)-1)%3]
+		assignTasksToMonth(month, tasks)
+
+		for _, week := range month.Weeks {
+			for _, day := range week.Days {
+				if day.Time.IsZero() || day.Time.Month() != month.Month {
+					continue
+				}
+				if len(day.OverflowTasks()) > 0 {
+					days = append(days, day)
+				}
+			}
+		}
+	}
+
+	return days
+}
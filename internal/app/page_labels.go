@@ -0,0 +1,87 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"phd-dissertation-planner/internal/core"
+)
+
+// pageLabel is the data of the header and footer templates of a page
+type pageLabel struct {
+	Page      string    // Name of the page in the pages list
+	Number    int       // Sheet of the page, from 1
+	Date      time.Time // First day the sheet shows; zero on pages without one
+	MonthName string    // Localized month of Date
+	Year      int
+	Body      interface{} // Data of the sheet's template
+}
+
+// pageLabels renders the header and footer templates of a page
+type pageLabels struct {
+	page           string
+	header, footer *template.Template
+}
+
+// newPageLabels parses the header and footer templates of a page
+func newPageLabels(page core.Page) (*pageLabels, error) {
+	l := &pageLabels{page: page.Name}
+	var err error
+	if l.header, err = parsePageLabel(page.Name, "header", page.Header); err != nil {
+		return nil, err
+	}
+	if l.footer, err = parsePageLabel(page.Name, "footer", page.Footer); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// parsePageLabel parses one label template, nil when it is empty
+func parsePageLabel(page, field, text string) (*template.Template, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	t, err := template.New(page + " " + field).Funcs(TemplateFuncs()).Parse(text)
+	if err != nil {
+		return nil, core.NewConfigError("", fmt.Sprintf("pages[%s].%s", page, field), "invalid template", err)
+	}
+	return t, nil
+}
+
+// write sets the header and footer of the sheets of a module; pages without
+// templates clear them
+func (l *pageLabels) write(wr io.Writer, number int, mod core.Module) error {
+	label := pageLabel{Page: l.page, Number: number, Date: mod.Date, Body: mod.Body}
+	if !mod.Date.IsZero() {
+		label.MonthName = mod.Cfg.GetLocale().MonthName(mod.Date.Month())
+		label.Year = mod.Date.Year()
+	}
+
+	var header, footer strings.Builder
+	if l.header != nil {
+		if err := l.header.Execute(&header, label); err != nil {
+			return core.NewTemplateError(l.header.Name(), 0, "failed to execute the page header", err)
+		}
+	}
+	if l.footer != nil {
+		if err := l.footer.Execute(&footer, label); err != nil {
+			return core.NewTemplateError(l.footer.Name(), 0, "failed to execute the page footer", err)
+		}
+	}
+	_, err := fmt.Fprintf(wr, "\\PageLabels{%s}{%s}%%\n", header.String(), footer.String())
+	return err
+}
+
+// monthPages are the composers whose sheets each show one month
+var monthPages = map[string]bool{"monthly": true, "monthstrip": true}
+
+// writeMonthSection opens the section of a month sheet, which sets the running
+// head and, with month sections, numbers and bookmarks the month
+func writeMonthSection(wr io.Writer, mod core.Module) error {
+	month := mod.Cfg.GetLocale().MonthName(mod.Date.Month())
+	_, err := fmt.Fprintf(wr, "\\MonthSection{%s %d}%%\n", month, mod.Date.Year())
+	return err
+}
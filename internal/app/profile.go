@@ -0,0 +1,137 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	fCPUProfile = "cpuprofile"
+	fMemProfile = "memprofile"
+	fTrace      = "trace"
+)
+
+// profiler writes the profiles asked for on the command line, covering the
+// whole run of the command
+type profiler struct {
+	cpu     *os.File
+	trace   *os.File
+	memPath string
+}
+
+// activeProfiler is the profiler of the current run, if any
+var activeProfiler *profiler
+
+// profilePaths returns the CPU, heap and trace profile paths from the flags.
+// PLANNER_MEMORY_PROFILE=true writes memory.prof (CPU) and heap.prof to
+// PLANNER_PROFILE_DIR, or profiles/, as earlier versions did.
+func profilePaths(c *cli.Context) (cpu, mem, tr string, err error) {
+	cpu, mem, tr = c.Path(fCPUProfile), c.Path(fMemProfile), c.Path(fTrace)
+	if os.Getenv("PLANNER_MEMORY_PROFILE") == "true" && cpu == "" && mem == "" {
+		dir := os.Getenv("PLANNER_PROFILE_DIR")
+		if dir == "" {
+			dir = "profiles"
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return "", "", "", fmt.Errorf("failed to create profile directory: %w", err)
+		}
+		cpu, mem = filepath.Join(dir, "memory.prof"), filepath.Join(dir, "heap.prof")
+	}
+	return cpu, mem, tr, nil
+}
+
+// startProfiling starts the CPU profile and execution trace, and notes where
+// to write the heap profile when the run ends
+func startProfiling(c *cli.Context) error {
+	cpu, mem, tr, err := profilePaths(c)
+	if err != nil {
+		return formatError("Profiling", "Unable to set up profiling", err)
+	}
+	if cpu == "" && mem == "" && tr == "" {
+		return nil
+	}
+
+	p := &profiler{memPath: mem}
+	if cpu != "" {
+		if p.cpu, err = os.Create(cpu); err != nil {
+			return formatError("Profiling", "Unable to create the CPU profile", err, "Check that the directory of --"+fCPUProfile+" exists and is writable")
+		}
+		if err := pprof.StartCPUProfile(p.cpu); err != nil {
+			p.cpu.Close()
+			return formatError("Profiling", "Unable to start CPU profiling", err)
+		}
+	}
+	if tr != "" {
+		if p.trace, err = os.Create(tr); err != nil {
+			p.stop()
+			return formatError("Profiling", "Unable to create the execution trace", err, "Check that the directory of --"+fTrace+" exists and is writable")
+		}
+		if err := trace.Start(p.trace); err != nil {
+			p.trace.Close()
+			p.trace = nil
+			p.stop()
+			return formatError("Profiling", "Unable to start the execution trace", err)
+		}
+	}
+	activeProfiler = p
+	return nil
+}
+
+// stopProfiling ends the profiles of the run. Failures are logged rather than
+// returned so they do not change the exit status of the command.
+func stopProfiling(*cli.Context) error {
+	if activeProfiler == nil {
+		return nil
+	}
+	activeProfiler.stop()
+	activeProfiler = nil
+	return nil
+}
+
+// stop stops the CPU profile and trace, then writes the heap profile
+func (p *profiler) stop() {
+	if p.cpu != nil {
+		pprof.StopCPUProfile()
+		if err := p.cpu.Close(); err != nil {
+			logger.Warn("Failed to write CPU profile: %v", err)
+		} else {
+			logger.Info("CPU profile written to %s (go tool pprof %s)", p.cpu.Name(), p.cpu.Name())
+		}
+	}
+	if p.trace != nil {
+		trace.Stop()
+		if err := p.trace.Close(); err != nil {
+			logger.Warn("Failed to write execution trace: %v", err)
+		} else {
+			logger.Info("Execution trace written to %s (go tool trace %s)", p.trace.Name(), p.trace.Name())
+		}
+	}
+	if p.memPath != "" {
+		if err := writeHeapProfile(p.memPath); err != nil {
+			logger.Warn("Failed to write heap profile: %v", err)
+		} else {
+			logger.Info("Heap profile written to %s (go tool pprof %s)", p.memPath, p.memPath)
+		}
+	}
+}
+
+// writeHeapProfile writes the heap profile, after a garbage collection so it
+// shows the memory still in use as well as all allocations of the run
+func writeHeapProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"time"
+
+	cal "phd-dissertation-planner/internal/calendar"
+	"phd-dissertation-planner/internal/core"
+)
+
+// quarterlyTpl is the built-in template for quarter pages
+const quarterlyTpl = "quarterly.tpl"
+
+// Quarterly composes one page per quarter that has tasks, with the three
+// months side by side and task bars spanning across them
+func Quarterly(cfg core.Config, tpls []string) (core.Modules, error) {
+	tplName := quarterlyTpl
+	if len(tpls) > 0 {
+		tplName = tpls[0]
+	}
+
+	tasks := cfg.VisibleTasks()
+	spanningTasks := make([]cal.SpanningTask, 0, len(tasks))
+	for _, task := range tasks {
+		spanningTasks = append(spanningTasks, cal.CreateSpanningTask(&cfg, task, task.StartDate, task.EndDate))
+	}
+
+	var modules core.Modules
+	for _, yearNum := range planningYears(cfg) {
+		year := cal.NewYear(cfg.WeekStart, yearNum, &cfg)
+
+		for _, quarter := range year.Quarters {
+			view := cal.NewQuarterView(quarter, spanningTasks, &cfg)
+			if !view.HasTasks() {
+				continue
+			}
+
+			modules = append(modules, core.Module{
+				Cfg:  cfg,
+				Tpl:  tplName,
+				Date: time.Date(yearNum, quarter.Months[0].Month, 1, 0, 0, 0, 0, time.UTC),
+				Body: map[string]interface{}{
+					"View":       view,
+					"QuarterRef": view.Ref(),
+					"Breadcrumb": view.Breadcrumb(),
+					"Locale":     cfg.GetLocale(),
+				},
+			})
+		}
+	}
+
+	return modules, nil
+}
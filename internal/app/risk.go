@@ -0,0 +1,35 @@
+package app
+
+import (
+	cal "phd-dissertation-planner/internal/calendar"
+	"phd-dissertation-planner/internal/core"
+)
+
+// riskTpl is the built-in template for the schedule risk page
+const riskTpl = "risk.tpl"
+
+// Risk composes a page with the simulated confidence dates of every milestone
+// and the finish distribution of the last one
+func Risk(cfg core.Config, tpls []string) (core.Modules, error) {
+	tplName := riskTpl
+	if len(tpls) > 0 {
+		tplName = tpls[0]
+	}
+
+	result, err := cfg.SimulateSchedule(cfg.Tasks)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Milestones) == 0 {
+		return core.Modules{}, nil
+	}
+
+	return core.Modules{{
+		Cfg: cfg,
+		Tpl: tplName,
+		Body: map[string]interface{}{
+			"Risk":   cal.NewRiskReport(result, &cfg),
+			"Locale": cfg.GetLocale(),
+		},
+	}}, nil
+}
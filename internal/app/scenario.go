@@ -0,0 +1,80 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"phd-dissertation-planner/internal/core"
+)
+
+// loadScenario reads the proposed plan, dates it like the current one and
+// records which tasks change. Leveling is not applied to the proposed plan,
+// so its moves are not mixed into the comparison.
+func loadScenario(cfg *core.Config) error {
+	files := cfg.Scenario.ProposedFiles()
+	if len(files) == 0 {
+		return nil
+	}
+
+	proposed, err := core.ReadTasksFromMultipleFiles(files)
+	if err != nil {
+		return fmt.Errorf("proposed plan: %w", err)
+	}
+	if err := cfg.ApplyTimezone(proposed); err != nil {
+		return fmt.Errorf("proposed plan: %w", err)
+	}
+	if err := cfg.DetectMilestones(proposed); err != nil {
+		return fmt.Errorf("proposed plan: %w", err)
+	}
+	if proposed, err = cfg.ExpandTemplates(proposed); err != nil {
+		return fmt.Errorf("proposed plan: %w", err)
+	}
+	if err := cfg.ScheduleTasks(proposed); err != nil {
+		return fmt.Errorf("proposed plan: %w", err)
+	}
+
+	cfg.SetScenarioShifts(core.CompareSchedules(cfg.Tasks, proposed))
+	return nil
+}
+
+// printScenarioComparison lists the milestones, tasks and months that move in
+// the proposed plan
+func printScenarioComparison(cfg core.Config) {
+	if len(cfg.Scenario.ProposedFiles()) == 0 {
+		return
+	}
+
+	fmt.Println(core.DimText("═══════════════════════════════════════"))
+	shifts := cfg.ScenarioShifts()
+	if len(shifts) == 0 {
+		fmt.Printf("%s", core.Success("🔀 What-if: the proposed plan matches the current one\n"))
+		return
+	}
+
+	var milestones, tasks []core.TaskShift
+	for _, s := range shifts {
+		if s.IsMilestone {
+			milestones = append(milestones, s)
+		} else {
+			tasks = append(tasks, s)
+		}
+	}
+
+	fmt.Printf("%s", core.Info(fmt.Sprintf("🔀 What-if: %d milestone(s) and %d task(s) change in the proposed plan\n",
+		len(milestones), len(tasks))))
+	for _, s := range milestones {
+		fmt.Println(core.Warning("   ★ " + s.String()))
+	}
+	for _, s := range tasks {
+		fmt.Printf("   %s\n", s)
+	}
+
+	months := core.ShiftedMonths(shifts)
+	if len(months) > 0 {
+		labels := make([]string, 0, len(months))
+		for _, m := range months {
+			labels = append(labels, m.Format("Jan 2006"))
+		}
+		fmt.Printf("%s", core.Info(fmt.Sprintf("   Months affected: %s\n", strings.Join(labels, ", "))))
+	}
+}
@@ -0,0 +1,205 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"phd-dissertation-planner/internal/core"
+)
+
+// printSlackReport lists the tasks that cannot slip without moving the end of
+// the plan, or the slack of every task when all is set
+func printSlackReport(cfg core.Config, all bool) {
+	if !hasDependencies(cfg.Tasks) {
+		return
+	}
+
+	cpm, err := cfg.ComputeCPM(cfg.Tasks)
+	if err != nil || len(cpm.Tasks) == 0 {
+		return
+	}
+
+	names := make(map[string]string, len(cfg.Tasks))
+	for _, task := range cfg.Tasks {
+		names[task.ID] = task.Name
+	}
+
+	fmt.Println(core.DimText("═══════════════════════════════════════"))
+	fmt.Printf("%s", core.Info(fmt.Sprintf("🧭 Schedule slack: %d of %d tasks cannot slip without moving %s\n",
+		cpm.CriticalCount(), len(cpm.Tasks), cpm.Finish.Format("2006-01-02"))))
+
+	for _, t := range cpm.BySlack() {
+		if !all && !t.Critical() {
+			break
+		}
+		line := fmt.Sprintf("   %-8s %-45s %4dd  latest start %s",
+			t.ID, names[t.ID], t.TotalFloat, t.LateStart.Format("2006-01-02"))
+		if t.Critical() {
+			fmt.Println(core.Warning(line))
+		} else {
+			fmt.Println(line)
+		}
+	}
+}
+
+// printLevelingMoves lists the tasks the workload leveling pass moved
+func printLevelingMoves(cfg core.Config) {
+	moves := cfg.LevelingMoves()
+	if len(moves) == 0 {
+		return
+	}
+
+	fmt.Printf("%s", core.Info(fmt.Sprintf("⚖️  Workload leveling moved %d task(s):\n", len(moves))))
+	for _, move := range moves {
+		fmt.Printf("   %s\n", move)
+	}
+}
+
+// printBaselineVariance lists the tasks finishing later than their baseline
+func printBaselineVariance(cfg core.Config) {
+	var slipped []core.Task
+	baselined := 0
+	for _, task := range cfg.Tasks {
+		if !task.HasBaseline() {
+			continue
+		}
+		baselined++
+		if cfg.IsSlipped(task) {
+			slipped = append(slipped, task)
+		}
+	}
+	if baselined == 0 {
+		return
+	}
+
+	fmt.Println(core.DimText("═══════════════════════════════════════"))
+	if len(slipped) == 0 {
+		fmt.Printf("%s", core.Success(fmt.Sprintf("📏 Baseline: all %d baselined tasks on schedule\n", baselined)))
+		return
+	}
+
+	sort.SliceStable(slipped, func(i, j int) bool {
+		return slipped[i].FinishVariance() > slipped[j].FinishVariance()
+	})
+	fmt.Printf("%s", core.Info(fmt.Sprintf("📏 Baseline: %d of %d baselined tasks slipped\n", len(slipped), baselined)))
+	for _, task := range slipped {
+		fmt.Println(core.Warning(fmt.Sprintf("   %-8s %-45s finish %+4dd  start %+4dd  (baseline end %s)",
+			task.ID, task.Name, task.FinishVariance(), task.StartVariance(), task.BaselineEnd.Format("2006-01-02"))))
+	}
+}
+
+// printDeadlineMisses warns about tasks scheduled to end after their deadline
+func printDeadlineMisses(cfg core.Config) {
+	misses := core.DeadlineMisses(cfg.Tasks)
+	if len(misses) == 0 {
+		return
+	}
+
+	fmt.Println(core.Warning(fmt.Sprintf("⏰ %d task(s) end after their deadline:", len(misses))))
+	for _, issue := range misses {
+		fmt.Printf("   %s\n", issue.Message)
+	}
+}
+
+// printBlackoutConflicts warns about tasks dated across a blackout period
+func printBlackoutConflicts(cfg core.Config) {
+	conflicts := cfg.BlackoutConflicts(cfg.Tasks)
+	if len(conflicts) == 0 {
+		return
+	}
+
+	fmt.Println(core.Warning(fmt.Sprintf("🏖️  %d task(s) run across a blackout period:", len(conflicts))))
+	for _, issue := range conflicts {
+		fmt.Printf("   %s\n", issue.Message)
+	}
+}
+
+// reportSuggestions prints date shifts that resolve critical overlaps and,
+// when patchDir is set, writes copies of the CSV files with them applied
+func reportSuggestions(cfg core.Config, csvFiles []string, patchDir string, silent bool) error {
+	suggestions, err := core.SuggestReschedule(cfg.Tasks)
+	if err != nil {
+		return err
+	}
+
+	if !silent {
+		fmt.Println(core.DimText("═══════════════════════════════════════"))
+		if len(suggestions) == 0 {
+			fmt.Printf("%s", core.Success("💡 No dependency or critical-task overlaps to resolve\n"))
+		} else {
+			fmt.Printf("%s", core.Info(fmt.Sprintf("💡 %d suggested date shift(s):\n", len(suggestions))))
+			for _, s := range suggestions {
+				line := "   " + s.String()
+				if s.Critical {
+					fmt.Println(core.Warning(line))
+				} else {
+					fmt.Println(line)
+				}
+			}
+		}
+	}
+
+	if patchDir == "" || len(suggestions) == 0 {
+		return nil
+	}
+	if cfg.DryRun {
+		if !silent {
+			for _, src := range csvFiles {
+				fmt.Printf("   📝 %s would be patched (dry run)\n", filepath.Join(patchDir, filepath.Base(src)))
+			}
+		}
+		return nil
+	}
+	if err := os.MkdirAll(patchDir, 0o755); err != nil {
+		return core.NewFileError(patchDir, "create directory", err)
+	}
+	for _, src := range csvFiles {
+		dst := filepath.Join(patchDir, filepath.Base(src))
+		n, err := core.PatchCSV(src, dst, suggestions)
+		if err != nil {
+			return err
+		}
+		if !silent {
+			fmt.Printf("   📝 %s: %d row(s) patched\n", dst, n)
+		}
+	}
+	return nil
+}
+
+// printCapacityOverloads warns about weeks planned beyond someone's capacity
+func printCapacityOverloads(cfg core.Config) {
+	overloads := cfg.CheckCapacity(cfg.Tasks)
+	if len(overloads) == 0 {
+		return
+	}
+
+	fmt.Println(core.Warning(fmt.Sprintf("🏋️  %d week(s) planned over capacity:", len(overloads))))
+	for _, o := range overloads {
+		fmt.Printf("   %s\n", o)
+	}
+}
+
+// printSimulationReport prints the Monte Carlo confidence dates of each milestone
+func printSimulationReport(cfg core.Config) {
+	result, err := cfg.SimulateSchedule(cfg.Tasks)
+	if err != nil {
+		logger.Warn("Risk simulation skipped: %v", err)
+		return
+	}
+	if len(result.Milestones) == 0 {
+		return
+	}
+
+	fmt.Println(core.DimText("═══════════════════════════════════════"))
+	fmt.Printf("%s", core.Info(fmt.Sprintf("🎲 Risk simulation (%d trials):\n", result.Trials)))
+	for _, m := range result.Milestones {
+		line := fmt.Sprintf("   %-8s %-40s planned %s  on time %3.0f%%",
+			m.ID, m.Name, m.Planned.Format("2006-01-02"), m.OnTimeProbability()*100)
+		for _, p := range result.Percentiles {
+			line += fmt.Sprintf("  P%d %s", p, m.Percentile(p).Format("2006-01-02"))
+		}
+		fmt.Println(line)
+	}
+}
@@ -0,0 +1,222 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	cal "phd-dissertation-planner/internal/calendar"
+	"phd-dissertation-planner/internal/core"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	fServeAddr = "addr"
+	fServeAPI  = "api"
+)
+
+// previewPage is the HTML preview; it polls /version and reloads when the
+// plan is rebuilt
+var previewPage = template.Must(template.New("preview").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Planner preview</title>
+<style>
+body { font-family: sans-serif; margin: 1.5em; color: #222; }
+.error { background: #fdecea; border: 1px solid #e57373; padding: 0.8em; white-space: pre-wrap; }
+.warnings { color: #8a5a00; }
+.meta { color: #777; font-size: 0.9em; }
+</style>
+</head>
+<body>
+<h1>Planner preview</h1>
+<p class="meta">Built {{.Built.Format "15:04:05"}}; reloads when the CSV or config files change.</p>
+{{if .Error}}<div class="error">{{.Error}}</div>{{else}}
+<p>{{.Tasks}} task(s), {{.Milestones}} milestone(s){{if .Range}}, {{.Range}}{{end}}</p>
+{{if .Warnings}}<details class="warnings"><summary>{{len .Warnings}} schedule conflict(s)</summary><ul>
+{{range .Warnings}}<li>{{.Message}}</li>
+{{end}}</ul></details>{{end}}
+{{.Chart}}
+{{end}}
+<script>
+const version = "{{.Version}}";
+setInterval(() => fetch("/version").then(r => r.text()).then(v => { if (v !== version) location.reload(); }).catch(() => {}), 1000);
+</script>
+</body>
+</html>
+`))
+
+// serveCommand serves a live HTML preview of the plan
+func serveCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "serve",
+		Usage:     "serve an HTML/SVG preview of the plan that refreshes when the data changes",
+		UsageText: "plannergen [--config FILE] serve [--addr localhost:8080] [--api]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: fServeAddr, Required: false, Value: "localhost:8080", Usage: "address to listen on"},
+			&cli.BoolFlag{Name: fServeAPI, Required: false, Usage: "also serve the JSON API for uploads, validation reports and generated bundles"},
+		},
+		Action: runServe,
+	}
+}
+
+// preview holds the latest load of the plan for the HTTP handlers
+type preview struct {
+	mu      sync.RWMutex
+	cfg     core.Config
+	err     error
+	built   time.Time
+	version int
+}
+
+// reload loads the plan again; a failed load is shown on the page
+func (p *preview) reload(c *cli.Context) {
+	cfg, err := loadPlan(c)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cfg, p.err = cfg, err
+	p.built = time.Now()
+	p.version++
+}
+
+// follow watches the files named by the config of the last good load
+func (p *preview) follow(w *inputWatcher) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.err == nil {
+		w.follow(p.cfg)
+	}
+}
+
+// ServeHTTP renders the preview page
+func (p *preview) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	data := map[string]interface{}{
+		"Built":   p.built,
+		"Version": strconv.Itoa(p.version),
+	}
+	if p.err != nil {
+		data["Error"] = p.err.Error()
+	} else {
+		tasks := p.cfg.VisibleTasks()
+		spanningTasks := make([]cal.SpanningTask, 0, len(tasks))
+		milestones := 0
+		for _, task := range tasks {
+			spanningTasks = append(spanningTasks, cal.CreateSpanningTask(&p.cfg, task, task.StartDate, task.EndDate))
+			if task.IsMilestone {
+				milestones++
+			}
+		}
+		// The SVG is generated from escaped task data
+		data["Chart"] = template.HTML(cal.NewGanttChart(spanningTasks, &p.cfg).SVG())
+		data["Tasks"] = len(tasks)
+		data["Milestones"] = milestones
+		data["Warnings"] = scheduleWarnings(p.cfg)
+		if len(tasks) > 0 {
+			r := core.CalculateDateRange(tasks)
+			data["Range"] = r.Earliest.Format(core.DateFormatISO) + " to " + r.Latest.Format(core.DateFormatISO)
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := previewPage.Execute(w, data); err != nil {
+		logger.Warn("Preview rendering failed: %v", err)
+	}
+}
+
+// serveVersion reports the build counter the page polls for changes
+func (p *preview) serveVersion(w http.ResponseWriter, _ *http.Request) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	w.Header().Set("Content-Type", "text/plain")
+	fmt.Fprint(w, p.version)
+}
+
+// runServe serves the preview and reloads the plan, config included, after
+// changes to the CSV files, the config or the files it names, until
+// interrupted
+func runServe(c *cli.Context) error {
+	watcher, err := newInputWatcher(c)
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	p := &preview{}
+	p.reload(c)
+	p.follow(watcher)
+
+	mux := http.NewServeMux()
+	mux.Handle("/", p)
+	mux.HandleFunc("/version", p.serveVersion)
+	if c.Bool(fServeAPI) {
+		(&planAPI{ctx: c, preview: p}).register(mux)
+	}
+	server := &http.Server{Addr: c.String(fServeAddr), Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	failed := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			failed <- err
+		}
+	}()
+	fmt.Println(core.Success(fmt.Sprintf("🌐 Preview at http://%s (Ctrl+C to stop)", server.Addr)))
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(stop)
+
+	var pending <-chan time.Time
+	var changed string
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Has(fsnotify.Chmod) || !watcher.isInput(event.Name) {
+				continue
+			}
+			changed = event.Name
+			pending = time.After(300 * time.Millisecond)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			logger.Warn("File watcher error: %v", err)
+		case <-pending:
+			pending = nil
+			fmt.Println(core.Info(fmt.Sprintf("🔄 %s changed, reloading preview...", filepath.Base(changed))))
+			p.reload(c)
+			p.follow(watcher)
+		case err := <-failed:
+			return formatError("Serve", "Unable to serve the preview on "+server.Addr, err,
+				"Choose another address with --addr")
+		case <-stop:
+			fmt.Println()
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			return server.Shutdown(ctx)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"phd-dissertation-planner/internal/core"
+
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	fShiftBy    = "by"
+	fShiftPhase = "phase"
+	fShiftOut   = "out"
+)
+
+// shiftCommand moves task dates in the input CSV files, for when the plan slips
+func shiftCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "shift",
+		Usage:     "move the start and end dates of all tasks, or of one phase, by a number of days",
+		UsageText: `plannergen shift --by 14d [--phase "Aim 2"] [--out DIR]`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: fShiftBy, Required: true, Usage: "shift such as 14d, -7d or 2w"},
+			&cli.StringFlag{Name: fShiftPhase, Required: false, Usage: "only shift tasks whose phase starts with this text"},
+			&cli.PathFlag{Name: fShiftOut, Required: false, Value: "", Usage: "write shifted copies to this directory instead of rewriting the CSV files"},
+		},
+		Action: runShift,
+	}
+}
+
+// runShift rewrites the dates of every input CSV file, or writes shifted copies
+func runShift(c *cli.Context) error {
+	days, err := core.ParseShift(c.String(fShiftBy))
+	if err != nil {
+		return formatError("Shift", "Invalid --by value", err, "Use a number of days or weeks such as 14d, -7d or 2w")
+	}
+
+	csvFiles, err := getAllCSVFiles()
+	if err != nil {
+		return formatError(
+			"CSV File Detection",
+			"Unable to find CSV files to shift",
+			err,
+			"Check that input_data directory exists",
+		)
+	}
+
+	outDir := strings.TrimSpace(c.Path(fShiftOut))
+	if outDir != "" {
+		if err := os.MkdirAll(outDir, 0o755); err != nil {
+			return core.NewFileError(outDir, "create directory", err)
+		}
+	}
+
+	phase := c.String(fShiftPhase)
+	total := 0
+	for _, src := range csvFiles {
+		dst := src
+		if outDir != "" {
+			dst = filepath.Join(outDir, filepath.Base(src))
+		}
+		n, err := core.ShiftCSV(src, dst, days, phase)
+		if err != nil {
+			return formatError("Shift", "Unable to shift "+src, err)
+		}
+		total += n
+		fmt.Printf("   📝 %s: %d row(s) shifted\n", dst, n)
+	}
+
+	scope := "all phases"
+	if strings.TrimSpace(phase) != "" {
+		scope = fmt.Sprintf("phase %q", phase)
+	}
+	fmt.Println(core.Success(fmt.Sprintf("Shifted %d task(s) in %s by %+d day(s)", total, scope, days)))
+	return nil
+}
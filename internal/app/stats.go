@@ -0,0 +1,110 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"phd-dissertation-planner/internal/core"
+
+	"github.com/urfave/cli/v2"
+)
+
+// statsCommand prints plan statistics, phase progress and earned value without
+// generating output
+func statsCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "stats",
+		Usage:  "print task counts, phase progress and earned value metrics for the plan",
+		Action: runStats,
+	}
+}
+
+// runStats loads the plan the same way as generation and prints its statistics
+func runStats(c *cli.Context) error {
+	csvFiles, err := getAllCSVFiles()
+	if err != nil {
+		return formatError(
+			"CSV File Detection",
+			"Unable to find CSV files to analyze",
+			err,
+			"Check that input_data directory exists",
+		)
+	}
+
+	tasks, err := core.ReadTasksFromMultipleFiles(csvFiles)
+	if err != nil {
+		return formatError("CSV Merging", "Unable to merge CSV files", err)
+	}
+
+	cfg, _, err := loadConfigurationWithTasks(c, tasks)
+	if err != nil {
+		return loadError(err)
+	}
+
+	printTaskCounts(cfg.Tasks)
+	printPhaseProgress(core.PhaseRollups(cfg.Tasks))
+	printEarnedValue(cfg.ComputeEarnedValue(cfg.Tasks))
+	return nil
+}
+
+// printTaskCounts prints the number of tasks, milestones and completed tasks
+func printTaskCounts(tasks []core.Task) {
+	milestones, completed := 0, 0
+	for _, task := range tasks {
+		if task.IsMilestone {
+			milestones++
+		}
+		if strings.EqualFold(strings.TrimSpace(task.Status), "completed") {
+			completed++
+		}
+	}
+
+	fmt.Println(core.BoldText("📊 Plan Statistics"))
+	fmt.Println(core.DimText("═══════════════════════════════════════"))
+	fmt.Printf("   Tasks:      %d\n", len(tasks))
+	fmt.Printf("   Milestones: %d\n", milestones)
+	fmt.Printf("   Completed:  %d\n", completed)
+}
+
+// printPhaseProgress prints each phase's completion, weighted by task length
+func printPhaseProgress(rollups []core.PhaseRollup) {
+	if len(rollups) == 0 {
+		return
+	}
+
+	fmt.Println(core.DimText("═══════════════════════════════════════"))
+	fmt.Println(core.Info("🧩 Phase progress (weighted by task length)"))
+	fmt.Printf("   %-40s %6s %9s\n", "Phase", "Tasks", "Complete")
+	for _, r := range rollups {
+		fmt.Printf("   %-40s %6d %8.0f%%\n", truncateFunc(40, r.Phase), r.Tasks, r.Progress)
+	}
+}
+
+// printEarnedValue prints the earned value table, overall and per category
+func printEarnedValue(report core.EarnedValueReport) {
+	if report.Overall.Tasks == 0 {
+		return
+	}
+
+	fmt.Println(core.DimText("═══════════════════════════════════════"))
+	fmt.Printf("%s", core.Info(fmt.Sprintf("📈 Earned value as of %s (task-days)\n", report.AsOf.Format("2006-01-02"))))
+	fmt.Printf("   %-36s %6s %8s %8s %8s %6s %6s\n", "Category", "Tasks", "PV", "EV", "AC", "SPI", "CPI")
+
+	// Without actual days there is no cost, and CPI would only repeat SPI
+	line := func(e core.EarnedValue) string {
+		ac, cpi := "-", "-"
+		if e.HasCost() {
+			ac, cpi = fmt.Sprintf("%.1f", e.AC), fmt.Sprintf("%.2f", e.CPI())
+		}
+		return fmt.Sprintf("   %-36s %6d %8.1f %8.1f %8s %6.2f %6s",
+			truncateFunc(36, e.Name), e.Tasks, e.PV, e.EV, ac, e.SPI(), cpi)
+	}
+	for _, e := range report.Categories {
+		if e.SPI() < 1 {
+			fmt.Println(core.Warning(line(e)))
+		} else {
+			fmt.Println(line(e))
+		}
+	}
+	fmt.Println(core.BoldText(line(report.Overall)))
+}
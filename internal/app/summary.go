@@ -0,0 +1,203 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"phd-dissertation-planner/internal/core"
+)
+
+// outputFile is a file the pipeline wrote, or would have written in a dry run
+type outputFile struct {
+	path string
+	size int
+}
+
+// outputs collects the files of the current generation
+var outputs struct {
+	sync.Mutex
+	files []outputFile
+}
+
+// recordOutput notes a file the pipeline wrote or would have written
+func recordOutput(path string, size int) {
+	outputs.Lock()
+	defer outputs.Unlock()
+	outputs.files = append(outputs.files, outputFile{path: path, size: size})
+}
+
+// takeOutputs returns the recorded files and starts a new list
+func takeOutputs() []outputFile {
+	outputs.Lock()
+	defer outputs.Unlock()
+	files := outputs.files
+	outputs.files = nil
+	return files
+}
+
+// ansiEscape matches the color codes of terminal output
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// runSummary is the machine-readable report of one generation, written by
+// --json-summary for scripts and dashboards
+type runSummary struct {
+	Status      string            `json:"status"` // "ok" or "failed"
+	ExitCode    int               `json:"exit_code"`
+	Error       string            `json:"error,omitempty"`
+	GeneratedAt time.Time         `json:"generated_at"`
+	DurationMS  int64             `json:"duration_ms"`
+	DryRun      bool              `json:"dry_run"`
+	Compiled    bool              `json:"compiled"`
+	CSVFiles    []string          `json:"csv_files"`
+	Statistics  *planStatistics   `json:"statistics,omitempty"` // Absent when the plan did not load
+	Validation  summaryValidation `json:"validation"`
+	Outputs     []summaryOutput   `json:"outputs"`
+
+	start time.Time
+}
+
+// planStatistics are the task counts and layout of the generated plan
+type planStatistics struct {
+	Tasks       int                `json:"tasks"`
+	Milestones  int                `json:"milestones"`
+	Completed   int                `json:"completed"`
+	Months      int                `json:"months"` // Calendar months laid out
+	Phases      []summaryPhase     `json:"phases"`
+	EarnedValue summaryEarnedValue `json:"earned_value"`
+}
+
+// summaryPhase is a phase rollup in the summary
+type summaryPhase struct {
+	Phase    string  `json:"phase"`
+	Start    string  `json:"start,omitempty"`
+	End      string  `json:"end,omitempty"`
+	Tasks    int     `json:"tasks"`
+	Progress float64 `json:"progress"`
+}
+
+// summaryEarnedValue is the overall earned value of the plan, in days
+type summaryEarnedValue struct {
+	AsOf string  `json:"as_of"`
+	BAC  float64 `json:"bac"`
+	PV   float64 `json:"pv"`
+	EV   float64 `json:"ev"`
+	SPI  float64 `json:"spi"`
+	// AC and CPI are left out when no task gives its actual days
+	AC  *float64 `json:"ac,omitempty"`
+	CPI *float64 `json:"cpi,omitempty"`
+}
+
+// summaryValidation holds the issues found while loading the plan
+type summaryValidation struct {
+	Errors   []core.ValidationIssue `json:"errors"`
+	Warnings []core.ValidationIssue `json:"warnings"`
+}
+
+// summaryOutput is a generated file in the summary
+type summaryOutput struct {
+	Path string `json:"path"`
+	Size int    `json:"size"`
+}
+
+// newRunSummary starts the summary of a generation
+func newRunSummary(dryRun bool) *runSummary {
+	return &runSummary{
+		DryRun:     dryRun,
+		CSVFiles:   []string{},
+		Validation: summaryValidation{Errors: []core.ValidationIssue{}, Warnings: []core.ValidationIssue{}},
+		Outputs:    []summaryOutput{},
+		start:      time.Now(),
+	}
+}
+
+// addPlan records the statistics and schedule warnings of the loaded plan
+func (s *runSummary) addPlan(cfg core.Config, warnings []core.ValidationIssue) {
+	stats := &planStatistics{Tasks: len(cfg.Tasks), Months: len(cfg.MonthsWithTasks), Phases: []summaryPhase{}}
+	for _, task := range cfg.Tasks {
+		if task.IsMilestone {
+			stats.Milestones++
+		}
+		if strings.EqualFold(strings.TrimSpace(task.Status), "completed") {
+			stats.Completed++
+		}
+	}
+	for _, r := range core.PhaseRollups(cfg.Tasks) {
+		stats.Phases = append(stats.Phases, summaryPhase{
+			Phase: r.Phase, Start: summaryDate(r.Start), End: summaryDate(r.End),
+			Tasks: r.Tasks, Progress: r.Progress,
+		})
+	}
+	ev := cfg.ComputeEarnedValue(cfg.Tasks)
+	stats.EarnedValue = summaryEarnedValue{
+		AsOf: summaryDate(ev.AsOf),
+		BAC:  ev.Overall.BAC, PV: ev.Overall.PV, EV: ev.Overall.EV,
+		SPI: ev.Overall.SPI(),
+	}
+	if ev.Overall.HasCost() {
+		ac, cpi := ev.Overall.AC, ev.Overall.CPI()
+		stats.EarnedValue.AC, stats.EarnedValue.CPI = &ac, &cpi
+	}
+	s.Statistics = stats
+
+	s.Validation.Warnings = append(s.Validation.Warnings, warnings...)
+}
+
+// addOutputs records generated files
+func (s *runSummary) addOutputs(files []outputFile) {
+	for _, f := range files {
+		s.Outputs = append(s.Outputs, summaryOutput{Path: f.path, Size: f.size})
+	}
+}
+
+// write finishes the summary with the outcome of the generation and writes
+// it to path. A failure to write is returned only when generation succeeded.
+func (s *runSummary) write(path string, genErr error) error {
+	s.addOutputs(takeOutputs())
+	s.GeneratedAt = time.Now()
+	s.DurationMS = time.Since(s.start).Milliseconds()
+	s.Status = "ok"
+	s.ExitCode = ExitCode(genErr)
+	if genErr != nil {
+		s.Status = "failed"
+		s.Error = strings.TrimSpace(ansiEscape.ReplaceAllString(genErr.Error(), ""))
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err == nil {
+		if dir := filepath.Dir(path); dir != "." {
+			err = os.MkdirAll(dir, 0o755)
+		}
+		if err == nil {
+			err = os.WriteFile(path, append(data, '\n'), 0o600)
+		}
+	}
+	switch {
+	case err == nil:
+		logger.Debug("Wrote run summary: %s", path)
+		return genErr
+	case genErr != nil:
+		logger.Warn("Unable to write run summary %s: %v", path, err)
+		return genErr
+	default:
+		return formatError(
+			"Run Summary",
+			fmt.Sprintf("Unable to write %s", path),
+			err,
+			"Check that the --json-summary directory is writable",
+		)
+	}
+}
+
+// summaryDate formats a date for the summary, empty when unset
+func summaryDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
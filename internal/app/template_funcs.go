@@ -1,9 +1,16 @@
 // Package app - Template functions provide custom helpers for template rendering.
 //
-// This module defines all custom template functions available in the template
-// rendering engine. Each function is designed to simplify common template operations.
+// This module defines the template helper registry. Every helper is registered
+// with a name and a group; templates reference helpers by name. Helpers marked
+// as required are used by the built-in templates and are always available.
+// Optional helpers can be switched off from the config:
 //
-// Available functions:
+//	template_helpers:
+//	  disabled: [categoryColor]
+//	  # or allow only a fixed set of optional helpers
+//	  enabled: [addDays, truncate]
+//
+// Required helpers:
 //
 // dict: Create maps from key-value pairs
 //
@@ -20,14 +27,35 @@
 //	Usage: {{ dec .Index }}
 //	Useful for zero-based indexing
 //
+// buildInfo: Version, commit and build day of the generator, LaTeX-escaped
+//
+//	Usage: PlannerGen {{ buildInfo }}
+//	Stamps the pages and PDF metadata so a planner can be traced to its build
+//
+// text: Text of the generated pages in the configured locale, LaTeX-escaped
+//
+//	Usage: {{ text .Cfg "legend" }} or {{ text .Cfg "task_count" 12 }}
+//	Looks the key up in the strings config, the locale's bundle, then English
+//
+// escapeLatex: Escape LaTeX special characters
+//
+//	Usage: {{ escapeLatex .Label }}
+//	Used for labels taken from the config, such as category names
+//
 // is: Check if a value is truthy
 //
 //	Usage: {{ if is .Value }}...{{ end }}
 //	Returns true for non-nil values and explicit true booleans
 //	Returns false for nil and explicit false booleans
 //
-// All functions are thoroughly tested with 100% code coverage.
-// See template_funcs_test.go for comprehensive test cases.
+// Optional helpers:
+//
+// date:   addDays, daysBetween, formatDate
+// text:   truncate, upper
+// color:  categoryColor, hexToRGB
+//
+// Additional helpers can be added with RegisterTemplateHelper before the
+// templates are parsed.
 //
 // Example template usage:
 //
@@ -39,34 +67,163 @@
 //	    {{ incr $i }}. {{ $item.Name }}
 //	{{ end }}
 //
-//	{{/* Conditional rendering */}}
-//	{{ if is .OptionalField }}
-//	    Optional content: {{ .OptionalField }}
-//	{{ end }}
+//	{{/* Date math and truncation */}}
+//	{{ formatDate "Jan 02" (addDays $task.StartDate 7) }}
+//	{{ $task.Name | truncate 20 }}
 package app
 
 import (
 	"errors"
+	"fmt"
+	"sort"
 	"strings"
 	"text/template"
+	"time"
+	"unicode"
 
+	"phd-dissertation-planner/internal/core"
 	"phd-dissertation-planner/internal/templates"
 )
 
-// TemplateFuncs returns a FuncMap with all custom template functions
+// Template helper groups
+const (
+	helperGroupCore  = "core"
+	helperGroupDate  = "date"
+	helperGroupText  = "text"
+	helperGroupColor = "color"
+)
+
+// TemplateHelper describes a function callable from .tpl files
+type TemplateHelper struct {
+	Name        string
+	Group       string
+	Description string
+	Fn          interface{}
+
+	// Required helpers are used by the built-in templates and cannot be disabled
+	Required bool
+}
+
+// templateHelpers is the registry of all known helpers, keyed by name
+var templateHelpers = newHelperRegistry(
+	TemplateHelper{Name: "dict", Group: helperGroupCore, Fn: dictFunc, Required: true, Description: "build a map from key-value pairs"},
+	TemplateHelper{Name: "incr", Group: helperGroupCore, Fn: incrFunc, Required: true, Description: "add 1 to an integer"},
+	TemplateHelper{Name: "dec", Group: helperGroupCore, Fn: decFunc, Required: true, Description: "subtract 1 from an integer"},
+	TemplateHelper{Name: "is", Group: helperGroupCore, Fn: isFunc, Required: true, Description: "truthiness check"},
+	TemplateHelper{Name: "hypertarget", Group: helperGroupCore, Fn: templates.Hypertarget, Required: true, Description: "LaTeX hypertarget"},
+	TemplateHelper{Name: "lower", Group: helperGroupCore, Fn: lowerFunc, Required: true, Description: "lowercase a string"},
+	TemplateHelper{Name: "plus", Group: helperGroupCore, Fn: plusFunc, Required: true, Description: "add two integers"},
+	TemplateHelper{Name: "mod", Group: helperGroupCore, Fn: modFunc, Required: true, Description: "integer modulo"},
+	TemplateHelper{Name: "replace", Group: helperGroupCore, Fn: replaceFunc, Required: true, Description: "replace all occurrences of a substring"},
+	TemplateHelper{Name: "escapeLatex", Group: helperGroupCore, Fn: EscapeLatex, Required: true, Description: "escape LaTeX special characters"},
+	TemplateHelper{Name: "text", Group: helperGroupCore, Fn: textFunc, Required: true, Description: "localized text of the generated pages, LaTeX-escaped"},
+	TemplateHelper{Name: "buildInfo", Group: helperGroupCore, Fn: buildInfoFunc, Required: true, Description: "version, commit and build day of the generator, LaTeX-escaped"},
+
+	TemplateHelper{Name: "addDays", Group: helperGroupDate, Fn: addDaysFunc, Description: "shift a date by n days"},
+	TemplateHelper{Name: "daysBetween", Group: helperGroupDate, Fn: daysBetweenFunc, Description: "whole days from one date to another"},
+	TemplateHelper{Name: "formatDate", Group: helperGroupDate, Fn: formatDateFunc, Description: "format a date with a Go layout"},
+	TemplateHelper{Name: "truncate", Group: helperGroupText, Fn: truncateFunc, Description: "shorten a string to n characters with an ellipsis"},
+	TemplateHelper{Name: "upper", Group: helperGroupText, Fn: upperFunc, Description: "uppercase a string"},
+	TemplateHelper{Name: "categoryColor", Group: helperGroupColor, Fn: categoryColorFunc, Description: "hex color for a category name in the config"},
+	TemplateHelper{Name: "hexToRGB", Group: helperGroupColor, Fn: core.HexToRGB, Description: "convert a hex color to an r,g,b triple"},
+)
+
+// newHelperRegistry builds the helper registry from a list of helpers
+func newHelperRegistry(helpers ...TemplateHelper) map[string]TemplateHelper {
+	registry := make(map[string]TemplateHelper, len(helpers))
+	for _, h := range helpers {
+		registry[h.Name] = h
+	}
+	return registry
+}
+
+// RegisterTemplateHelper adds a helper to the registry. The templates
+// returned by NewTplForConfig and NewTplWithOverlay after the call can use
+// it, including user template overlays.
+func RegisterTemplateHelper(h TemplateHelper) error {
+	if strings.TrimSpace(h.Name) == "" {
+		return errors.New("template helper name is required")
+	}
+	if h.Fn == nil {
+		return fmt.Errorf("template helper %q has no function", h.Name)
+	}
+	if existing, ok := templateHelpers[h.Name]; ok && existing.Required {
+		return fmt.Errorf("template helper %q is built in and cannot be replaced", h.Name)
+	}
+
+	templateHelpers[h.Name] = h
+	return nil
+}
+
+// TemplateHelpers returns all registered helpers sorted by group and name
+func TemplateHelpers() []TemplateHelper {
+	helpers := make([]TemplateHelper, 0, len(templateHelpers))
+	for _, h := range templateHelpers {
+		helpers = append(helpers, h)
+	}
+	sort.Slice(helpers, func(i, j int) bool {
+		if helpers[i].Group != helpers[j].Group {
+			return helpers[i].Group < helpers[j].Group
+		}
+		return helpers[i].Name < helpers[j].Name
+	})
+	return helpers
+}
+
+// TemplateFuncs returns a FuncMap with all registered template functions
 // These functions are available to all templates during rendering
 func TemplateFuncs() template.FuncMap {
-	return template.FuncMap{
-		"dict":        dictFunc,
-		"incr":        incrFunc,
-		"dec":         decFunc,
-		"is":          isFunc,
-		"hypertarget": templates.Hypertarget,
-		"lower":       lowerFunc,
-		"plus":        plusFunc,
-		"mod":         modFunc,
-		"replace":     replaceFunc,
+	funcs := make(template.FuncMap, len(templateHelpers))
+	for name, h := range templateHelpers {
+		funcs[name] = h.Fn
 	}
+	return funcs
+}
+
+// disabledTemplateFuncs returns replacement functions for the optional helpers
+// switched off by the configuration. Templates calling a disabled helper fail
+// at render time with a descriptive error.
+func disabledTemplateFuncs(settings core.TemplateHelpers) (template.FuncMap, error) {
+	for _, name := range append(append([]string{}, settings.Enabled...), settings.Disabled...) {
+		h, ok := templateHelpers[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown template helper %q in template_helpers", name)
+		}
+		if h.Required && contains(settings.Disabled, name) {
+			return nil, fmt.Errorf("template helper %q is required by the built-in templates and cannot be disabled", name)
+		}
+	}
+
+	disabled := make(template.FuncMap)
+	for name, h := range templateHelpers {
+		if h.Required {
+			continue
+		}
+		off := contains(settings.Disabled, name) ||
+			(len(settings.Enabled) > 0 && !contains(settings.Enabled, name))
+		if off {
+			disabled[name] = disabledHelper(name)
+		}
+	}
+
+	return disabled, nil
+}
+
+// disabledHelper returns a stand-in that reports the helper as disabled
+func disabledHelper(name string) func(...interface{}) (interface{}, error) {
+	return func(...interface{}) (interface{}, error) {
+		return nil, fmt.Errorf("template helper %q is disabled by configuration", name)
+	}
+}
+
+// contains reports whether list contains s
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
 }
 
 // dictFunc creates a map from key-value pairs for use in templates
@@ -143,9 +300,92 @@ func replaceFunc(input, from, to string) string {
 	return strings.ReplaceAll(input, from, to)
 }
 
-// Additional template helper functions can be added here
-// Examples:
-// - formatDate: Format time.Time values
-// - join: Join string slices
-// - default: Provide default values
-// - escape: Escape special characters
+// buildInfoFunc returns the generator's version, commit and build day
+// Usage: {{ buildInfo }}
+func buildInfoFunc() string {
+	return EscapeLatex(core.Build().String())
+}
+
+// textFunc returns the localized text for key, LaTeX-escaped and filled with
+// args. The args are inserted as they are, so they may carry markup such as
+// escaped task names.
+// Usage: {{ text .Cfg "legend" }}
+func textFunc(cfg core.Config, key string, args ...any) string {
+	text := cfg.GetLocale().Text(key)
+	if len(args) == 0 {
+		return EscapeLatex(text)
+	}
+	return fmt.Sprintf(escapeFormat(text), args...)
+}
+
+// categoryColorFunc returns the hex color of a category in the config
+// Usage: {{ categoryColor .Cfg "Writing" }}
+func categoryColorFunc(cfg core.Config, category string) string {
+	return cfg.GenerateCategoryColor(category)
+}
+
+// escapeFormat escapes the LaTeX special characters of a format string,
+// leaving its verbs in place; %% becomes a literal percent sign
+func escapeFormat(format string) string {
+	var b strings.Builder
+	for {
+		i := strings.IndexByte(format, '%')
+		if i < 0 {
+			b.WriteString(EscapeLatex(format))
+			return b.String()
+		}
+		b.WriteString(EscapeLatex(format[:i]))
+		format = format[i+1:]
+
+		// The verb runs to its first letter, past flags, width and precision;
+		// anything else, such as "50% done", is a literal percent sign
+		j := strings.IndexFunc(format, unicode.IsLetter)
+		if j < 0 || strings.Trim(format[:j], "+-#0123456789.") != "" {
+			b.WriteString(`\%%`)
+			format = strings.TrimPrefix(format, "%")
+			continue
+		}
+		b.WriteString("%" + format[:j+1])
+		format = format[j+1:]
+	}
+}
+
+// addDaysFunc shifts a date by n days
+// Usage: {{ addDays .Date 7 }}
+func addDaysFunc(t time.Time, n int) time.Time {
+	return t.AddDate(0, 0, n)
+}
+
+// daysBetweenFunc returns the number of calendar days from start to end,
+// counted on their dates in UTC so that DST changes don't shorten a day
+// Usage: {{ daysBetween .StartDate .EndDate }}
+func daysBetweenFunc(start, end time.Time) int {
+	from := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	to := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// formatDateFunc formats a date with a Go reference layout
+// Usage: {{ formatDate "Jan 02" .Date }}
+func formatDateFunc(layout string, t time.Time) string {
+	return t.Format(layout)
+}
+
+// truncateFunc shortens a string to at most n characters, adding an ellipsis
+// Usage: {{ .Name | truncate 20 }}
+func truncateFunc(n int, s string) string {
+	runes := []rune(s)
+	if n <= 0 || len(runes) <= n {
+		return s
+	}
+	if n <= 3 {
+		return string(runes[:n])
+	}
+	return strings.TrimSpace(string(runes[:n-3])) + "..."
+}
+
+// upperFunc converts a string to uppercase
+// Usage: {{ .Value | upper }}
+func upperFunc(s string) string {
+	return strings.ToUpper(s)
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"phd-dissertation-planner/internal/core"
+)
+
+func TestTruncateFunc(t *testing.T) {
+	tests := []struct {
+		n        int
+		input    string
+		expected string
+	}{
+		{10, "short", "short"},
+		{8, "Literature review", "Liter..."},
+		{3, "Draft", "Dra"},
+		{0, "unchanged", "unchanged"},
+		{5, "Übersicht", "Üb..."},
+	}
+
+	for _, tt := range tests {
+		if actual := truncateFunc(tt.n, tt.input); actual != tt.expected {
+			t.Errorf("truncateFunc(%d, %q): expected %q, got %q", tt.n, tt.input, tt.expected, actual)
+		}
+	}
+}
+
+func TestDisabledTemplateFuncs(t *testing.T) {
+	disabled, err := disabledTemplateFuncs(core.TemplateHelpers{Disabled: []string{"truncate"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := disabled["truncate"]; !ok || len(disabled) != 1 {
+		t.Errorf("expected only truncate to be disabled, got %v", disabled)
+	}
+
+	disabled, err = disabledTemplateFuncs(core.TemplateHelpers{Enabled: []string{"addDays"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := disabled["addDays"]; ok {
+		t.Error("enabled helper addDays should not be disabled")
+	}
+	if _, ok := disabled["dict"]; ok {
+		t.Error("required helper dict should never be disabled")
+	}
+	if _, ok := disabled["truncate"]; !ok {
+		t.Error("helper not in enabled list should be disabled")
+	}
+
+	if _, err := disabledTemplateFuncs(core.TemplateHelpers{Disabled: []string{"dict"}}); err == nil {
+		t.Error("expected error when disabling a required helper")
+	}
+	if _, err := disabledTemplateFuncs(core.TemplateHelpers{Disabled: []string{"nope"}}); err == nil {
+		t.Error("expected error for unknown helper")
+	}
+}
+
+func TestRegisteredHelperInOverlay(t *testing.T) {
+	if err := RegisterTemplateHelper(TemplateHelper{Name: "shout", Group: helperGroupText, Fn: strings.ToUpper}); err != nil {
+		t.Fatal(err)
+	}
+	defer delete(templateHelpers, "shout")
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "shout.tpl"), []byte(`{{ define "shout" }}{{ shout . }}{{ end }}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tpl, err := NewTplForConfig(core.Config{TemplatesDir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sb strings.Builder
+	if err := tpl.Execute(&sb, "shout", "draft"); err != nil {
+		t.Fatal(err)
+	}
+	if sb.String() != "DRAFT" {
+		t.Errorf("rendered %q, want DRAFT", sb.String())
+	}
+}
+
+func TestDaysBetweenFuncAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	start := time.Date(2025, 3, 8, 0, 0, 0, 0, loc)
+	end := time.Date(2025, 3, 10, 0, 0, 0, 0, loc)
+	if got := daysBetweenFunc(start, end); got != 2 {
+		t.Errorf("days across the DST change = %d, want 2", got)
+	}
+}
@@ -0,0 +1,38 @@
+package app
+
+import (
+	cal "phd-dissertation-planner/internal/calendar"
+	"phd-dissertation-planner/internal/core"
+)
+
+// timelineTpl is the built-in template for the roadmap timeline page
+const timelineTpl = "timeline.tpl"
+
+// Timeline composes a single roadmap page: phases as bands along a time axis
+// with milestones marked as labeled diamonds
+func Timeline(cfg core.Config, tpls []string) (core.Modules, error) {
+	tplName := timelineTpl
+	if len(tpls) > 0 {
+		tplName = tpls[0]
+	}
+
+	tasks := cfg.VisibleTasks()
+	spanningTasks := make([]cal.SpanningTask, 0, len(tasks))
+	for _, task := range tasks {
+		spanningTasks = append(spanningTasks, cal.CreateSpanningTask(&cfg, task, task.StartDate, task.EndDate))
+	}
+
+	timeline := cal.NewTimeline(spanningTasks, &cfg)
+	if !timeline.HasContent() {
+		return core.Modules{}, nil
+	}
+
+	return core.Modules{{
+		Cfg: cfg,
+		Tpl: tplName,
+		Body: map[string]interface{}{
+			"Timeline": timeline,
+			"Locale":   cfg.GetLocale(),
+		},
+	}}, nil
+}
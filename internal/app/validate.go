@@ -0,0 +1,177 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"phd-dissertation-planner/internal/core"
+
+	"github.com/urfave/cli/v2"
+)
+
+const fValidateStrict = "strict"
+
+// validateCommand checks the plan without writing any LaTeX
+func validateCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "validate",
+		Usage:     "check the CSV data, configuration, schedule and categories without generating LaTeX",
+		UsageText: "plannergen [--config FILE] validate [--strict]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: fValidateStrict, Required: false, Usage: "fail on warnings as well as errors"},
+		},
+		Action: runValidate,
+	}
+}
+
+// validationSection is one part of the validation report
+type validationSection struct {
+	icon     string
+	title    string
+	errors   []core.ValidationIssue
+	warnings []core.ValidationIssue
+}
+
+// runValidate prints one report section per check and fails when any check
+// found errors
+func runValidate(c *cli.Context) error {
+	failOnWarn, err := failOnWarnings(c)
+	if err != nil {
+		return err
+	}
+	sections, err := validationSections(c)
+	if err != nil {
+		return err
+	}
+
+	errors, warnings := 0, 0
+	fmt.Println("🔍 Validation Report")
+	fmt.Println("═══════════════════════════════════════")
+	for _, section := range sections {
+		errors += len(section.errors)
+		warnings += len(section.warnings)
+		printValidationSection(section)
+	}
+	fmt.Println("═══════════════════════════════════════")
+
+	summary := fmt.Sprintf("%d error(s), %d warning(s)", errors, warnings)
+	switch {
+	case errors > 0:
+		fmt.Println(core.Error("❌ Validation failed: " + summary))
+		return withExitCode(ExitValidation, fmt.Errorf("validation failed: %s", summary))
+	case (c.Bool(fValidateStrict) || failOnWarn) && warnings > 0:
+		fmt.Println(core.Error("❌ Validation failed: " + summary))
+		return withExitCode(ExitWarnings, fmt.Errorf("validation failed: %s", summary))
+	case warnings > 0:
+		fmt.Println(core.Warning("⚠️  Validation passed with " + summary))
+	default:
+		fmt.Println(core.Success("✅ All validation checks passed"))
+	}
+	return nil
+}
+
+// validationSections validates each CSV file, then loads and schedules the
+// merged plan with the configuration and checks it as generation would
+func validationSections(c *cli.Context) ([]validationSection, error) {
+	csvFiles, err := getAllCSVFiles()
+	if err != nil {
+		return nil, formatError(
+			"CSV File Detection",
+			"Unable to find CSV files to validate",
+			err,
+			"Check that input_data directory exists",
+		)
+	}
+
+	var sections []validationSection
+	for _, csvFile := range csvFiles {
+		section := validationSection{icon: "📄", title: filepath.Base(csvFile)}
+		result, err := core.NewCSVValidator().ValidateCSVFile(csvFile)
+		if result != nil {
+			section.errors, section.warnings = result.Errors, result.Warnings
+		} else if err != nil {
+			section.errors = append(section.errors, core.ValidationIssue{Type: "csv_parsing", Message: err.Error()})
+		}
+		sections = append(sections, section)
+	}
+
+	return append(sections, validatePlan(c, csvFiles)...), nil
+}
+
+// validatePlan runs the checks that need the merged, scheduled plan
+func validatePlan(c *cli.Context, csvFiles []string) []validationSection {
+	config := validationSection{icon: "⚙️ ", title: "Configuration"}
+	tasks, err := core.ReadTasksFromMultipleFiles(csvFiles)
+	if err != nil {
+		config.errors = append(config.errors, core.ValidationIssue{Type: "csv_merge", Message: err.Error()})
+		return []validationSection{config}
+	}
+
+	schedule := validationSection{icon: "🔗", title: "Schedule"}
+	cfg, configPaths, err := loadConfigurationWithTasks(c, tasks)
+	var cycles *cycleError
+	if errors.As(err, &cycles) {
+		schedule.errors = cycles.cycles
+		return []validationSection{config, schedule}
+	}
+	if err != nil {
+		config.errors = append(config.errors, core.ValidationIssue{Type: "config", Message: err.Error()})
+		return []validationSection{config}
+	}
+	for _, path := range configPaths {
+		result, err := core.NewConfigValidator().ValidateConfigFile(path)
+		if err != nil {
+			config.errors = append(config.errors, core.ValidationIssue{Type: "config", Message: err.Error()})
+			continue
+		}
+		config.errors = append(config.errors, result.Errors...)
+		config.warnings = append(config.warnings, result.Warnings...)
+	}
+
+	schedule.warnings = scheduleWarnings(cfg)
+
+	categories := validationSection{icon: "🏷️ ", title: "Categories", warnings: cfg.CategoryIssues(cfg.Tasks)}
+
+	return []validationSection{config, schedule, categories}
+}
+
+// planWarnings returns the schedule and category warnings of a loaded plan
+func planWarnings(cfg core.Config) []core.ValidationIssue {
+	return append(scheduleWarnings(cfg), cfg.CategoryIssues(cfg.Tasks)...)
+}
+
+// scheduleWarnings returns the dependency and critical-task overlaps, missed
+// deadlines, blackout conflicts and over-capacity weeks of a loaded plan
+func scheduleWarnings(cfg core.Config) []core.ValidationIssue {
+	var warnings []core.ValidationIssue
+	if suggestions, err := core.SuggestReschedule(cfg.Tasks); err == nil {
+		for _, s := range suggestions {
+			warnings = append(warnings, core.ValidationIssue{Type: "overlap", Message: s.String()})
+		}
+	}
+	warnings = append(warnings, core.DeadlineMisses(cfg.Tasks)...)
+	warnings = append(warnings, cfg.BlackoutConflicts(cfg.Tasks)...)
+	for _, o := range cfg.CheckCapacity(cfg.Tasks) {
+		warnings = append(warnings, core.ValidationIssue{Type: "over_capacity", Message: o.String()})
+	}
+	return warnings
+}
+
+// printValidationSection prints a section title with its status and issues
+func printValidationSection(section validationSection) {
+	switch {
+	case len(section.errors) > 0:
+		fmt.Printf("%s %s %s\n", section.icon, section.title, core.Error(fmt.Sprintf("❌ %d error(s), %d warning(s)", len(section.errors), len(section.warnings))))
+	case len(section.warnings) > 0:
+		fmt.Printf("%s %s %s\n", section.icon, section.title, core.Warning(fmt.Sprintf("⚠️  %d warning(s)", len(section.warnings))))
+	default:
+		fmt.Printf("%s %s %s\n", section.icon, section.title, core.Success("✅"))
+	}
+	for _, issue := range section.errors {
+		fmt.Println(formatValidationIssue(issue))
+	}
+	for _, issue := range section.warnings {
+		fmt.Println(core.DimText(formatValidationIssue(issue)))
+	}
+}
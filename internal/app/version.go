@@ -0,0 +1,49 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"phd-dissertation-planner/internal/core"
+
+	"github.com/urfave/cli/v2"
+)
+
+const fVersionJSON = "json"
+
+// versionCommand prints the build metadata embedded in the binary
+func versionCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "version",
+		Usage:     "print the version, git commit and build date of this binary",
+		UsageText: "plannergen version [--json]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: fVersionJSON, Required: false, Usage: "print the build metadata as JSON"},
+		},
+		Action: runVersion,
+	}
+}
+
+// runVersion prints the build metadata, one field per line or as JSON
+func runVersion(c *cli.Context) error {
+	build := core.Build()
+	if c.Bool(fVersionJSON) {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(build)
+	}
+
+	commit, date := build.ShortCommit(), build.Date
+	if commit == "" {
+		commit = "unknown"
+	}
+	if date == "" {
+		date = "unknown"
+	}
+	fmt.Printf("plannergen %s\n", build.Version)
+	fmt.Printf("  commit:  %s\n", commit)
+	fmt.Printf("  built:   %s\n", date)
+	fmt.Printf("  go:      %s %s\n", build.GoVersion, build.Platform)
+	return nil
+}
@@ -0,0 +1,184 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"phd-dissertation-planner/internal/core"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	fWatchCompile  = "compile"
+	fWatchDebounce = "debounce"
+)
+
+// watchCommand regenerates the planner whenever its inputs change
+func watchCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "watch",
+		Usage:     "regenerate the LaTeX whenever a CSV or config file changes",
+		UsageText: "plannergen [--config FILE] [--range 2025-03] watch [--compile]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: fWatchCompile, Required: false, Usage: "also compile the PDF after every rebuild"},
+			&cli.DurationFlag{Name: fWatchDebounce, Required: false, Value: 300 * time.Millisecond, Usage: "wait this long after the last change before rebuilding"},
+		},
+		Action: runWatch,
+	}
+}
+
+// runWatch builds once, then rebuilds after changes to the CSV files in
+// input_data, the config files or the files the config names until
+// interrupted. Every rebuild reloads the config, and failed builds are
+// reported while watching goes on.
+func runWatch(c *cli.Context) error {
+	watcher, err := newInputWatcher(c)
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	compile := c.Bool(fWatchCompile)
+	rebuild := func() {
+		if err := generate(c, compile); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		// Follow the files named by the config as it is now
+		if cfg, err := core.NewConfigWithOverrides(setOverrides(c), configPaths(c)...); err == nil {
+			applyCLIOverrides(c, &cfg)
+			watcher.follow(cfg)
+		}
+		fmt.Println(core.DimText("👀 Watching for changes (Ctrl+C to stop)..."))
+	}
+	rebuild()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(stop)
+
+	var pending <-chan time.Time
+	var changed string
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Has(fsnotify.Chmod) || !watcher.isInput(event.Name) {
+				continue
+			}
+			changed = event.Name
+			pending = time.After(c.Duration(fWatchDebounce))
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			logger.Warn("File watcher error: %v", err)
+		case <-pending:
+			pending = nil
+			fmt.Println(core.Info(fmt.Sprintf("🔄 %s changed, rebuilding...", filepath.Base(changed))))
+			rebuild()
+		case <-stop:
+			fmt.Println()
+			return nil
+		}
+	}
+}
+
+// inputWatcher watches the inputs of the plan: the CSV files in input_data,
+// the config files, and the holiday, academic calendar, baseline and
+// proposed plan files and templates directory the config names. Events must
+// be filtered with isInput.
+type inputWatcher struct {
+	*fsnotify.Watcher
+	files        map[string]bool // Config files and the files they name
+	dirs         map[string]bool // Directories being watched
+	templatesDir string
+}
+
+// newInputWatcher watches the directories holding the CSV files and the
+// config files; follow adds the files the config names
+func newInputWatcher(c *cli.Context) (*inputWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, formatError("Watch", "Unable to start the file watcher", err)
+	}
+	w := &inputWatcher{Watcher: watcher, files: make(map[string]bool), dirs: make(map[string]bool)}
+
+	// Watch directories rather than files: editors often save by replacing
+	dirs := []string{inputDataDir}
+	for _, path := range configPaths(c) {
+		w.files[filepath.Clean(path)] = true
+		dirs = append(dirs, filepath.Dir(path))
+	}
+	for _, dir := range dirs {
+		if err := w.watchDir(dir); err != nil {
+			watcher.Close()
+			return nil, formatError("Watch", "Unable to watch "+dir, err, "Check that the directory exists")
+		}
+	}
+	return w, nil
+}
+
+// follow watches the files a loaded config names, so that editing the config
+// to name another file picks that file up too. Files that cannot be watched
+// are skipped; the rebuild reports them missing.
+func (w *inputWatcher) follow(cfg core.Config) {
+	paths := append([]string{cfg.Holidays.File, cfg.AcademicCalendar.File, cfg.Baseline.File, cfg.Cover.Logo, cfg.Layout.LaTeX.Document.MacrosFile}, cfg.Scenario.ProposedFiles()...)
+	for _, path := range paths {
+		if path = strings.TrimSpace(path); path == "" {
+			continue
+		}
+		w.files[filepath.Clean(path)] = true
+		if err := w.watchDir(filepath.Dir(path)); err != nil {
+			logger.Debug("Not watching %s: %v", path, err)
+		}
+	}
+
+	w.templatesDir = ""
+	if dir := strings.TrimSpace(cfg.TemplatesDir); dir != "" {
+		if err := w.watchDir(dir); err != nil {
+			logger.Debug("Not watching %s: %v", dir, err)
+			return
+		}
+		w.templatesDir = filepath.Clean(dir)
+	}
+}
+
+// watchDir watches a directory once
+func (w *inputWatcher) watchDir(dir string) error {
+	dir = filepath.Clean(dir)
+	if w.dirs[dir] {
+		return nil
+	}
+	if err := w.Add(dir); err != nil {
+		return err
+	}
+	w.dirs[dir] = true
+	return nil
+}
+
+// isInput reports whether a changed file is a config file or one it names,
+// a template of the templates directory, or a CSV file in input_data
+func (w *inputWatcher) isInput(name string) bool {
+	name = filepath.Clean(name)
+	if w.files[name] {
+		return true
+	}
+	base := filepath.Base(name)
+	if strings.HasPrefix(base, ".") {
+		return false
+	}
+	dir := filepath.Dir(name)
+	if w.templatesDir != "" && dir == w.templatesDir && strings.HasSuffix(base, ".tpl") {
+		return true
+	}
+	return dir == filepath.Clean(inputDataDir) && strings.HasSuffix(strings.ToLower(base), ".csv")
+}
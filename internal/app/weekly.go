@@ -0,0 +1,136 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	cal "phd-dissertation-planner/internal/calendar"
+	"phd-dissertation-planner/internal/core"
+)
+
+// weeklyTpl is the built-in template for weekly planner pages
+const weeklyTpl = "weekly.tpl"
+
+// Weekly composes one weekly planner page per week in the task date range.
+// Enable it by adding a page with the "weekly" render block after the monthly
+// page, or set weekly.interleave to place weeks after each monthly page instead.
+func Weekly(cfg core.Config, tpls []string) (core.Modules, error) {
+	tasks := cfg.VisibleTasks()
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("weekly pages require task data")
+	}
+
+	tplName := weeklyTpl
+	if len(tpls) > 0 {
+		tplName = tpls[0]
+	}
+
+	dateRange := core.CalculateDateRange(tasks)
+	if r := cfg.PreviewRange; r != nil {
+		if r.Start().After(dateRange.Earliest) {
+			dateRange.Earliest = r.Start()
+		}
+		if r.End().Before(dateRange.Latest) {
+			dateRange.Latest = r.End()
+		}
+	}
+	modules := make(core.Modules, 0)
+	for _, start := range weekStartsBetween(dateRange.Earliest, dateRange.Latest, cfg.WeekStart) {
+		if module, ok := weeklyModule(cfg, start, tplName); ok {
+			modules = append(modules, module)
+		}
+	}
+
+	return modules, nil
+}
+
+// weeklyModulesForMonth returns the weekly pages to interleave after a monthly
+// page. Weeks spanning two months are emitted once, after the first month.
+func weeklyModulesForMonth(cfg core.Config, month *cal.Month, seen map[time.Time]bool) core.Modules {
+	monthStart := time.Date(month.Year.Number, month.Month, 1, 0, 0, 0, 0, time.UTC)
+	monthEnd := monthStart.AddDate(0, 1, -1)
+
+	var modules core.Modules
+	for _, start := range weekStartsBetween(monthStart, monthEnd, cfg.WeekStart) {
+		if seen[start] {
+			continue
+		}
+		seen[start] = true
+
+		if module, ok := weeklyModule(cfg, start, weeklyTpl); ok {
+			modules = append(modules, module)
+		}
+	}
+
+	return modules
+}
+
+// weekStartsBetween returns the first day of every week touching [from, to]
+func weekStartsBetween(from, to time.Time, wd time.Weekday) []time.Time {
+	var starts []time.Time
+	last := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	for start := cal.WeekStartOn(from, wd); !start.After(last); start = start.AddDate(0, 0, 7) {
+		starts = append(starts, start)
+	}
+	return starts
+}
+
+// weeklyModule builds the module for the week beginning on start.
+// Returns false when the week is empty and empty weeks are skipped.
+func weeklyModule(cfg core.Config, start time.Time, tplName string) (core.Module, bool) {
+	week := cal.NewWeek(start, &cfg)
+	assignTasksToWeek(week, &cfg)
+
+	if cfg.Weekly.SkipEmpty && !week.HasTasks() {
+		return core.Module{}, false
+	}
+
+	return core.Module{
+		Cfg:  cfg,
+		Tpl:  tplName,
+		Date: start,
+		Body: map[string]interface{}{
+			"Week":       week,
+			"WeekRef":    week.Ref(),
+			"Breadcrumb": week.Breadcrumb(),
+			"Extra":      week.PrevNext().WithTopRightCorner(cfg.ClearTopRightCorner, cfg.Layout.Calendar.TaskKernSpacing),
+			"Style":      cfg.GetWeeklyStyle(),
+			"Hours":      weeklyHourLabels(cfg),
+			"Today":      cal.Day{Time: cfg.GetAsOfDate(), Cfg: &cfg},
+			"Locale":     cfg.GetLocale(),
+		},
+	}, true
+}
+
+// assignTasksToWeek converts the visible tasks overlapping the week and
+// applies them to its days
+func assignTasksToWeek(week *cal.Week, cfg *core.Config) {
+	weekStart := week.StartDate()
+	weekEnd := week.EndDate()
+
+	var spanningTasks []cal.SpanningTask
+	for _, task := range cfg.VisibleTasks() {
+		if task.StartDate.Before(weekEnd.AddDate(0, 0, 1)) && task.EndDate.After(weekStart.AddDate(0, 0, -1)) {
+			spanningTasks = append(spanningTasks, cal.CreateSpanningTask(cfg, task, task.StartDate, task.EndDate))
+		}
+	}
+
+	cal.ApplySpanningTasksToWeek(week, spanningTasks)
+}
+
+// weeklyHourLabels returns the hour row labels honoring the AM/PM setting
+func weeklyHourLabels(cfg core.Config) []string {
+	startHour, endHour := cfg.GetWeeklyHours()
+	labels := make([]string, 0, endHour-startHour)
+
+	for h := startHour; h < endHour; h++ {
+		t := time.Date(2000, 1, 1, h, 0, 0, 0, time.UTC)
+		if cfg.AMPMTime {
+			labels = append(labels, t.Format("3 PM"))
+		} else {
+			labels = append(labels, t.Format("15:04"))
+		}
+	}
+
+	return labels
+}
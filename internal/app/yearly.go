@@ -0,0 +1,70 @@
+package app
+
+import (
+	"time"
+
+	cal "phd-dissertation-planner/internal/calendar"
+	"phd-dissertation-planner/internal/core"
+)
+
+// yearlyTpl is the built-in template for the year-at-a-glance page
+const yearlyTpl = "yearly.tpl"
+
+// Yearly composes one year-at-a-glance page per year covered by the tasks.
+// Place its page before the monthly page for a high-level overview first.
+func Yearly(cfg core.Config, tpls []string) (core.Modules, error) {
+	tplName := yearlyTpl
+	if len(tpls) > 0 {
+		tplName = tpls[0]
+	}
+
+	years := planningYears(cfg)
+
+	tasks := cfg.VisibleTasks()
+	modules := make(core.Modules, 0, len(years))
+	for _, yearNum := range years {
+		year := cal.NewYear(cfg.WeekStart, yearNum, &cfg)
+		for _, month := range year.Months() {
+			assignTasksToMonth(month, tasks)
+		}
+
+		modules = append(modules, core.Module{
+			Cfg:  cfg,
+			Tpl:  tplName,
+			Date: time.Date(yearNum, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Body: map[string]interface{}{
+				"Year":       year,
+				"YearRef":    year.Ref(),
+				"Breadcrumb": year.Breadcrumb(),
+				"Legend":     year.PhaseLegend(),
+				"Locale":     cfg.GetLocale(),
+			},
+		})
+	}
+
+	return modules, nil
+}
+
+// planningYears returns the years of the calendar range when one is set, the
+// years covered by the tasks when tasks are loaded, otherwise the configured
+// years
+func planningYears(cfg core.Config) []int {
+	if r := cfg.CalendarMonths; r != nil {
+		years := make([]int, 0, r.To.Year-r.From.Year+1)
+		for y := r.From.Year; y <= r.To.Year; y++ {
+			years = append(years, y)
+		}
+		return years
+	}
+	tasks := cfg.VisibleTasks()
+	if len(tasks) == 0 {
+		return cfg.GetYears()
+	}
+
+	dateRange := core.CalculateDateRange(tasks)
+	years := make([]int, 0, dateRange.Latest.Year()-dateRange.Earliest.Year()+1)
+	for y := dateRange.Earliest.Year(); y <= dateRange.Latest.Year(); y++ {
+		years = append(years, y)
+	}
+	return years
+}
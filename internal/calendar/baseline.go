@@ -0,0 +1,37 @@
+package calendar
+
+import (
+	"strconv"
+	"time"
+
+	"phd-dissertation-planner/internal/core"
+)
+
+// ============================================================================
+// BASELINE VARIANCE
+// ============================================================================
+
+// slipDays returns how many days the task finishes after its baseline when
+// that exceeds the configured tolerance, or 0
+func slipDays(task *SpanningTask, cfg *core.Config) int {
+	if cfg == nil || task.BaselineEnd.IsZero() {
+		return 0
+	}
+	baselineEnd := time.Date(task.BaselineEnd.Year(), task.BaselineEnd.Month(), task.BaselineEnd.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(task.EndDate.Sub(baselineEnd).Hours() / 24)
+	if days <= cfg.Baseline.ToleranceDays {
+		return 0
+	}
+	return days
+}
+
+// slipWarning returns the warning marker for a slipped task's first segment
+func (d Day) slipWarning(task *SpanningTask) string {
+	if !d.getDayDate().Equal(task.StartDate) {
+		return ""
+	}
+	if days := slipDays(task, d.Cfg); days > 0 {
+		return `\SlipWarning{+` + strconv.Itoa(days) + `d}`
+	}
+	return ""
+}
@@ -31,6 +31,13 @@ type Day struct {
 	Time  time.Time
 	Tasks []*SpanningTask // All tasks (even 1-day tasks are "spanning")
 	Cfg   *core.Config
+
+	// refPrefix namespaces hyperlink targets when the same day appears on
+	// several pages (e.g. monthly and weekly views)
+	refPrefix string
+
+	// overflowNote numbers the month page footnote listing collapsed tasks
+	overflowNote int
 }
 
 // TaskOverlay represents a spanning task overlay with LaTeX content
@@ -52,7 +59,7 @@ func (d Day) Day(today, large interface{}) string {
 	day := strconv.Itoa(d.Time.Day())
 
 	if larg, _ := large.(bool); larg {
-		return d.renderLargeDay(day)
+		return d.cellShading() + sourceMarker(SourceDay, d.Time.Format(time.DateOnly)) + d.renderLargeDay(day) + sourceEndMarker
 	}
 
 	if td, ok := today.(Day); ok {
@@ -66,7 +73,7 @@ func (d Day) Day(today, large interface{}) string {
 
 // renderLargeDay renders the day cell for large (monthly) view with tasks
 func (d Day) renderLargeDay(day string) string {
-	leftCell := d.buildDayNumberCell(day)
+	leftCell := d.phaseBands() + d.dependencyAnchors() + d.buildDayNumberCell(day) + d.holidayLabel() + d.academicLabel() + d.deadlineTick()
 
 	// Check for tasks using intelligent stacking
 	overlay := d.renderSpanningTaskOverlay()
@@ -82,7 +89,7 @@ func (d Day) renderLargeDay(day string) string {
 
 // ref generates a reference string for the day
 func (d Day) ref(prefix ...string) string {
-	p := ""
+	p := d.refPrefix
 
 	if len(prefix) > 0 {
 		p = prefix[0]
@@ -198,9 +205,9 @@ func (d Day) buildCellInner(leftCell string, layout cellLayout) string {
 		`\endgroup}`
 }
 
-// wrapWithHyperlink wraps content with a hyperlink to the day's reference
+// wrapWithHyperlink wraps content with a hyperlink to the day's agenda page or reference
 func (d Day) wrapWithHyperlink(inner string) string {
-	return `\hyperlink{` + d.ref() + `}{` + inner + `}`
+	return `\hyperlink{` + d.linkTarget() + `}{` + inner + `}`
 }
 
 // buildSimpleDayCell creates a simple day cell without tasks
@@ -223,36 +230,12 @@ func (d Day) renderSpanningTaskOverlay() *TaskOverlay {
 		return nil
 	}
 
-	// Assign tracks to ALL active tasks (including continuing ones)
-	// This ensures consistent track assignments across days
-	trackAssignments := d.assignTaskTracks(activeTasks)
-
-	// Combine all tasks that need rendering (starting tasks get full rendering, continuing tasks get continuation indicators)
-	// Use a struct to avoid map lookups
-	type RenderedTask struct {
-		Task  *SpanningTask
-		Track int
-		Type  string // "start" or "continue"
-	}
-	var allTasksToRender = make([]RenderedTask, 0, len(activeTasks))
-
-	// Categorize active tasks
-	for i, task := range activeTasks {
-		track := trackAssignments[i]
-		start := d.getTaskStartDate(task)
-		if dayDate.Equal(start) {
-			// This task starts today
-			allTasksToRender = append(allTasksToRender, RenderedTask{task, track, "start"})
-		} else {
-			// This task is continuing from a previous day
-			allTasksToRender = append(allTasksToRender, RenderedTask{task, track, "continue"})
-		}
+	if d.Cfg != nil && d.Cfg.IsSwimlaneLayout() {
+		return d.renderSwimlaneOverlay(activeTasks, maxCols)
 	}
 
-	// Sort tasks by their assigned track (lowest track first, renders at bottom)
-	sort.Slice(allTasksToRender, func(i, j int) bool {
-		return allTasksToRender[i].Track < allTasksToRender[j].Track
-	})
+	allTasksToRender := d.rankTasks(activeTasks)
+	limit := d.maxTasksPerDay()
 
 	// Render task pills with vertical offsets based on track
 	// Use strings.Builder for efficient string concatenation
@@ -260,6 +243,7 @@ type RenderedTask struct {
 	// Pre-allocate buffer if possible, but exact size is unknown.
 	// Average pill is maybe 100-200 bytes.
 
+	shown, hidden := 0, 0
 	for i, rt := range allTasksToRender {
 		task := rt.Task
 
@@ -270,46 +254,24 @@ type RenderedTask struct {
 			continue
 		}
 
-		// Render starting task (original logic)
-		// Optimization: Use pre-calculated escaped name
-		taskName := task.EscapedName
-		// UX/A11y: Use accessible star icon for milestones
-		// Check both the boolean flag and legacy description prefix for backward compatibility
-		if task.IsMilestone || d.isMilestoneSpanningTask(task) {
-			taskName = `\BeginAccSupp{method=pdfstringdef,unicode,ActualText={Milestone: } }★\EndAccSupp{} ` + taskName
-		}
-
-		objective := ""
-		if task.Description != "" {
-			// Optimization: Use pre-calculated escaped description
-			objective = task.EscapedDescription
-		}
-
-		taskColor := core.HexToRGB(task.Color)
-		if taskColor == "" {
-			taskColor = core.Defaults.DefaultTaskColor
+		// Tasks past the display threshold collapse into the overflow link
+		if limit > 0 && shown >= limit {
+			hidden++
+			continue
 		}
+		shown++
 
 		// Add spacing between stacked tasks (except for the first task)
 		if i > 0 {
-			sb.WriteString(`\vspace{1mm}`) // Add 1mm spacing between stacked tasks
-		}
-
-		// Choose appropriate macro based on whether task is a milestone
-		var macroName string
-		if task.IsMilestone {
-			macroName = `\MilestoneTaskOverlayBox`
-		} else {
-			macroName = `\TaskOverlayBox`
+			sb.WriteString(`\vspace{` + d.stackingSpacing() + `}`)
 		}
 
 		// Use appropriate macro - LaTeX will stack naturally with spacing
-		// Optimization: Write directly to builder
-		fmt.Fprintf(&sb, `%s{%s}{%s}{%s}`,
-			macroName,
-			taskColor,
-			taskName,
-			objective)
+		sb.WriteString(d.taskOverlayBox(task))
+	}
+
+	if hidden > 0 {
+		sb.WriteString(d.overflowLink(hidden))
 	}
 
 	return &TaskOverlay{
@@ -318,6 +280,111 @@ type RenderedTask struct {
 	}
 }
 
+// stackingSpacing returns the gap between stacked task bars
+// (layout.stacking.spacing)
+func (d Day) stackingSpacing() string {
+	if d.Cfg == nil {
+		return core.Defaults.StackingSpacing
+	}
+	return d.Cfg.GetStackingSpacing()
+}
+
+// renderedTask is an active task with its stacking track
+type renderedTask struct {
+	Task  *SpanningTask
+	Track int
+	Type  string // "start" or "continue"
+}
+
+// rankTasks orders the active tasks by stacking track and marks which ones
+// start a bar segment in this cell
+func (d Day) rankTasks(activeTasks []*SpanningTask) []renderedTask {
+	// Assign tracks to ALL active tasks (including continuing ones)
+	// This ensures consistent track assignments across days
+	trackAssignments := d.assignTaskTracks(activeTasks)
+
+	// Combine all tasks that need rendering (starting tasks get full rendering, continuing tasks get continuation indicators)
+	var allTasksToRender = make([]renderedTask, 0, len(activeTasks))
+
+	// Categorize active tasks
+	for i, task := range activeTasks {
+		track := trackAssignments[i]
+		if d.startsSegment(task) {
+			// This task starts today (or resumes after a weekend)
+			allTasksToRender = append(allTasksToRender, renderedTask{task, track, "start"})
+		} else {
+			// This task is continuing from a previous day
+			allTasksToRender = append(allTasksToRender, renderedTask{task, track, "continue"})
+		}
+	}
+
+	// Sort tasks by their category's z_order, then by their assigned track
+	// (lowest track first, renders at bottom)
+	sort.Slice(allTasksToRender, func(i, j int) bool {
+		zi := d.Cfg.CategoryZOrder(allTasksToRender[i].Task.Category)
+		zj := d.Cfg.CategoryZOrder(allTasksToRender[j].Task.Category)
+		if zi != zj {
+			return zi > zj
+		}
+		return allTasksToRender[i].Track < allTasksToRender[j].Track
+	})
+
+	return allTasksToRender
+}
+
+// taskOverlayBox renders a single starting task with the overlay macros
+func (d Day) taskOverlayBox(task *SpanningTask) string {
+	marker := sourceMarker(SourceTask, task.ID)
+	if d.isMilestoneMarker(task) {
+		return marker + d.milestoneMarker(task)
+	}
+
+	// Optimization: Use pre-calculated escaped name
+	taskName := barName(d.Cfg, task)
+	// UX/A11y: Use accessible star icon for milestones
+	if task.IsMilestone {
+		taskName = `\BeginAccSupp{method=pdfstringdef,unicode,ActualText={` + d.locale().Text("milestone") + `: } }★\EndAccSupp{} ` + taskName
+	}
+	taskName = d.timeLabel(task) + d.slipWarning(task) + taskName
+	taskName = d.dependencyMarkers(task, taskName)
+	taskName = d.continuationMarkers(task, taskName)
+	taskName += d.durationLabel(task)
+
+	taskColor := core.HexToRGB(task.Color)
+	if taskColor == "" {
+		taskColor = core.Defaults.DefaultTaskColor
+	}
+
+	// Bars wrapping across week rows repeat only the title on later segments
+	description := task.EscapedDescription
+	if !d.getDayDate().Equal(task.StartDate) {
+		description = ""
+	}
+
+	// Choose appropriate macro based on whether task is a milestone or critical
+	macroName := `\TaskOverlayBox`
+	switch {
+	case task.IsMilestone:
+		macroName = `\MilestoneTaskOverlayBox`
+	case task.IsCritical:
+		macroName = `\CriticalTaskOverlayBox`
+	}
+
+	box := fmt.Sprintf(`%s{%s}{%s}{%s}`, macroName, taskColor, taskName, description)
+	if macroName == `\TaskOverlayBox` && task.Progress > 0 {
+		// Partially completed tasks carry the completed fraction of the bar width
+		box = fmt.Sprintf(`\TaskProgressBox{%s}{%s}{%s}{%.2f}`, taskColor, taskName, description, float64(task.Progress)/100)
+	}
+	if options := d.barOptions(task); options != "" {
+		box = `{\tcbset{` + options + `}` + box + `}`
+	}
+	// Bars of categories with close colors are told apart by hatching
+	if pattern := d.Cfg.CategoryPattern(task.Category); pattern != "" {
+		box = `\HatchedTask{` + pattern + `}{` + box + `}`
+	}
+	return marker + d.timedBar(task, box)
+}
+
 // ============================================================================
 // HELPER FUNCTIONS - DATE AND TASK UTILITIES
 // ============================================================================
@@ -344,8 +411,7 @@ func (d Day) isTaskActiveOnDay(dayDate, start, end time.Time) bool {
 
 // calculateTaskSpanColumns calculates how many columns a task should span
 func (d Day) calculateTaskSpanColumns(dayDate, end time.Time) int {
-	idxMonFirst := (int(dayDate.Weekday()) + 6) % 7 // Monday=0
-	remainInRow := 7 - idxMonFirst
+	remainInRow := 7 - d.rowColumn(dayDate)
 	totalRemain := int(end.Sub(dayDate).Hours()/24) + 1
 	if totalRemain < 1 {
 		totalRemain = 1
@@ -369,6 +435,11 @@ func (d Day) findActiveTasks(dayDate time.Time) ([]*SpanningTask, int) {
 	// Since tasks are pre-sorted in ApplySpanningTasksToMonth, d.Tasks is already sorted by StartDate.
 	// We iterate directly to avoid unnecessary allocations and checks.
 
+	workingDaysOnly := d.workingDaysOnly()
+	if workingDaysOnly && core.IsWeekend(dayDate) {
+		return nil, 0
+	}
+
 	var maxCols int
 
 	for _, task := range d.Tasks {
@@ -385,6 +456,18 @@ func (d Day) findActiveTasks(dayDate time.Time) ([]*SpanningTask, int) {
 			cols = d.calculateRemainingSpanColumns(dayDate, end)
 		}
 
+		// Pinned milestone markers occupy a single column
+		if d.isMilestoneMarker(task) {
+			cols = 1
+		}
+
+		// Working-day bars stop at the weekend and resume as a new segment
+		if workingDaysOnly {
+			if weekdays := weekdaysFrom(dayDate); cols > weekdays {
+				cols = weekdays
+			}
+		}
+
 		if cols > maxCols {
 			maxCols = cols
 		}
@@ -428,7 +511,7 @@ func (d Day) findLowestAvailableTrackForTask(task *SpanningTask, tracksUsage *[M
 			otherEnd := d.getTaskEndDate(otherTask)
 
 			// Check if date ranges overlap
-			if d.dateRangesOverlap(taskStart, taskEnd, otherStart, otherEnd) {
+			if d.dateRangesOverlap(taskStart, taskEnd, otherStart, otherEnd) && !d.hoursApart(task, otherTask) {
 				occupied = true
 				break
 			}
@@ -451,8 +534,7 @@ func (d Day) dateRangesOverlap(start1, end1, start2, end2 time.Time) bool {
 // calculateRemainingSpanColumns calculates how many columns a continuing task spans
 // from the current day to its end (or end of week, whichever is sooner)
 func (d Day) calculateRemainingSpanColumns(dayDate, end time.Time) int {
-	idxMonFirst := (int(dayDate.Weekday()) + 6) % 7 // Monday=0
-	remainInRow := 7 - idxMonFirst
+	remainInRow := 7 - d.rowColumn(dayDate)
 	daysLeft := int(end.Sub(dayDate).Hours()/24) + 1
 
 	if daysLeft < 1 {
@@ -472,11 +554,6 @@ func (d Day) sortTasksInPlace(tasks []*SpanningTask) {
 	})
 }
 
-// isMilestoneSpanningTask checks if a task is a milestone
-func (d Day) isMilestoneSpanningTask(task *SpanningTask) bool {
-	return strings.HasPrefix(strings.ToUpper(strings.TrimSpace(task.Description)), "MILESTONE:")
-}
-
 // ============================================================================
 // HELPER FUNCTIONS - LATEX UTILITIES
 // ============================================================================
@@ -522,7 +599,7 @@ type Week struct {
 }
 
 func NewWeeksForMonth(wd time.Weekday, year *Year, qrtr *Quarter, month *Month, cfg *core.Config) Weeks {
-	ptr := time.Date(year.Number, month.Month, 1, 0, 0, 0, 0, time.Local)
+	ptr := time.Date(year.Number, month.Month, 1, 0, 0, 0, 0, time.UTC)
 	weekday := ptr.Weekday()
 	shift := (7 + weekday - wd) % 7
 
@@ -567,18 +644,23 @@ func (w *Week) WeekNumber(large interface{}) string {
 	wn := w.weekNumber()
 	larg, _ := large.(bool)
 
-	itoa := strconv.Itoa(wn)
-	ref := w.ref()
-	if !larg {
-		return templates.Link(ref, itoa)
+	text := strconv.Itoa(wn)
+	if larg {
+		text = `\rotatebox[origin=tr]{90}{\makebox[` + w.gridConfig().weekLabelHeight + `][c]{` + w.locale().Text("week") + ` ` + text + `}}`
 	}
 
-	text := `\rotatebox[origin=tr]{90}{\makebox[70pt][c]{Week ` + itoa + `}}`
-
-	return templates.Link(ref, text)
+	// Only weekly pages define the week's target
+	if !w.HasWeeklyPage() {
+		return text
+	}
+	return templates.Link(w.ref(), text)
 }
 
 func (w *Week) weekNumber() int {
+	if w.usesISOWeekNumbers() {
+		return w.isoWeekNumber()
+	}
+
 	// Calculate sequential week number for the entire year (1-based)
 	// Find the first non-zero day in the week
 	var firstDay time.Time
@@ -610,17 +692,40 @@ func (w *Week) weekNumber() int {
 	return weekNum
 }
 
+// usesISOWeekNumbers reports whether the configuration asks for ISO-8601 numbering
+func (w *Week) usesISOWeekNumbers() bool {
+	for _, day := range w.Days {
+		if day.Cfg != nil {
+			return day.Cfg.UsesISOWeekNumbers()
+		}
+	}
+	return false
+}
+
+// isoWeekNumber returns the ISO-8601 week of the row's Monday, so rows that
+// start on Sunday take the number of the week they mostly overlap
+func (w *Week) isoWeekNumber() int {
+	start := w.StartDate()
+	if start.IsZero() {
+		return 0
+	}
+	monday := start.AddDate(0, 0, int((7+time.Monday-start.Weekday())%7))
+	_, week := monday.ISOWeek()
+	return week
+}
+
 func (w Week) ref(prefix ...string) string {
 	p := ""
 	if len(prefix) > 0 {
 		p = prefix[0]
 	}
-	return p + "week-" + strconv.Itoa(w.Year.Number) + "-" + strconv.Itoa(w.weekNumber())
+	// Keyed by the week's first calendar day so month rows link to weekly pages
+	return p + "week-" + w.StartDate().Format("2006-01-02")
 }
 
 func NewWeeksForYear(wd time.Weekday, year *Year, cfg *core.Config) Weeks {
 	var weeks Weeks
-	ptr := time.Date(year.Number, 1, 1, 0, 0, 0, 0, time.Local)
+	ptr := time.Date(year.Number, 1, 1, 0, 0, 0, 0, time.UTC)
 	weekday := ptr.Weekday()
 	_ = (7 + weekday - wd) % 7
 
@@ -663,6 +768,9 @@ type Month struct {
 	Weekday time.Weekday
 	Weeks   Weeks
 	Cfg     *core.Config // * Reference to core configuration
+
+	// Connections are the task dependencies touching this month
+	Connections []VisualConnection
 }
 
 func NewMonth(wd time.Weekday, year *Year, qrtr *Quarter, month time.Month, cfg *core.Config) *Month {
@@ -683,12 +791,25 @@ func (m Month) Breadcrumb() string {
 	return templates.Items{
 		templates.NewIntItem(m.Year.Number),
 		templates.NewTextItem("Q" + strconv.Itoa(m.Quarter.Number)),
-		templates.NewMonthItem(m.Month),
+		templates.NewMonthItem(m.Month).Label(m.locale().MonthName(m.Month)),
 	}.Table(true)
 }
 
+// locale returns the configured locale, falling back to English when no config is attached
+func (m Month) locale() core.Locale {
+	if m.Cfg == nil {
+		return core.LookupLocale(core.DefaultLocaleCode)
+	}
+	return m.Cfg.GetLocale()
+}
+
+// Name returns the localized month name
+func (m Month) Name() string {
+	return m.locale().MonthName(m.Month)
+}
+
 func (m Month) MonthLink() string {
-	return templates.Link(m.ref(), m.Month.String())
+	return templates.Link(m.ref(), m.Name())
 }
 
 func (m Month) ref(prefix ...string) string {
@@ -709,7 +830,7 @@ func (m Month) HeadingMOS(prefix ...string) string {
 	if len(prefix) > 0 {
 		p = prefix[0]
 	}
-	monthStr := m.Month.String()
+	monthStr := m.Name()
 	if len(leaf) > 0 {
 		monthStr = templates.Link(m.ref(p), monthStr)
 	}
@@ -727,7 +848,7 @@ func (m Month) HeadingMOS(prefix ...string) string {
 	}
 	r1 = append(r1, templates.Multirow(2, templates.ResizeBoxW(`\myLenHeaderResizeBox`, monthStr)))
 	r2 = append(r2, "")
-	r1 = append(r1, templates.Bold(m.Month.String()))
+	r1 = append(r1, templates.Bold(m.Name()))
 	r2 = append(r2, strconv.Itoa(m.Year.Number))
 	if m.NextExists() {
 		rl = "l"
@@ -746,15 +867,16 @@ func (m Month) PrevNext(prefix ...string) templates.Items {
 		p = prefix[0]
 	}
 	items := templates.Items{}
+	loc := m.locale()
 
 	if m.PrevExists() {
 		prev := m.Prev()
-		items = append(items, templates.NewTextItem(prev.Month.String()).RefText(p+prev.ref()))
+		items = append(items, templates.NewTextItem(loc.MonthName(prev.Month)).RefText(p+prev.ref()))
 	}
 
 	if m.NextExists() {
 		next := m.Next()
-		items = append(items, templates.NewTextItem(next.Month.String()).RefText(p+next.ref()))
+		items = append(items, templates.NewTextItem(loc.MonthName(next.Month)).RefText(p+next.ref()))
 	}
 
 	return items
@@ -805,6 +927,70 @@ func (m *Month) DefineTable(typ interface{}, large interface{}) string {
 	return `\begin{tabular}[t]{c|*{7}{c}}`
 }
 
+// gridConfig holds the lengths of the month grid's week rows
+type gridConfig struct {
+	fill            bool
+	padding         string
+	weekLabelHeight string
+}
+
+// newGridConfig extracts the grid configuration with fallbacks. Filled rows
+// are sized by GridLengths into \myLenMonthlyRowHeight, which the week label
+// then spans; fixed rows keep a 70pt label.
+func newGridConfig(cfg *core.Config) gridConfig {
+	if cfg == nil || !cfg.Layout.Grid.Fill {
+		return gridConfig{weekLabelHeight: "70pt"}
+	}
+	return gridConfig{
+		fill:            true,
+		padding:         cfg.GetGridPadding(),
+		weekLabelHeight: `\dimexpr\myLenMonthlyRowHeight-\arrayrulewidth\relax`,
+	}
+}
+
+// gridConfig returns the grid configuration of the week's month
+func (w *Week) gridConfig() gridConfig {
+	for _, d := range w.Days {
+		if d.Cfg != nil {
+			return newGridConfig(d.Cfg)
+		}
+	}
+	return newGridConfig(nil)
+}
+
+// GridLengths sizes the week rows of a large month grid to fill the text
+// height left by the paper margins and the grid padding. Each row takes an
+// equal share after the weekday header; the day cells get the row less the
+// strut and rule of the tabular. Fixed grids keep latex.monthlycellheight,
+// set again only for a month with its own overrides.
+func (m *Month) GridLengths(large interface{}) string {
+	full, _ := large.(bool)
+	grid := newGridConfig(m.Cfg)
+	if !full {
+		return ""
+	}
+	if !grid.fill {
+		if m.Cfg != nil && m.Cfg.HasMonthOverride() {
+			return `\setlength{\myLenMonthlyCellHeight}{` + m.Cfg.Layout.LaTeX.MonthlyCellHeight + `}`
+		}
+		return ""
+	}
+
+	rows := 0
+	for _, w := range m.Weeks {
+		if w.HasDays() {
+			rows++
+		}
+	}
+	if rows == 0 {
+		return ""
+	}
+
+	return `\setlength{\myLenMonthlyRowHeight}{\dimexpr(\textheight-` + grid.padding +
+		`-\arraystretch\baselineskip-\myLenLineThicknessThick)/` + strconv.Itoa(rows) + `\relax}` +
+		`\setlength{\myLenMonthlyCellHeight}{\dimexpr\myLenMonthlyRowHeight-\arraystretch\baselineskip-\arrayrulewidth\relax}`
+}
+
 func (m *Month) EndTable(typ interface{}) string {
 	typStr, ok := typ.(string)
 	if !ok || typStr == "tabularx" {
@@ -821,13 +1007,14 @@ func (m *Month) MaybeName(large interface{}) string {
 		return ""
 	}
 
-	return `\multicolumn{8}{c}{` + templates.Link(m.Month.String(), m.Month.String()) + `} \\ \hline`
+	return `\multicolumn{8}{c}{` + templates.Link(m.Month.String(), m.Name()) + `} \\ \hline`
 }
 
 func (m *Month) WeekHeader(large interface{}) string {
 	full, _ := large.(bool)
 
 	names := make([]string, 0, 8)
+	loc := m.locale()
 
 	if full {
 		names = append(names, "")
@@ -836,12 +1023,12 @@ func (m *Month) WeekHeader(large interface{}) string {
 	}
 
 	for i := time.Sunday; i < 7; i++ {
-		name := ((m.Weekday + i) % 7).String()
+		name := loc.WeekdayName((m.Weekday + i) % 7)
 		if full {
 			// Add vertical padding with \rule for equal top/bottom spacing
 			name = `\hfil{}\rule{0pt}{2.5ex}\rule[-1ex]{0pt}{0pt}` + name
 		} else {
-			name = name[:1]
+			name = string([]rune(loc.ShortWeekdayName((m.Weekday + i) % 7))[:1])
 		}
 
 		names = append(names, name)
@@ -864,11 +1051,10 @@ func (m *Month) GetTaskColors() map[string]string {
 					}
 					seen[task.Category] = struct{}{}
 
-					color := core.GenerateCategoryColor(task.Category)
+					color := m.Cfg.GenerateCategoryColor(task.Category)
 					if color != "" {
 						// Convert to RGB for LaTeX compatibility
-						// Optimization: Use pre-calculated escaped category
-						colorMap[core.HexToRGB(color)] = task.EscapedCategory
+						colorMap[core.HexToRGB(color)] = categoryLabel(m.Cfg, task.Category)
 					}
 				}
 			}
@@ -904,10 +1090,10 @@ func (m *Month) GetTaskColorsByPhase() []PhaseGroup {
 				if task.Phase != "" {
 					// Use the phase name directly (no number extraction needed)
 					phaseName := task.Phase
-					
+
 					// Get color for this phase
 					if _, exists := phaseMap[phaseName]; !exists {
-						color := core.GenerateCategoryColor(phaseName)
+						color := m.Cfg.GenerateCategoryColor(phaseName)
 						if color != "" {
 							phaseMap[phaseName] = core.HexToRGB(color)
 							phaseOrder = append(phaseOrder, phaseName)
@@ -923,18 +1109,16 @@ func (m *Month) GetTaskColorsByPhase() []PhaseGroup {
 
 	for _, phaseName := range phaseOrder {
 		if color, exists := phaseMap[phaseName]; exists {
+			label := categoryLabel(m.Cfg, phaseName)
 			phase := PhaseGroup{
-				PhaseNumber: "",  // No longer using phase numbers
-				PhaseName:   EscapeLatexSpecialChars(phaseName),
+				PhaseNumber: "", // No longer using phase numbers
+				PhaseName:   label,
 			}
 
 			// Add the phase as a "subphase" for consistency with template
 			phase.SubPhases = append(phase.SubPhases, SubPhaseLegendItem{
-				// Optimization: We could use task.EscapedPhase here, but we are iterating unique phases.
-				// Since we don't have the task object here (we are iterating phaseOrder), we must escape again.
-				// However, we can optimize by storing escaped name in the map or just doing it here.
-				// Given the low cardinality of phases, this is not a hot path.
-				Name:  EscapeLatexSpecialChars(phaseName),
+				// Given the low cardinality of phases, escaping again is not a hot path
+				Name:  label,
 				Color: color,
 			})
 
@@ -945,6 +1129,15 @@ func (m *Month) GetTaskColorsByPhase() []PhaseGroup {
 	return phases
 }
 
+// categoryLabel returns the escaped legend label of a category: its display
+// name when it is declared in the config, otherwise the name itself
+func categoryLabel(cfg *core.Config, category string) string {
+	if declared, ok := cfg.LookupCategory(category); ok {
+		return EscapeLatexSpecialChars(declared.Label())
+	}
+	return EscapeLatexSpecialChars(category)
+}
+
 // GetPhaseDescription returns a human-readable phase description
 func GetPhaseDescription(phaseNum string) string {
 	descriptions := map[string]string{
@@ -1067,18 +1260,40 @@ type SpanningTask struct {
 	Status      string // Task status
 	Assignee    string // Task assignee
 	IsMilestone bool   // Whether this is a milestone task
+	IsRecurring bool   // Whether the milestone was generated from a recurring rule
+	IsCritical  bool   // Whether the task is on the critical path
+
+	// BaselineStart and BaselineEnd are the originally planned dates, if any
+	BaselineStart time.Time
+	BaselineEnd   time.Time
+
+	// Dependencies lists the IDs of tasks this task depends on
+	Dependencies []string
+	// Lags holds the lag in days per predecessor ID; negative values are leads
+	Lags map[string]int
+	// DepTypes holds the dependency type per predecessor ID when not finish-to-start
+	DepTypes map[string]core.DependencyType
+
+	// StartTime and EndTime are the times of day of tasks given with times
+	StartTime time.Duration
+	EndTime   time.Duration
 
 	// Memoized escaped strings for LaTeX rendering
 	EscapedName        string
 	EscapedDescription string
 	EscapedCategory    string
 	EscapedPhase       string
+
+	// Dependency links resolved for the month being rendered
+	hasDependents  bool // Another task in the plan depends on this one
+	depFromEarlier bool // A predecessor ends in an earlier month
+	depToLater     bool // A successor starts in a later month
 }
 
 // CreateSpanningTask creates a new spanning task from basic task data
-func CreateSpanningTask(task core.Task, startDate, endDate time.Time) SpanningTask {
+func CreateSpanningTask(cfg *core.Config, task core.Task, startDate, endDate time.Time) SpanningTask {
 	// * Use Sub-Phase as category for better granularity
-	color := core.GenerateCategoryColor(task.Category)
+	color := cfg.GenerateCategoryColor(task.Category)
 
 	return SpanningTask{
 		ID:          task.ID,
@@ -1089,33 +1304,55 @@ func CreateSpanningTask(task core.Task, startDate, endDate time.Time) SpanningTa
 		StartDate:   startDate,
 		EndDate:     endDate,
 		Color:       color,
-		Progress:    0,                // Default progress
+		Progress:    task.Progress,    // Completion percentage from the CSV
 		Status:      task.Status,      // * Fixed: Use actual Status field
 		Assignee:    task.Assignee,    // * Fixed: Use actual Assignee field
 		IsMilestone: task.IsMilestone, // * Added: Pass milestone status
+		IsRecurring: task.Recurring,
+
+		Dependencies: task.Dependencies,
+		Lags:         task.Lags,
+		DepTypes:     task.DepTypes,
+
+		StartTime: task.StartTime,
+		EndTime:   task.EndTime,
+
+		BaselineStart: task.BaselineStart,
+		BaselineEnd:   task.BaselineEnd,
 	}
 }
 
-// ApplySpanningTasksToMonth applies spanning tasks to a month
-func ApplySpanningTasksToMonth(month *Month, tasks []SpanningTask) {
-	// Optimization: Create a map of day numbers to Day pointers for O(1) lookup
-	// This avoids nested loops searching for the correct day cell
-	dayMap := make(map[int]*Day, 31)
-	for _, week := range month.Weeks {
-		for i := range week.Days {
-			// Only map days that belong to the current month
-			if week.Days[i].Time.Month() == month.Month &&
-				week.Days[i].Time.Year() == month.Year.Number {
-				dayMap[week.Days[i].Time.Day()] = &week.Days[i]
-			}
+// barName returns the escaped name of a task for its task bar, shortened by
+// the configured abbreviations and led by its category's icon
+func barName(cfg *core.Config, task *SpanningTask) string {
+	name := task.EscapedName
+	if cfg == nil {
+		return name
+	}
+	if short := cfg.Layout.TaskStyling.Abbreviate(task.Name); short != task.Name {
+		name = EscapeLatexSpecialChars(short)
+	}
+	if c, ok := cfg.LookupCategory(task.Category); ok {
+		if c.Italic {
+			name = `\textit{` + name + `}`
+		}
+		if c.Bold {
+			name = `\textbf{` + name + `}`
 		}
 	}
+	if icon := cfg.CategoryIcon(task.Category); icon != "" {
+		name = `\CategoryIcon{` + EscapeLatexSpecialChars(icon) + `}` + name
+	}
+	return name
+}
 
+// prepareSpanningTasks returns a normalized, escaped copy of tasks sorted by start date
+func prepareSpanningTasks(tasks []SpanningTask) []SpanningTask {
 	// Clone tasks to avoid mutating the input slice and to ensure memory ownership
 	localTasks := make([]SpanningTask, len(tasks))
 	copy(localTasks, tasks)
 
-	// 1. Normalize dates for all tasks first
+	// Normalize dates for all tasks first
 	// This ensures consistent comparison logic and avoids re-normalization
 	for i := range localTasks {
 		localTasks[i].StartDate = time.Date(localTasks[i].StartDate.Year(), localTasks[i].StartDate.Month(), localTasks[i].StartDate.Day(), 0, 0, 0, 0, time.UTC)
@@ -1128,15 +1365,40 @@ func ApplySpanningTasksToMonth(month *Month, tasks []SpanningTask) {
 		localTasks[i].EscapedPhase = EscapeLatexSpecialChars(localTasks[i].Phase)
 	}
 
-	// 2. Sort tasks by StartDate
+	// Sort tasks by StartDate
 	// This ensures that when we append tasks to days, they are already sorted by start date.
 	// This eliminates the need to sort tasks in the hot loop (findActiveTasks) for every day.
 	sort.Slice(localTasks, func(i, j int) bool {
 		return localTasks[i].StartDate.Before(localTasks[j].StartDate)
 	})
 
+	return localTasks
+}
+
+// ApplySpanningTasksToMonth applies spanning tasks to a month
+func ApplySpanningTasksToMonth(month *Month, tasks []SpanningTask) {
+	// Optimization: Create a map of day numbers to Day pointers for O(1) lookup
+	// This avoids nested loops searching for the correct day cell
+	dayMap := make(map[int]*Day, 31)
+	for _, week := range month.Weeks {
+		for i := range week.Days {
+			// Only map days that belong to the current month
+			if week.Days[i].Time.Month() == month.Month &&
+				week.Days[i].Time.Year() == month.Year.Number {
+				dayMap[week.Days[i].Time.Day()] = &week.Days[i]
+			}
+		}
+	}
+
+	// 1-2. Normalize, escape and sort a private copy of the tasks
+	localTasks := prepareSpanningTasks(tasks)
+
 	monthStart := time.Date(month.Year.Number, month.Month, 1, 0, 0, 0, 0, time.UTC)
 	monthEnd := monthStart.AddDate(0, 1, -1) // Last day of month
+	month.Connections = linkDependencies(localTasks, monthStart, monthEnd)
+	if month.Cfg != nil && month.Cfg.Layout.TaskStyling.HighlightCriticalPath {
+		markCriticalPath(localTasks, month.Cfg.CriticalPath(month.Cfg.Tasks))
+	}
 
 	// 3. Apply sorted tasks to the appropriate days in the month
 	for i := range localTasks {
@@ -1177,4 +1439,8 @@ func ApplySpanningTasksToMonth(month *Month, tasks []SpanningTask) {
 			}
 		}
 	}
+
+	if month.Cfg != nil && month.Cfg.UsesOverflowFootnotes() {
+		month.numberOverflowNotes()
+	}
 }
@@ -96,14 +96,6 @@ func (cb *CellBuilder) BuildTaskCell(leftCell, content string, isSpanning bool,
 	return fmt.Sprintf(`\hyperlink{%s}{%s}`, "", inner)
 }
 
-// BuildWeekHeaderCell creates a week header cell
-func (cb *CellBuilder) BuildWeekHeaderCell(weekNum int) string {
-	weekHeaderHeight := "\\myLenMonthlyCellHeight" // Default height
-
-	return fmt.Sprintf(`\hyperlink{week-%d}{\rotatebox[origin=tr]{90}{\makebox[%s][c]{Week %d}}}`,
-		weekNum, weekHeaderHeight, weekNum)
-}
-
 // BuildMonthHeaderCell creates a month header cell
 func (cb *CellBuilder) BuildMonthHeaderCell(monthName string, monthNum int) string {
 	monthHeaderHeight := "\\myLenMonthlyCellHeight" // Default height
@@ -0,0 +1,234 @@
+package calendar
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"phd-dissertation-planner/internal/core"
+	"phd-dissertation-planner/internal/templates"
+)
+
+// ============================================================================
+// DAILY AGENDA
+// ============================================================================
+
+// dailyComposer is the composer name that renders daily agenda pages
+const dailyComposer = "daily"
+
+// DailyRef returns the hyperlink target of the day's agenda page
+func (d Day) DailyRef() string {
+	return "day-" + d.Time.Format("2006-01-02")
+}
+
+// HasDailyPage reports whether an agenda page is generated for this day
+func (d Day) HasDailyPage() bool {
+	if d.Cfg == nil || d.Time.IsZero() || !d.Cfg.UsesComposer(dailyComposer) {
+		return false
+	}
+	return len(d.Tasks) > 0 || !d.Cfg.Daily.SkipEmpty
+}
+
+// linkTarget returns where a click on the day cell leads: the agenda page when
+// one exists, otherwise the day itself
+func (d Day) linkTarget() string {
+	if d.refPrefix == "" && d.HasDailyPage() {
+		return d.DailyRef()
+	}
+	return d.ref()
+}
+
+// locale returns the configured locale, falling back to English
+func (d Day) locale() core.Locale {
+	if d.Cfg == nil {
+		return core.LookupLocale(core.DefaultLocaleCode)
+	}
+	return d.Cfg.GetLocale()
+}
+
+// Title returns the localized long date used as the agenda page heading
+func (d Day) Title() string {
+	loc := d.locale()
+	return loc.WeekdayName(d.Time.Weekday()) + ", " + loc.LongDate(d.Time)
+}
+
+// Holiday returns the escaped name of the holiday on this day, if any
+func (d Day) Holiday() string {
+	if d.Cfg == nil || d.Time.IsZero() {
+		return ""
+	}
+	name, _ := d.Cfg.HolidayOn(d.getDayDate())
+	return EscapeLatexSpecialChars(name)
+}
+
+// holidayLabel renders the holiday name beside the day number in the month grid
+func (d Day) holidayLabel() string {
+	if name := d.Holiday(); name != "" {
+		return `\HolidayLabel{` + name + `}`
+	}
+	return ""
+}
+
+// AcademicEvents returns the academic calendar events covering this day
+func (d Day) AcademicEvents() []core.AcademicEvent {
+	if d.Cfg == nil || d.Time.IsZero() {
+		return nil
+	}
+	return d.Cfg.AcademicEventsOn(d.Time)
+}
+
+// AcademicNames returns the escaped names of the academic events covering this day
+func (d Day) AcademicNames() []string {
+	events := d.AcademicEvents()
+	names := make([]string, 0, len(events))
+	for _, e := range events {
+		names = append(names, EscapeLatexSpecialChars(e.Name))
+	}
+	return names
+}
+
+// academicBand returns the background shading for academic events, if any
+func (d Day) academicBand() string {
+	for _, e := range d.AcademicEvents() {
+		if color := e.BandColor(); color != "" {
+			return `\cellcolor[RGB]{` + color + `}`
+		}
+	}
+	return ""
+}
+
+// cellShading returns the background of a month grid cell: the as-of day
+// highlight, then past-day shading, then blackout periods, then over-capacity
+// weeks, then academic calendar bands
+func (d Day) cellShading() string {
+	if d.Cfg != nil {
+		asOf := d.Cfg.GetAsOfDate()
+		day := d.getDayDate()
+		_, blackout := d.Cfg.BlackoutOn(day)
+		switch {
+		case d.Cfg.Today.Highlight && day.Equal(asOf):
+			return `\TodayCell{}`
+		case d.Cfg.Today.ShadePast && day.Before(asOf):
+			return `\PastCell{}`
+		case blackout:
+			return `\BlackoutCell{}`
+		case d.Cfg.IsOverCapacity(day):
+			return `\OverCapacityCell{}`
+		}
+	}
+	return d.academicBand()
+}
+
+// academicLabel names the academic events starting on this day in the month grid
+func (d Day) academicLabel() string {
+	var names []string
+	for _, e := range d.AcademicEvents() {
+		if e.Start.Equal(d.getDayDate()) {
+			names = append(names, EscapeLatexSpecialChars(e.Name))
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	return `\AcademicLabel{` + strings.Join(names, ", ") + `}`
+}
+
+// maxPhaseBandLanes caps the phase bands stacked in one day cell
+const maxPhaseBandLanes = 4
+
+// phaseBands draws a thin band for every phase whose span covers this day,
+// each phase on its own lane so bands line up across the month grid
+func (d Day) phaseBands() string {
+	if d.Cfg == nil || d.Time.IsZero() || !d.Cfg.Layout.Calendar.PhaseBands {
+		return ""
+	}
+	day := d.getDayDate()
+	var sb strings.Builder
+	for _, r := range core.PhaseRollups(d.Cfg.VisibleTasks()) {
+		if r.Lane >= maxPhaseBandLanes || day.Before(r.Start) || day.After(r.End) {
+			continue
+		}
+		rgb := core.HexToRGB(d.Cfg.GenerateCategoryColor(r.Phase))
+		sb.WriteString(`\PhaseBand{` + rgb + `}{` + strconv.Itoa(r.Lane) + `}`)
+	}
+	return sb.String()
+}
+
+// deadlineTick marks the hard deadlines falling on this day in the month grid.
+// The label is emphasized when a task is scheduled to end after it.
+func (d Day) deadlineTick() string {
+	if d.Cfg == nil || d.Time.IsZero() {
+		return ""
+	}
+	due := d.Cfg.DeadlinesOn(d.getDayDate())
+	if len(due) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(due))
+	for _, t := range due {
+		name := EscapeLatexSpecialChars(t.Name)
+		if t.MissesDeadline() {
+			name = `\textbf{` + name + `!}`
+		}
+		names = append(names, name)
+	}
+	return `\DeadlineTick{` + strings.Join(names, ", ") + `}`
+}
+
+// DailyBreadcrumb creates the year / quarter / month / day trail for agenda pages
+func (d Day) DailyBreadcrumb() string {
+	loc := d.locale()
+	quarter := (int(d.Time.Month())-1)/3 + 1
+	monthRef := "month-" + strconv.Itoa(d.Time.Year()) + "-" + strconv.Itoa(int(d.Time.Month()))
+
+	return templates.Items{
+		templates.NewIntItem(d.Time.Year()),
+		templates.NewTextItem("Q" + strconv.Itoa(quarter)),
+		templates.NewTextItem(loc.MonthName(d.Time.Month())).RefText(monthRef),
+		templates.NewTextItem(strconv.Itoa(d.Time.Day())).RefText(d.ref()).Bold(true),
+	}.Table(true)
+}
+
+// DailyPrevNext creates navigation items for the neighbouring agenda pages
+func (d Day) DailyPrevNext(prev, next time.Time) templates.Items {
+	loc := d.locale()
+	items := templates.Items{}
+
+	if !prev.IsZero() {
+		p := Day{Time: prev}
+		items = append(items, templates.NewTextItem(`$\langle$ `+loc.ShortDate(prev)).RefText(p.DailyRef()))
+	}
+	if !next.IsZero() {
+		n := Day{Time: next}
+		items = append(items, templates.NewTextItem(loc.ShortDate(next)+` $\rangle$`).RefText(n.DailyRef()))
+	}
+
+	return items
+}
+
+// StartsToday reports whether the task begins on this day
+func (d Day) StartsToday(task *SpanningTask) bool {
+	return d.getDayDate().Equal(task.StartDate)
+}
+
+// EndsToday reports whether the task is due on this day
+func (d Day) EndsToday(task *SpanningTask) bool {
+	return d.getDayDate().Equal(task.EndDate)
+}
+
+// ColorRGB returns the task color as an "r,g,b" triple for LaTeX
+func (t SpanningTask) ColorRGB() string {
+	return core.HexToRGB(t.Color)
+}
+
+// Meta returns the escaped status and assignee of the task for agenda listings
+func (t SpanningTask) Meta() string {
+	parts := make([]string, 0, 2)
+	for _, v := range []string{t.Status, t.Assignee} {
+		if strings.TrimSpace(v) != "" {
+			parts = append(parts, EscapeLatexSpecialChars(v))
+		}
+	}
+	return strings.Join(parts, ` \textperiodcentered{} `)
+}
@@ -0,0 +1,64 @@
+package calendar
+
+import (
+	"testing"
+
+	"phd-dissertation-planner/internal/core"
+)
+
+func TestDeadlineTick(t *testing.T) {
+	due := date(2026, 3, 13)
+	tests := []struct {
+		name  string
+		tasks []core.Task
+		want  string
+	}{
+		{"no deadline", []core.Task{{Name: "Draft", EndDate: due}}, ""},
+		{"deadline elsewhere", []core.Task{{Name: "Draft", EndDate: due, Deadline: date(2026, 3, 20)}}, ""},
+		{"met", []core.Task{{Name: "Draft", EndDate: due, Deadline: due}}, `\DeadlineTick{Draft}`},
+		{"missed and escaped", []core.Task{
+			{Name: "Q&A", EndDate: due, Deadline: due},
+			{Name: "Chapter 2", EndDate: date(2026, 3, 18), Deadline: due},
+		}, `\DeadlineTick{Q\&A, \textbf{Chapter 2!}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day := Day{Time: due, Cfg: &core.Config{Tasks: tt.tasks}}
+			if got := day.deadlineTick(); got != tt.want {
+				t.Errorf("deadlineTick = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPhaseBands(t *testing.T) {
+	tasks := []core.Task{
+		{Phase: "Aim 1", StartDate: date(2026, 3, 2), EndDate: date(2026, 3, 10)},
+		{Phase: "Aim 2", StartDate: date(2026, 3, 9), EndDate: date(2026, 3, 20)},
+	}
+	cfg := &core.Config{Tasks: tasks}
+	cfg.Layout.Calendar.PhaseBands = true
+	aim1 := `\PhaseBand{` + core.HexToRGB(cfg.GenerateCategoryColor("Aim 1")) + `}{0}`
+	aim2 := `\PhaseBand{` + core.HexToRGB(cfg.GenerateCategoryColor("Aim 2")) + `}{1}`
+
+	tests := []struct {
+		name string
+		cfg  *core.Config
+		day  int
+		want string
+	}{
+		{"disabled", &core.Config{Tasks: tasks}, 9, ""},
+		{"one phase", cfg, 3, aim1},
+		{"overlap on separate lanes", cfg, 9, aim1 + aim2},
+		{"second lane alone", cfg, 15, aim2},
+		{"no phase", cfg, 25, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day := Day{Time: date(2026, 3, tt.day), Cfg: tt.cfg}
+			if got := day.phaseBands(); got != tt.want {
+				t.Errorf("phaseBands = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"phd-dissertation-planner/internal/core"
+)
+
+// ============================================================================
+// DEPENDENCY CONNECTIONS
+// ============================================================================
+
+// ConnectionKind describes where the ends of a dependency fall relative to a month
+type ConnectionKind int
+
+const (
+	// ConnectionWithinMonth links two tasks drawn on the same month page
+	ConnectionWithinMonth ConnectionKind = iota
+	// ConnectionFromEarlier links a task to a predecessor ending in an earlier month
+	ConnectionFromEarlier
+	// ConnectionToLater links a task to a successor starting in a later month
+	ConnectionToLater
+)
+
+// VisualConnection is a dependency between two tasks as seen from one month
+type VisualConnection struct {
+	From *SpanningTask // Predecessor
+	To   *SpanningTask // Dependent task
+	Kind ConnectionKind
+	Lag  int // Days between the predecessor's end and the task's start; negative for a lead
+	Type core.DependencyType
+}
+
+// FromDate returns the predecessor end point the connection leaves from: its
+// start for start-to-start and start-to-finish dependencies, its end otherwise
+func (c VisualConnection) FromDate() time.Time {
+	if c.Type.FromStart() {
+		return c.From.StartDate
+	}
+	return c.From.EndDate
+}
+
+// ToDate returns the dependent task end point the connection arrives at: its
+// end for finish-to-finish and start-to-finish dependencies, its start otherwise
+func (c VisualConnection) ToDate() time.Time {
+	if c.Type.ToFinish() {
+		return c.To.EndDate
+	}
+	return c.To.StartDate
+}
+
+// linkDependencies resolves task dependencies for the month spanning
+// [monthStart, monthEnd] and flags the tasks that need anchors or markers.
+// Unknown dependency IDs are ignored; validation reports them.
+func linkDependencies(tasks []SpanningTask, monthStart, monthEnd time.Time) []VisualConnection {
+	byID := make(map[string]*SpanningTask, len(tasks))
+	for i := range tasks {
+		if id := strings.TrimSpace(tasks[i].ID); id != "" {
+			byID[id] = &tasks[i]
+		}
+	}
+
+	inMonth := func(t time.Time) bool {
+		return !t.Before(monthStart) && !t.After(monthEnd)
+	}
+
+	var connections []VisualConnection
+	for i := range tasks {
+		task := &tasks[i]
+		for _, dep := range task.Dependencies {
+			pred, ok := byID[strings.TrimSpace(dep)]
+			if !ok || pred == task {
+				continue
+			}
+			pred.hasDependents = true
+			c := VisualConnection{From: pred, To: task, Lag: task.Lags[strings.TrimSpace(dep)]}
+			if kind, ok := task.DepTypes[strings.TrimSpace(dep)]; ok {
+				c.Type = kind
+			}
+			from, to := c.FromDate(), c.ToDate()
+
+			switch {
+			case inMonth(to) && inMonth(from):
+				c.Kind = ConnectionWithinMonth
+			case inMonth(to) && from.Before(monthStart):
+				task.depFromEarlier = true
+				c.Kind = ConnectionFromEarlier
+			case inMonth(from) && to.After(monthEnd):
+				pred.depToLater = true
+				c.Kind = ConnectionToLater
+			default:
+				continue
+			}
+			connections = append(connections, c)
+		}
+	}
+
+	return connections
+}
+
+// markCriticalPath flags the tasks whose IDs are in critical
+func markCriticalPath(tasks []SpanningTask, critical map[string]bool) {
+	if len(critical) == 0 {
+		return
+	}
+	for i := range tasks {
+		tasks[i].IsCritical = critical[strings.TrimSpace(tasks[i].ID)]
+	}
+}
+
+// showDependencies reports whether dependency arrows and markers are drawn
+func (d Day) showDependencies() bool {
+	return d.Cfg != nil && d.Cfg.Layout.TaskStyling.ShowDependencies
+}
+
+// dependencyAnchors places TikZ coordinates in the day cell where a dependent
+// task starts or a predecessor ends, for Month.DependencyArrows to connect
+func (d Day) dependencyAnchors() string {
+	if !d.showDependencies() || d.refPrefix != "" {
+		return ""
+	}
+
+	// Both ends of linked tasks are anchored, since start-to-start and
+	// finish-to-finish dependencies connect other ends than finish-to-start
+	dayDate := d.getDayDate()
+	var sb strings.Builder
+	for _, task := range d.Tasks {
+		if len(task.Dependencies) == 0 && !task.hasDependents {
+			continue
+		}
+		if dayDate.Equal(task.StartDate) {
+			sb.WriteString(`\DepAnchor{` + dependencyAnchor(dayDate, task, "start") + `}`)
+		}
+		if dayDate.Equal(task.EndDate) {
+			sb.WriteString(`\DepAnchor{` + dependencyAnchor(dayDate, task, "end") + `}`)
+		}
+	}
+	return sb.String()
+}
+
+// dependencyMarkers adds continuation arrows to a task title when a
+// predecessor or successor lies on another month's page
+func (d Day) dependencyMarkers(task *SpanningTask, name string) string {
+	if !d.showDependencies() {
+		return name
+	}
+	if task.depFromEarlier {
+		name = `\DepFromEarlier{}` + name
+	}
+	if task.depToLater {
+		name += `\DepToLater{}`
+	}
+	return name
+}
+
+// DependencyArrows draws arrows between dependent tasks that both appear on
+// this month's page, from the predecessor's end day to the dependent's start
+// day, or between the ends the dependency type links. Arrows of other types
+// than finish-to-start, or with a lag or lead, are labelled with them.
+func (m *Month) DependencyArrows() string {
+	if m.Cfg == nil || !m.Cfg.Layout.TaskStyling.ShowDependencies {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, c := range m.Connections {
+		if c.Kind != ConnectionWithinMonth {
+			continue
+		}
+		fromEnd, toEnd := "end", "start"
+		if c.Type.FromStart() {
+			fromEnd = "start"
+		}
+		if c.Type.ToFinish() {
+			toEnd = "end"
+		}
+		from := dependencyAnchor(c.FromDate(), c.From, fromEnd)
+		to := dependencyAnchor(c.ToDate(), c.To, toEnd)
+		if label := core.DependencyLabel(c.Type, c.Lag); label != "" {
+			fmt.Fprintf(&sb, `\DepArrowLag{%s}{%s}{%s}`+"\n", from, to, label)
+			continue
+		}
+		fmt.Fprintf(&sb, `\DepArrow{%s}{%s}`+"\n", from, to)
+	}
+	if sb.Len() == 0 {
+		return ""
+	}
+
+	return `\begin{tikzpicture}[remember picture, overlay]` + "\n" + sb.String() + `\end{tikzpicture}`
+}
+
+// dependencyAnchor names the coordinate for a task end point. The month is
+// part of the name so anchors on different pages never collide.
+func dependencyAnchor(day time.Time, task *SpanningTask, end string) string {
+	id := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '-'
+	}, strings.TrimSpace(task.ID))
+	return "dep-" + day.Format("2006-01") + "-" + id + "-" + end
+}
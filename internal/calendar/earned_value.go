@@ -0,0 +1,65 @@
+package calendar
+
+import (
+	"fmt"
+	"time"
+
+	"phd-dissertation-planner/internal/core"
+)
+
+// ============================================================================
+// EARNED VALUE
+// ============================================================================
+
+// EarnedValueTable presents earned value figures for rendering
+type EarnedValueTable struct {
+	AsOf    time.Time
+	Rows    []EarnedValueRow
+	Overall EarnedValueRow
+}
+
+// EarnedValueRow is one category line of the table, formatted for LaTeX
+type EarnedValueRow struct {
+	Name     string
+	Tasks    int
+	PV       string
+	EV       string
+	AC       string
+	SPI      string
+	CPI      string
+	Complete string
+	Behind   bool // Earning less than planned (SPI below 1)
+}
+
+// NewEarnedValueTable formats an earned value report
+func NewEarnedValueTable(report core.EarnedValueReport) *EarnedValueTable {
+	table := &EarnedValueTable{AsOf: report.AsOf, Overall: earnedValueRow(report.Overall)}
+	for _, e := range report.Categories {
+		table.Rows = append(table.Rows, earnedValueRow(e))
+	}
+	return table
+}
+
+func earnedValueRow(e core.EarnedValue) EarnedValueRow {
+	name := e.Name
+	if name == "" {
+		name = "Uncategorized"
+	}
+	row := EarnedValueRow{
+		Name:     EscapeLatexSpecialChars(name),
+		Tasks:    e.Tasks,
+		PV:       fmt.Sprintf("%.1f", e.PV),
+		EV:       fmt.Sprintf("%.1f", e.EV),
+		AC:       "--",
+		SPI:      fmt.Sprintf("%.2f", e.SPI()),
+		CPI:      "--",
+		Complete: fmt.Sprintf(`%.0f\%%`, e.PercentComplete()),
+		Behind:   e.SPI() < 1,
+	}
+	// Without actual days there is no cost to measure
+	if e.HasCost() {
+		row.AC = fmt.Sprintf("%.1f", e.AC)
+		row.CPI = fmt.Sprintf("%.2f", e.CPI())
+	}
+	return row
+}
@@ -0,0 +1,327 @@
+package calendar
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"phd-dissertation-planner/internal/core"
+)
+
+// ============================================================================
+// GANTT CHART
+// ============================================================================
+
+// GanttChart lays out tasks as rows against a proportional time axis
+type GanttChart struct {
+	Start time.Time // Axis start (first day of the first month)
+	End   time.Time // Axis end (first day after the last month)
+	Rows  []GanttRow
+	Cfg   *core.Config
+}
+
+// GanttRow is either a phase heading or a task bar
+type GanttRow struct {
+	Heading string // Escaped phase name for heading rows
+	Phase   string // Phase name as written, for heading rows
+	Task    *SpanningTask
+	// Occurrences holds every date of a recurring milestone, which shares one row
+	Occurrences []*SpanningTask
+}
+
+// NewGanttChart builds a chart covering the tasks, grouped by phase in order of
+// each phase's first start date
+func NewGanttChart(tasks []SpanningTask, cfg *core.Config) *GanttChart {
+	localTasks := prepareSpanningTasks(tasks)
+	chart := &GanttChart{Cfg: cfg}
+	if cfg != nil && cfg.Layout.TaskStyling.HighlightCriticalPath {
+		markCriticalPath(localTasks, cfg.CriticalPath(cfg.Tasks))
+	}
+	if len(localTasks) == 0 {
+		return chart
+	}
+
+	// Axis spans whole months around the tasks
+	earliest, latest := localTasks[0].StartDate, localTasks[0].EndDate
+	for _, t := range localTasks {
+		if t.EndDate.After(latest) {
+			latest = t.EndDate
+		}
+	}
+	chart.Start = time.Date(earliest.Year(), earliest.Month(), 1, 0, 0, 0, 0, time.UTC)
+	chart.End = time.Date(latest.Year(), latest.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1, 0)
+
+	// Group by phase, keeping phases in order of first appearance
+	var phaseOrder []string
+	byPhase := make(map[string][]*SpanningTask)
+	for i := range localTasks {
+		phase := localTasks[i].Phase
+		if _, ok := byPhase[phase]; !ok {
+			phaseOrder = append(phaseOrder, phase)
+		}
+		byPhase[phase] = append(byPhase[phase], &localTasks[i])
+	}
+
+	for _, phase := range phaseOrder {
+		if phase != "" {
+			chart.Rows = append(chart.Rows, GanttRow{Heading: EscapeLatexSpecialChars(phase), Phase: phase})
+		}
+		phaseTasks := byPhase[phase]
+		sort.SliceStable(phaseTasks, func(i, j int) bool {
+			return phaseTasks[i].StartDate.Before(phaseTasks[j].StartDate)
+		})
+		recurringRows := make(map[string]int)
+		for _, t := range phaseTasks {
+			if !t.IsRecurring {
+				chart.Rows = append(chart.Rows, GanttRow{Task: t})
+				continue
+			}
+			if i, ok := recurringRows[t.Name]; ok {
+				chart.Rows[i].Occurrences = append(chart.Rows[i].Occurrences, t)
+				continue
+			}
+			recurringRows[t.Name] = len(chart.Rows)
+			chart.Rows = append(chart.Rows, GanttRow{Task: t, Occurrences: []*SpanningTask{t}})
+		}
+	}
+
+	return chart
+}
+
+// Paginate splits the chart into pages of at most rowsPerPage rows. A phase
+// heading never ends a page on its own.
+func (g *GanttChart) Paginate(rowsPerPage int) []*GanttChart {
+	if rowsPerPage <= 1 || len(g.Rows) <= rowsPerPage {
+		return []*GanttChart{g}
+	}
+
+	var pages []*GanttChart
+	for start := 0; start < len(g.Rows); {
+		end := start + rowsPerPage
+		if end >= len(g.Rows) {
+			end = len(g.Rows)
+		} else if g.Rows[end-1].Task == nil {
+			end--
+		}
+
+		page := *g
+		page.Rows = g.Rows[start:end]
+		pages = append(pages, &page)
+		start = end
+	}
+
+	return pages
+}
+
+// frac maps a date to its position on the axis (0..1)
+func (g *GanttChart) frac(t time.Time) float64 {
+	return axisPosition(g.Start, g.End, t)
+}
+
+// axisPosition maps t onto a proportional [start, end) axis, clamped to 0..1
+func axisPosition(start, end, t time.Time) float64 {
+	total := end.Sub(start).Hours()
+	if total <= 0 {
+		return 0
+	}
+	f := t.Sub(start).Hours() / total
+	if f < 0 {
+		return 0
+	}
+	if f > 1 {
+		return 1
+	}
+	return f
+}
+
+// barBorder returns the TikZ options outlining a task bar in a dashed or
+// dotted border style, and casting its drop shadow
+func (g *GanttChart) barBorder(t *SpanningTask) string {
+	if g.Cfg == nil {
+		return ""
+	}
+	var options string
+	style := g.Cfg.CategoryBorderStyle(t.Category)
+	if style == "" {
+		style = g.Cfg.GetBorderStyle()
+	}
+	if style != core.BorderStyleSolid {
+		if !t.IsCritical {
+			options += ", draw=black!60"
+		}
+		options += ", " + style
+	}
+	if g.Cfg.Layout.TaskStyling.Shadow {
+		options += ", drop shadow"
+	}
+	return options
+}
+
+// TikZ renders the chart as a tikzpicture. Task names sit left of the axis,
+// month gridlines run behind the bars and milestones are drawn as diamonds.
+func (g *GanttChart) TikZ() string {
+	if len(g.Rows) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	n := len(g.Rows)
+	loc := core.LookupLocale(core.DefaultLocaleCode)
+	if g.Cfg != nil {
+		loc = g.Cfg.GetLocale()
+	}
+
+	sb.WriteString(`\begin{tikzpicture}[x=0.7\linewidth, y=-1.4em, font=\scriptsize]` + "\n")
+
+	// Month gridlines and labels; label only quarters on long charts
+	months := 0
+	for m := g.Start; m.Before(g.End); m = m.AddDate(0, 1, 0) {
+		months++
+	}
+	for m := g.Start; m.Before(g.End); m = m.AddDate(0, 1, 0) {
+		x := g.frac(m)
+		fmt.Fprintf(&sb, `\draw[black!15] (%.4f,0) -- (%.4f,%d);`+"\n", x, x, n)
+
+		if months <= 24 || (m.Month()-1)%3 == 0 {
+			label := loc.ShortMonthName(m.Month())
+			if m.Month() == time.January || m.Equal(g.Start) {
+				label += fmt.Sprintf(" %d", m.Year())
+			}
+			mid := g.frac(m.AddDate(0, 0, 15))
+			fmt.Fprintf(&sb, `\node[above, font=\tiny] at (%.4f,0) {%s};`+"\n", mid, label)
+		}
+	}
+	fmt.Fprintf(&sb, `\draw[black!40] (0,0) -- (1,0) (0,%d) -- (1,%d);`+"\n", n, n)
+
+	// Shade the months a what-if comparison changes
+	if g.Cfg != nil {
+		for _, m := range core.ShiftedMonths(g.Cfg.ScenarioShifts()) {
+			if m.Before(g.Start) || !m.Before(g.End) {
+				continue
+			}
+			fmt.Fprintf(&sb, `\fill[orange!12] (%.4f,0) rectangle (%.4f,%d);`+"\n", g.frac(m), g.frac(m.AddDate(0, 1, 0)), n)
+		}
+	}
+
+	for i, row := range g.Rows {
+		y := float64(i) + 0.5
+
+		if row.Task == nil {
+			fmt.Fprintf(&sb, `\node[anchor=west, font=\scriptsize\bfseries] at (-0.43,%.2f) {%s};`+"\n", y, row.Heading)
+			continue
+		}
+
+		t := row.Task
+		rgb := core.HexToRGB(t.Color)
+		color := fmt.Sprintf(`{rgb,255:%s}`, rgbComponents(rgb))
+		name := barName(g.Cfg, t)
+		if days := slipDays(t, g.Cfg); days > 0 {
+			name = `\SlipWarning{+` + strconv.Itoa(days) + `d}` + name
+		}
+		fmt.Fprintf(&sb, `\node[anchor=east, text width=0.28\linewidth, align=right] at (-0.01,%.2f) {\hyperlink{%s}{%s}};`+"\n",
+			y, Day{Time: t.StartDate}.ref(), name)
+
+		// Recurring milestones are hollow diamonds at every occurrence
+		if len(row.Occurrences) > 0 {
+			for _, o := range row.Occurrences {
+				x := g.frac(o.StartDate)
+				fmt.Fprintf(&sb, `\draw[draw=%s, thick] ([yshift=2.5pt]%.4f,%.2f) -- ([xshift=2.5pt]%.4f,%.2f) -- ([yshift=-2.5pt]%.4f,%.2f) -- ([xshift=-2.5pt]%.4f,%.2f) -- cycle;`+"\n",
+					color, x, y, x, y, x, y, x, y)
+			}
+			continue
+		}
+
+		x0 := g.frac(t.StartDate)
+		if t.IsMilestone {
+			fmt.Fprintf(&sb, `\fill[fill=%s] ([yshift=3pt]%.4f,%.2f) -- ([xshift=3pt]%.4f,%.2f) -- ([yshift=-3pt]%.4f,%.2f) -- ([xshift=-3pt]%.4f,%.2f) -- cycle;`+"\n",
+				color, x0, y, x0, y, x0, y, x0, y)
+			sb.WriteString(g.scenarioAnnotation(t, y))
+			continue
+		}
+
+		// Baseline dates run as a thin bar beneath the current one
+		if !t.BaselineStart.IsZero() && !t.BaselineEnd.IsZero() {
+			fmt.Fprintf(&sb, `\fill[black!35] (%.4f,%.2f) rectangle (%.4f,%.2f);`+"\n",
+				g.frac(t.BaselineStart), y+0.3, g.frac(t.BaselineEnd.AddDate(0, 0, 1)), y+0.42)
+		}
+
+		// Bars include their end day
+		x1 := g.frac(t.EndDate.AddDate(0, 0, 1))
+		style := "fill=" + color
+		if opacity := g.Cfg.CategoryOpacity(t.Category); opacity > 0 {
+			style += fmt.Sprintf(", fill opacity=%g", float64(opacity)/100)
+		}
+		if pattern := g.Cfg.CategoryPattern(t.Category); pattern != "" {
+			style += ", postaction={pattern=" + pattern + ", pattern color=white!70!black}"
+		}
+		if t.IsCritical {
+			style += ", draw=red!75!black, line width=1pt"
+		}
+		style += g.barBorder(t)
+		fmt.Fprintf(&sb, `\fill[%s, rounded corners=1pt] (%.4f,%.2f) rectangle (%.4f,%.2f);`+"\n",
+			style, x0, y-0.3, x1, y+0.3)
+		sb.WriteString(g.scenarioAnnotation(t, y))
+	}
+
+	// Today marker when inside the axis
+	today := time.Now()
+	if g.Cfg != nil {
+		today = g.Cfg.GetAsOfDate()
+	}
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	if !today.Before(g.Start) && today.Before(g.End) {
+		x := g.frac(today)
+		fmt.Fprintf(&sb, `\draw[red!70, dashed] (%.4f,0) -- (%.4f,%d);`+"\n", x, x, n)
+	}
+
+	sb.WriteString(`\end{tikzpicture}`)
+	return sb.String()
+}
+
+// scenarioAnnotation draws where a task lands in the proposed plan of a
+// what-if comparison: a dashed outline (or hollow diamond) at the proposed
+// dates, labelled with the shift
+func (g *GanttChart) scenarioAnnotation(t *SpanningTask, y float64) string {
+	if g.Cfg == nil {
+		return ""
+	}
+	shift, ok := g.Cfg.ScenarioShift(t.ID)
+	if !ok {
+		return ""
+	}
+
+	label := `\textcolor{orange!80!black}{` + shift.Label() + `}`
+	if shift.Removed {
+		x := g.frac(t.EndDate.AddDate(0, 0, 1))
+		return fmt.Sprintf(`\node[anchor=west, font=\tiny, inner sep=1pt] at (%.4f,%.2f) {%s};`+"\n", x, y, label)
+	}
+
+	var sb strings.Builder
+	x0 := g.frac(shift.ProposedStart)
+	x1 := g.frac(shift.ProposedEnd.AddDate(0, 0, 1))
+	if t.IsMilestone {
+		x1 = x0
+		fmt.Fprintf(&sb, `\draw[orange!80!black, thick] ([yshift=3pt]%.4f,%.2f) -- ([xshift=3pt]%.4f,%.2f) -- ([yshift=-3pt]%.4f,%.2f) -- ([xshift=-3pt]%.4f,%.2f) -- cycle;`+"\n",
+			x0, y, x0, y, x0, y, x0, y)
+	} else {
+		fmt.Fprintf(&sb, `\draw[orange!80!black, dashed, rounded corners=1pt] (%.4f,%.2f) rectangle (%.4f,%.2f);`+"\n",
+			x0, y-0.4, x1, y+0.4)
+	}
+	right := x1
+	if end := g.frac(t.EndDate.AddDate(0, 0, 1)); end > right {
+		right = end
+	}
+	fmt.Fprintf(&sb, `\node[anchor=west, font=\tiny, inner sep=1pt] at ([xshift=3pt]%.4f,%.2f) {%s};`+"\n", right, y, label)
+	return sb.String()
+}
+
+// rgbComponents converts "r,g,b" into the xcolor "red,r;green,g;blue,b" form
+func rgbComponents(rgb string) string {
+	parts := strings.Split(rgb, ",")
+	if len(parts) != 3 {
+		return "red,128;green,128;blue,128"
+	}
+	return "red," + parts[0] + ";green," + parts[1] + ";blue," + parts[2]
+}
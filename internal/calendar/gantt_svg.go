@@ -0,0 +1,119 @@
+package calendar
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"phd-dissertation-planner/internal/core"
+)
+
+// SVG geometry of the Gantt preview, in pixels
+const (
+	svgLabelWidth = 280
+	svgChartWidth = 720
+	svgRowHeight  = 20
+	svgAxisHeight = 24
+)
+
+// SVG renders the chart as a standalone SVG image, laid out like the TikZ
+// chart: names left of the axis, month gridlines, bars, milestone diamonds
+// and the today marker. It is meant for previews, not for print.
+func (g *GanttChart) SVG() string {
+	if len(g.Rows) == 0 {
+		return ""
+	}
+
+	n := len(g.Rows)
+	width := svgLabelWidth + svgChartWidth + 10
+	height := svgAxisHeight + n*svgRowHeight + 4
+	x := func(t time.Time) float64 {
+		return svgLabelWidth + g.frac(t)*svgChartWidth
+	}
+	y := func(row int) float64 {
+		return float64(svgAxisHeight + row*svgRowHeight + svgRowHeight/2)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="11">`+"\n", width, height)
+
+	loc := core.LookupLocale(core.DefaultLocaleCode)
+	if g.Cfg != nil {
+		loc = g.Cfg.GetLocale()
+	}
+	months := 0
+	for m := g.Start; m.Before(g.End); m = m.AddDate(0, 1, 0) {
+		months++
+	}
+	for m := g.Start; m.Before(g.End); m = m.AddDate(0, 1, 0) {
+		fmt.Fprintf(&sb, `<line x1="%.1f" y1="%d" x2="%.1f" y2="%d" stroke="#e0e0e0"/>`+"\n",
+			x(m), svgAxisHeight, x(m), height-4)
+		if months <= 24 || (m.Month()-1)%3 == 0 {
+			label := loc.ShortMonthName(m.Month())
+			if m.Month() == time.January || m.Equal(g.Start) {
+				label += fmt.Sprintf(" %d", m.Year())
+			}
+			fmt.Fprintf(&sb, `<text x="%.1f" y="%d" text-anchor="middle" font-size="9">%s</text>`+"\n",
+				x(m.AddDate(0, 0, 15)), svgAxisHeight-6, html.EscapeString(label))
+		}
+	}
+
+	for i, row := range g.Rows {
+		cy := y(i)
+		if row.Task == nil {
+			fmt.Fprintf(&sb, `<text x="4" y="%.1f" font-weight="bold" dominant-baseline="middle">%s</text>`+"\n",
+				cy, html.EscapeString(row.Phase))
+			continue
+		}
+
+		t := row.Task
+		color := "rgb(" + core.HexToRGB(t.Color) + ")"
+		fmt.Fprintf(&sb, `<text x="%d" y="%.1f" text-anchor="end" dominant-baseline="middle"><title>%s</title>%s</text>`+"\n",
+			svgLabelWidth-6, cy, html.EscapeString(t.ID), html.EscapeString(t.Name))
+
+		if len(row.Occurrences) > 0 {
+			for _, o := range row.Occurrences {
+				sb.WriteString(svgDiamond(x(o.StartDate), cy, `fill="none" stroke="`+color+`" stroke-width="1.5"`))
+			}
+			continue
+		}
+		if t.IsMilestone {
+			sb.WriteString(svgDiamond(x(t.StartDate), cy, `fill="`+color+`"`))
+			continue
+		}
+
+		// Bars include their end day
+		x0, x1 := x(t.StartDate), x(t.EndDate.AddDate(0, 0, 1))
+		stroke := ""
+		if t.IsCritical {
+			stroke = ` stroke="#b00000" stroke-width="1.5"`
+		}
+		fmt.Fprintf(&sb, `<rect x="%.1f" y="%.1f" width="%.1f" height="%d" rx="2" fill="%s"%s><title>%s → %s</title></rect>`+"\n",
+			x0, cy-6, x1-x0, 12, color, stroke,
+			t.StartDate.Format(core.DateFormatISO), t.EndDate.Format(core.DateFormatISO))
+		if t.Progress > 0 {
+			fmt.Fprintf(&sb, `<rect x="%.1f" y="%.1f" width="%.1f" height="3" fill="black" fill-opacity="0.35"/>`+"\n",
+				x0, cy+3, (x1-x0)*float64(t.Progress)/100)
+		}
+	}
+
+	today := time.Now()
+	if g.Cfg != nil {
+		today = g.Cfg.GetAsOfDate()
+	}
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	if !today.Before(g.Start) && today.Before(g.End) {
+		fmt.Fprintf(&sb, `<line x1="%.1f" y1="%d" x2="%.1f" y2="%d" stroke="#d33" stroke-dasharray="4 3"/>`+"\n",
+			x(today), svgAxisHeight, x(today), height-4)
+	}
+
+	sb.WriteString("</svg>")
+	return sb.String()
+}
+
+// svgDiamond draws a milestone diamond centred on (x, y)
+func svgDiamond(x, y float64, attrs string) string {
+	return fmt.Sprintf(`<polygon points="%.1f,%.1f %.1f,%.1f %.1f,%.1f %.1f,%.1f" %s/>`+"\n",
+		x, y-5, x+5, y, x, y+5, x-5, y, attrs)
+}
@@ -0,0 +1,103 @@
+package calendar
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"phd-dissertation-planner/internal/core"
+	"phd-dissertation-planner/internal/templates"
+)
+
+// ============================================================================
+// MINI CALENDAR
+// ============================================================================
+
+// MiniCalendar is a compact, task-free month grid used for reference
+type MiniCalendar struct {
+	Year    int
+	Month   time.Month
+	Weekday time.Weekday // First day of the week
+	Cfg     *core.Config
+}
+
+// NewMiniCalendar creates a mini calendar for the month containing t
+func NewMiniCalendar(t time.Time, wd time.Weekday, cfg *core.Config) *MiniCalendar {
+	return &MiniCalendar{Year: t.Year(), Month: t.Month(), Weekday: wd, Cfg: cfg}
+}
+
+// Ref returns the hyperlink target of the month's page
+func (mc *MiniCalendar) Ref() string {
+	return "month-" + strconv.Itoa(mc.Year) + "-" + strconv.Itoa(int(mc.Month))
+}
+
+// hasPage reports whether the month is rendered, so links never dangle
+func (mc *MiniCalendar) hasPage() bool {
+	if mc.Cfg == nil || len(mc.Cfg.MonthsWithTasks) == 0 {
+		return false
+	}
+	for _, my := range mc.Cfg.MonthsWithTasks {
+		if my.Year == mc.Year && my.Month == mc.Month {
+			return true
+		}
+	}
+	return false
+}
+
+// Render returns the mini calendar as a small tabular
+func (mc *MiniCalendar) Render() string {
+	loc := core.LookupLocale(core.DefaultLocaleCode)
+	if mc.Cfg != nil {
+		loc = mc.Cfg.GetLocale()
+	}
+
+	title := `\textbf{` + loc.MonthName(mc.Month) + ` ` + strconv.Itoa(mc.Year) + `}`
+	if mc.hasPage() {
+		title = templates.Link(mc.Ref(), title)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`{\tiny\setlength{\tabcolsep}{1.5pt}\renewcommand{\arraystretch}{0.9}`)
+	sb.WriteString(`\begin{tabular}[b]{*{7}{r}}` + "\n")
+	sb.WriteString(`\multicolumn{7}{c}{` + title + `} \\` + "\n")
+
+	initials := make([]string, 0, 7)
+	for i := 0; i < 7; i++ {
+		name := loc.ShortWeekdayName((mc.Weekday + time.Weekday(i)) % 7)
+		initials = append(initials, string([]rune(name)[:1]))
+	}
+	sb.WriteString(strings.Join(initials, " & ") + ` \\ \hline` + "\n")
+
+	first := time.Date(mc.Year, mc.Month, 1, 0, 0, 0, 0, time.UTC)
+	cells := make([]string, int((7+first.Weekday()-mc.Weekday)%7))
+	for d := first; d.Month() == mc.Month; d = d.AddDate(0, 0, 1) {
+		cells = append(cells, strconv.Itoa(d.Day()))
+		if len(cells) == 7 {
+			sb.WriteString(strings.Join(cells, " & ") + ` \\` + "\n")
+			cells = cells[:0]
+		}
+	}
+	if len(cells) > 0 {
+		for len(cells) < 7 {
+			cells = append(cells, "")
+		}
+		sb.WriteString(strings.Join(cells, " & ") + ` \\` + "\n")
+	}
+
+	sb.WriteString(`\end{tabular}}`)
+	return sb.String()
+}
+
+// NeighbourMiniCalendars renders the previous and next month side by side for
+// the corner of a month page, or "" when disabled
+func (m *Month) NeighbourMiniCalendars() string {
+	if m.Cfg == nil || !m.Cfg.Layout.Calendar.MiniCalendars {
+		return ""
+	}
+
+	first := time.Date(m.Year.Number, m.Month, 1, 0, 0, 0, 0, time.UTC)
+	prev := NewMiniCalendar(first.AddDate(0, -1, 0), m.Weekday, m.Cfg)
+	next := NewMiniCalendar(first.AddDate(0, 1, 0), m.Weekday, m.Cfg)
+
+	return prev.Render() + `\quad` + next.Render()
+}
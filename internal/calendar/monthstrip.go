@@ -0,0 +1,198 @@
+package calendar
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"phd-dissertation-planner/internal/core"
+	"phd-dissertation-planner/internal/templates"
+)
+
+// ============================================================================
+// MONTH STRIP
+// ============================================================================
+
+// monthlyComposer is the composer name that renders the month grid pages
+const monthlyComposer = "monthly"
+
+// Horizontal layout of a strip, as fractions of the line width
+const (
+	stripLabelWidth = 0.16 // Day label column
+	stripLaneStart  = 0.18 // Left edge of the first task lane
+)
+
+// MonthStrip lays out a month with one day per row and task bars running
+// down the page in side-by-side lanes
+type MonthStrip struct {
+	Month *Month
+	Days  []Day
+	Lanes [][]*SpanningTask // Tasks per lane, in start order
+	Cfg   *core.Config
+}
+
+// NewMonthStrip builds the strip for a month whose days already carry tasks
+func NewMonthStrip(month *Month) *MonthStrip {
+	strip := &MonthStrip{Month: month, Cfg: month.Cfg}
+
+	for _, week := range month.Weeks {
+		for _, day := range week.Days {
+			if !day.Time.IsZero() && day.Time.Month() == month.Month {
+				strip.Days = append(strip.Days, day)
+			}
+		}
+	}
+
+	// Greedy lane assignment: reuse the first lane that is free again
+	seen := make(map[*SpanningTask]bool)
+	var laneEnds []time.Time
+	for _, day := range strip.Days {
+		for _, task := range day.Tasks {
+			if seen[task] {
+				continue
+			}
+			seen[task] = true
+
+			lane := -1
+			for i, end := range laneEnds {
+				if task.StartDate.After(end) {
+					lane = i
+					break
+				}
+			}
+			if lane < 0 {
+				lane = len(laneEnds)
+				laneEnds = append(laneEnds, time.Time{})
+				strip.Lanes = append(strip.Lanes, nil)
+			}
+			laneEnds[lane] = task.EndDate
+			strip.Lanes[lane] = append(strip.Lanes[lane], task)
+		}
+	}
+
+	return strip
+}
+
+// Title returns the localized "Month Year" heading
+func (s *MonthStrip) Title() string {
+	return s.Month.Name() + " " + strconv.Itoa(s.Month.Year.Number)
+}
+
+// Ref returns the page's hyperlink target. Without month grid pages the
+// strip takes over the month target so year and quarter links still land.
+func (s *MonthStrip) Ref() string {
+	if s.Cfg != nil && !s.Cfg.UsesComposer(monthlyComposer) {
+		return s.Month.ref()
+	}
+	return s.Month.ref("strip-")
+}
+
+// rowHeight returns the configured day row height
+func (s *MonthStrip) rowHeight() string {
+	if s.Cfg == nil || strings.TrimSpace(s.Cfg.MonthStrip.RowHeight) == "" {
+		return "6mm"
+	}
+	return s.Cfg.MonthStrip.RowHeight
+}
+
+// row returns the row index of a date, clamped to the month
+func (s *MonthStrip) row(t time.Time) int {
+	if len(s.Days) == 0 {
+		return 0
+	}
+	first := s.Days[0].getDayDate()
+	r := int(t.Sub(first).Hours() / 24)
+	if r < 0 {
+		return 0
+	}
+	if r >= len(s.Days) {
+		return len(s.Days) - 1
+	}
+	return r
+}
+
+// TikZ renders the strip: day labels on the left, weekend and today rows
+// shaded across the page, and each task as a bar spanning its rows
+func (s *MonthStrip) TikZ() string {
+	if len(s.Days) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	n := len(s.Days)
+	loc := s.Month.locale()
+
+	fmt.Fprintf(&sb, `\begin{tikzpicture}[x=\linewidth, y=-%s, font=\scriptsize]`+"\n", s.rowHeight())
+
+	asOf := time.Now()
+	if s.Cfg != nil {
+		asOf = s.Cfg.GetAsOfDate()
+	}
+	asOf = time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC)
+
+	for i, day := range s.Days {
+		date := day.getDayDate()
+		switch {
+		case s.Cfg != nil && s.Cfg.Today.Highlight && date.Equal(asOf):
+			fmt.Fprintf(&sb, `\fill[yellow!25] (0,%d) rectangle (1,%d);`+"\n", i, i+1)
+		case core.IsWeekend(date):
+			fmt.Fprintf(&sb, `\fill[black!6] (0,%d) rectangle (1,%d);`+"\n", i, i+1)
+		}
+		fmt.Fprintf(&sb, `\draw[black!15] (0,%d) -- (1,%d);`+"\n", i, i)
+
+		label := loc.ShortWeekdayName(date.Weekday()) + ` ` + strconv.Itoa(date.Day())
+		if day.HasDailyPage() {
+			label = templates.Link(day.DailyRef(), label)
+		}
+		if holiday := day.Holiday(); holiday != "" {
+			label += `\,{\tiny\textit{` + holiday + `}}`
+		}
+		fmt.Fprintf(&sb, `\node[anchor=west, inner sep=1pt, text width=%.2f\linewidth] at (0,%.2f) {\hypertarget{%s}{}%s};`+"\n",
+			stripLabelWidth, float64(i)+0.5, day.ref("strip-"), label)
+	}
+	fmt.Fprintf(&sb, `\draw[black!40] (0,0) -- (1,0) (0,%d) -- (1,%d) (%.2f,0) -- (%.2f,%d);`+"\n",
+		n, n, stripLaneStart-0.01, stripLaneStart-0.01, n)
+
+	if len(s.Lanes) > 0 {
+		laneWidth := (1 - stripLaneStart) / float64(len(s.Lanes))
+		for lane, tasks := range s.Lanes {
+			x0 := stripLaneStart + float64(lane)*laneWidth
+			x1 := x0 + laneWidth - 0.005
+			for _, task := range tasks {
+				sb.WriteString(s.taskBar(task, x0, x1))
+			}
+		}
+	}
+
+	sb.WriteString(`\end{tikzpicture}`)
+	return sb.String()
+}
+
+// taskBar draws one task spanning its rows in a lane, with the title written
+// across the top of the bar
+func (s *MonthStrip) taskBar(task *SpanningTask, x0, x1 float64) string {
+	color := fmt.Sprintf(`{rgb,255:%s}`, rgbComponents(core.HexToRGB(task.Color)))
+	top, bottom := s.row(task.StartDate), s.row(task.EndDate)+1
+
+	opacity := 0.3
+	if percent := s.Cfg.CategoryOpacity(task.Category); percent > 0 {
+		opacity = float64(percent) / 100
+	}
+	style := fmt.Sprintf(`fill=%s, fill opacity=%g, draw=%s`, color, opacity, color)
+	if task.IsCritical {
+		style += `, draw=red!75!black, line width=1pt`
+	}
+
+	name := barName(s.Cfg, task)
+	if task.IsMilestone {
+		name = `\ensuremath{\bigstar}~` + name
+	}
+	if task.StartDate.Before(s.Days[0].getDayDate()) {
+		name = `\ContinuedMarker{}` + name
+	}
+
+	return fmt.Sprintf(`\filldraw[%s, rounded corners=1pt] (%.4f,%d) rectangle (%.4f,%d);`+"\n", style, x0, top, x1, bottom) +
+		fmt.Sprintf(`\node[anchor=north west, inner sep=1pt, text width=%.4f\linewidth, font=\tiny] at (%.4f,%d) {%s};`+"\n",
+			x1-x0-0.005, x0, top, name)
+}
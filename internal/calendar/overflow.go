@@ -0,0 +1,115 @@
+package calendar
+
+import (
+	"strconv"
+	"strings"
+
+	"phd-dissertation-planner/internal/templates"
+)
+
+// ============================================================================
+// OVERFLOW APPENDIX
+// ============================================================================
+
+// overflowComposer is the composer name that renders the overflow appendix
+const overflowComposer = "overflow"
+
+// maxTasksPerDay returns the display threshold for task bars in a cell.
+// Swimlanes reserve a row per category, so they never collapse.
+func (d Day) maxTasksPerDay() int {
+	if d.Cfg == nil || d.Cfg.IsSwimlaneLayout() || d.Cfg.Layout.Calendar.MaxTasksPerDay < 0 {
+		return 0
+	}
+	return d.Cfg.Layout.Calendar.MaxTasksPerDay
+}
+
+// OverflowRef returns the hyperlink target of the day's overflow appendix entry
+func (d Day) OverflowRef() string {
+	return "overflow-" + d.Time.Format("2006-01-02")
+}
+
+// HasOverflowPage reports whether the overflow appendix is generated
+func (d Day) HasOverflowPage() bool {
+	return d.Cfg != nil && d.Cfg.UsesComposer(overflowComposer)
+}
+
+// OverflowTasks returns the tasks starting in this cell that exceed the
+// display threshold, in the order they would have been stacked
+func (d Day) OverflowTasks() []*SpanningTask {
+	limit := d.maxTasksPerDay()
+	if limit == 0 || d.Time.IsZero() {
+		return nil
+	}
+
+	activeTasks, _ := d.findActiveTasks(d.getDayDate())
+
+	var hidden []*SpanningTask
+	shown := 0
+	for _, rt := range d.rankTasks(activeTasks) {
+		if rt.Type != "start" {
+			continue
+		}
+		if shown < limit {
+			shown++
+			continue
+		}
+		hidden = append(hidden, rt.Task)
+	}
+	return hidden
+}
+
+// CellRef returns the hyperlink target of the day cell in the month grid
+func (d Day) CellRef() string {
+	return Day{Time: d.Time}.ref()
+}
+
+// overflowLink renders the "+N more" label, linked to the appendix when present
+func (d Day) overflowLink(hidden int) string {
+	label := `+` + strconv.Itoa(hidden) + ` ` + d.locale().Text("more")
+	if d.overflowNote > 0 {
+		return `\TaskOverflow{` + label + `\textsuperscript{` + strconv.Itoa(d.overflowNote) + `}}`
+	}
+	if d.HasOverflowPage() {
+		label = templates.Link(d.OverflowRef(), label)
+	}
+	return `\TaskOverflow{` + label + `}`
+}
+
+// numberOverflowNotes numbers the crowded days of the month in date order
+func (m *Month) numberOverflowNotes() {
+	note := 0
+	for _, week := range m.Weeks {
+		for i := range week.Days {
+			day := &week.Days[i]
+			if day.Time.IsZero() || day.Time.Month() != m.Month {
+				continue
+			}
+			if len(day.OverflowTasks()) > 0 {
+				note++
+				day.overflowNote = note
+			}
+		}
+	}
+}
+
+// OverflowFootnotes lists the tasks collapsed in crowded cells, one numbered
+// line per day, for the bottom of the month page
+func (m *Month) OverflowFootnotes() string {
+	var lines []string
+	for _, week := range m.Weeks {
+		for _, day := range week.Days {
+			if day.overflowNote == 0 || day.Time.Month() != m.Month {
+				continue
+			}
+
+			hidden := day.OverflowTasks()
+			names := make([]string, 0, len(hidden))
+			for _, task := range hidden {
+				names = append(names, task.EscapedName)
+			}
+			lines = append(lines, `\OverflowFootnote{`+strconv.Itoa(day.overflowNote)+`}{`+
+				templates.Link(day.ref(), day.locale().ShortDate(day.Time))+`}{`+strings.Join(names, `; `)+`}`)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
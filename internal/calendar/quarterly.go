@@ -0,0 +1,185 @@
+package calendar
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"phd-dissertation-planner/internal/core"
+	"phd-dissertation-planner/internal/templates"
+)
+
+// ============================================================================
+// QUARTERLY VIEW
+// ============================================================================
+
+// QuarterView lays out the tasks of one quarter on a grid of week columns so
+// that bars run continuously across month boundaries
+type QuarterView struct {
+	Quarter *Quarter
+	Weeks   []time.Time // First day of each week column
+	Rows    []QuarterRow
+	Cfg     *core.Config
+
+	start time.Time
+	end   time.Time
+}
+
+// QuarterRow is one task bar in the quarter grid
+type QuarterRow struct {
+	Task  SpanningTask
+	First int // First week column covered by the bar
+	Last  int // Last week column covered by the bar
+}
+
+// NewQuarterView builds the week grid for a quarter and places the tasks overlapping it
+func NewQuarterView(q *Quarter, tasks []SpanningTask, cfg *core.Config) *QuarterView {
+	firstMonth := time.Month((q.Number-1)*3 + 1)
+	start := time.Date(q.Year.Number, firstMonth, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 3, -1)
+
+	wd := time.Sunday
+	if cfg != nil {
+		wd = cfg.WeekStart
+	}
+
+	view := &QuarterView{Quarter: q, Cfg: cfg, start: start, end: end}
+	for ws := WeekStartOn(start, wd); !ws.After(end); ws = ws.AddDate(0, 0, 7) {
+		view.Weeks = append(view.Weeks, time.Date(ws.Year(), ws.Month(), ws.Day(), 0, 0, 0, 0, time.UTC))
+	}
+
+	for _, task := range prepareSpanningTasks(tasks) {
+		if task.EndDate.Before(start) || task.StartDate.After(end) {
+			continue
+		}
+		view.Rows = append(view.Rows, QuarterRow{
+			Task:  task,
+			First: view.columnFor(task.StartDate),
+			Last:  view.columnFor(task.EndDate),
+		})
+	}
+
+	return view
+}
+
+// columnFor returns the week column containing t, clamped to the grid
+func (v *QuarterView) columnFor(t time.Time) int {
+	for i := len(v.Weeks) - 1; i >= 0; i-- {
+		if !t.Before(v.Weeks[i]) {
+			return i
+		}
+	}
+	return 0
+}
+
+// HasTasks reports whether any task overlaps the quarter
+func (v *QuarterView) HasTasks() bool {
+	return len(v.Rows) > 0
+}
+
+// ColumnSpec returns the tabularx column specification for the grid
+func (v *QuarterView) ColumnSpec() string {
+	return `|p{0.26\linewidth}|*{` + strconv.Itoa(len(v.Weeks)) + `}{@{}X@{}|}`
+}
+
+// MonthHeader returns the header row naming each month over its week columns.
+// A week belongs to the month containing its midpoint.
+func (v *QuarterView) MonthHeader() string {
+	loc := v.locale()
+	cells := []string{""}
+
+	count := 0
+	current := v.monthOfColumn(0)
+	flush := func() {
+		ref := "month-" + strconv.Itoa(v.Quarter.Year.Number) + "-" + strconv.Itoa(int(current))
+		cells = append(cells, `\multicolumn{`+strconv.Itoa(count)+`}{c|}{\textbf{`+templates.Link(ref, loc.MonthName(current))+`}}`)
+	}
+
+	for i := range v.Weeks {
+		month := v.monthOfColumn(i)
+		if month != current {
+			flush()
+			current, count = month, 0
+		}
+		count++
+	}
+	flush()
+
+	return strings.Join(cells, " & ")
+}
+
+// WeekHeader returns the header row with the day-of-month each week column starts on
+func (v *QuarterView) WeekHeader() string {
+	cells := []string{`\textbf{Task}`}
+	for _, ws := range v.Weeks {
+		cells = append(cells, `{\tiny `+strconv.Itoa(ws.Day())+`}`)
+	}
+	return strings.Join(cells, " & ")
+}
+
+// TaskRows renders one table row per task with its bar shaded across week columns
+func (v *QuarterView) TaskRows() string {
+	var sb strings.Builder
+
+	for _, row := range v.Rows {
+		color := core.HexToRGB(row.Task.Color)
+		name := barName(v.Cfg, &row.Task)
+		if row.Task.IsMilestone {
+			name = `\ensuremath{\bigstar}~` + name
+		}
+
+		sb.WriteString(`{\scriptsize ` + name + `}`)
+		for i := range v.Weeks {
+			sb.WriteString(" & ")
+			if i < row.First || i > row.Last {
+				continue
+			}
+			switch {
+			case row.Task.IsMilestone:
+				sb.WriteString(`\hfil\textcolor[RGB]{` + color + `}{\ensuremath{\bigstar}}\hfil`)
+			default:
+				sb.WriteString(`\cellcolor[RGB]{` + color + `}`)
+				// Mark bars that continue beyond the quarter
+				if i == row.First && row.Task.StartDate.Before(v.start) {
+					sb.WriteString(`{\tiny\ensuremath{\triangleleft}}`)
+				}
+				if i == row.Last && row.Task.EndDate.After(v.end) {
+					sb.WriteString(`\hfill{\tiny\ensuremath{\triangleright}}`)
+				}
+			}
+		}
+		sb.WriteString(` \\ \hline` + "\n")
+	}
+
+	return sb.String()
+}
+
+// Breadcrumb creates the year / quarter trail for quarterly pages
+func (v *QuarterView) Breadcrumb() string {
+	return v.Quarter.Breadcrumb()
+}
+
+// Ref returns the hyperlink target for the quarterly page
+func (v *QuarterView) Ref() string {
+	return v.Quarter.ref()
+}
+
+// monthOfColumn returns the month a week column is attributed to
+func (v *QuarterView) monthOfColumn(i int) time.Month {
+	mid := v.Weeks[i].AddDate(0, 0, 3)
+	if mid.Before(v.start) {
+		mid = v.start
+	}
+	if mid.After(v.end) {
+		mid = v.end
+	}
+	return mid.Month()
+}
+
+// locale returns the configured locale, falling back to English
+func (v *QuarterView) locale() core.Locale {
+	if v.Cfg == nil {
+		return core.LookupLocale(core.DefaultLocaleCode)
+	}
+	return v.Cfg.GetLocale()
+}
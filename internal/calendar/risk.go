@@ -0,0 +1,140 @@
+package calendar
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"phd-dissertation-planner/internal/core"
+)
+
+// ============================================================================
+// SCHEDULE RISK
+// ============================================================================
+
+// riskHistogramBins is the number of bars in the finish distribution
+const riskHistogramBins = 20
+
+// RiskReport presents a Monte Carlo simulation of the plan's milestones
+type RiskReport struct {
+	Result core.SimulationResult
+	Cfg    *core.Config
+}
+
+// RiskRow is one milestone line of the confidence table
+type RiskRow struct {
+	Name    string      // Escaped milestone name
+	Planned time.Time   // Planned finish
+	OnTime  string      // Share of trials finishing by the planned date
+	Dates   []time.Time // Finish date at each reported percentile
+}
+
+// NewRiskReport wraps a simulation result for rendering
+func NewRiskReport(result core.SimulationResult, cfg *core.Config) *RiskReport {
+	return &RiskReport{Result: result, Cfg: cfg}
+}
+
+// PercentileHeaders returns the column headings, e.g. "P80"
+func (r *RiskReport) PercentileHeaders() []string {
+	headers := make([]string, 0, len(r.Result.Percentiles))
+	for _, p := range r.Result.Percentiles {
+		headers = append(headers, `P`+strconv.Itoa(p))
+	}
+	return headers
+}
+
+// ColumnSpec returns the tabularx column layout for the confidence table
+func (r *RiskReport) ColumnSpec() string {
+	return `@{}Xlr` + strings.Repeat("l", len(r.Result.Percentiles)) + `@{}`
+}
+
+// Rows returns the confidence table, one row per milestone in planned order
+func (r *RiskReport) Rows() []RiskRow {
+	rows := make([]RiskRow, 0, len(r.Result.Milestones))
+	for _, m := range r.Result.Milestones {
+		row := RiskRow{
+			Name:    EscapeLatexSpecialChars(m.Name),
+			Planned: m.Planned,
+			OnTime:  fmt.Sprintf(`%.0f\%%`, m.OnTimeProbability()*100),
+		}
+		for _, p := range r.Result.Percentiles {
+			row.Dates = append(row.Dates, m.Percentile(p))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// Final returns the milestone planned last, usually the defense or graduation
+func (r *RiskReport) Final() core.SimulatedDate {
+	return r.Result.Milestones[len(r.Result.Milestones)-1]
+}
+
+// FinalName returns the escaped name of the last milestone
+func (r *RiskReport) FinalName() string {
+	return EscapeLatexSpecialChars(r.Final().Name)
+}
+
+// Histogram renders the finish distribution of the last milestone as bars,
+// with the planned date and the highest reported percentile marked
+func (r *RiskReport) Histogram() string {
+	final := r.Final()
+	if len(final.Finishes) == 0 {
+		return ""
+	}
+
+	first, last := final.Finishes[0], final.Finishes[len(final.Finishes)-1]
+	if final.Planned.Before(first) {
+		first = final.Planned
+	}
+	if final.Planned.After(last) {
+		last = final.Planned
+	}
+	end := last.AddDate(0, 0, 1)
+
+	bins := make([]int, riskHistogramBins)
+	peak := 0
+	for _, f := range final.Finishes {
+		b := int(axisPosition(first, end, f) * riskHistogramBins)
+		if b >= riskHistogramBins {
+			b = riskHistogramBins - 1
+		}
+		bins[b]++
+		if bins[b] > peak {
+			peak = bins[b]
+		}
+	}
+
+	loc := core.LookupLocale(core.DefaultLocaleCode)
+	if r.Cfg != nil {
+		loc = r.Cfg.GetLocale()
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`\begin{tikzpicture}[x=\linewidth, y=4cm, font=\scriptsize]` + "\n")
+	width := 1.0 / riskHistogramBins
+	for i, n := range bins {
+		if n == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, `\fill[blue!45] (%.4f,0) rectangle (%.4f,%.4f);`+"\n",
+			float64(i)*width, float64(i+1)*width-0.004, float64(n)/float64(peak))
+	}
+	sb.WriteString(`\draw[black!60] (0,0) -- (1,0);` + "\n")
+	fmt.Fprintf(&sb, `\node[below right, inner sep=1pt] at (0,0) {%s};`+"\n", loc.ShortDate(first))
+	fmt.Fprintf(&sb, `\node[below left, inner sep=1pt] at (1,0) {%s};`+"\n", loc.ShortDate(last))
+
+	marker := func(t time.Time, style, anchor, label string) {
+		x := axisPosition(first, end, t)
+		fmt.Fprintf(&sb, `\draw[%s] (%.4f,0) -- (%.4f,1.05) node[%s, font=\tiny] {%s};`+"\n", style, x, x, anchor, label)
+	}
+	marker(final.Planned, `black, dashed`, `above left`, `planned `+loc.ShortDate(final.Planned))
+	if n := len(r.Result.Percentiles); n > 0 {
+		p := r.Result.Percentiles[n-1]
+		marker(final.Percentile(p), `red!70`, `above right`, `P`+strconv.Itoa(p)+` `+loc.ShortDate(final.Percentile(p)))
+	}
+
+	sb.WriteString(`\end{tikzpicture}`)
+	return sb.String()
+}
@@ -0,0 +1,197 @@
+package calendar
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"phd-dissertation-planner/internal/core"
+)
+
+// ============================================================================
+// BAR SEGMENTS AND MONTH CONTINUATION
+// ============================================================================
+
+// startsSegment reports whether a bar segment of the task begins on this day:
+// the task starts today, it carries over from the previous month onto the
+// first of the month, or with working-day bars it resumes after a weekend
+func (d Day) startsSegment(task *SpanningTask) bool {
+	dayDate := d.getDayDate()
+	start := d.getTaskStartDate(task)
+	if dayDate.Equal(start) {
+		return true
+	}
+	if !dayDate.After(start) || d.isMilestoneMarker(task) {
+		return false
+	}
+
+	if d.workingDaysOnly() {
+		if core.IsWeekend(dayDate) {
+			return false
+		}
+		if d.rowColumn(dayDate) == 0 {
+			return true
+		}
+		// Resume after a weekend, or on the first working day of the month
+		for prev := dayDate.AddDate(0, 0, -1); prev.Month() == dayDate.Month(); prev = prev.AddDate(0, 0, -1) {
+			if !core.IsWeekend(prev) {
+				return prev.Before(start) || core.IsWeekend(dayDate.AddDate(0, 0, -1))
+			}
+		}
+		return true
+	}
+
+	// Wrap onto each new week row and carry over onto the first of the month
+	return dayDate.Day() == 1 || d.rowColumn(dayDate) == 0
+}
+
+// isMilestoneMarker reports whether the task is drawn as a pinned marker
+// instead of a bar. Recurring milestones are always markers.
+func (d Day) isMilestoneMarker(task *SpanningTask) bool {
+	if task.IsRecurring {
+		return true
+	}
+	return task.IsMilestone && d.Cfg != nil && d.Cfg.GetMilestoneStyle() != core.MilestoneStyleBox
+}
+
+// milestoneMarker renders a milestone as a diamond or star with its title,
+// or as a circular arrow for recurring milestones
+func (d Day) milestoneMarker(task *SpanningTask) string {
+	macro := `\MilestoneDiamond`
+	switch {
+	case task.IsRecurring:
+		macro = `\RecurringMilestone`
+	case d.Cfg != nil && d.Cfg.GetMilestoneStyle() == core.MilestoneStyleStar:
+		macro = `\MilestoneStar`
+	}
+	return macro + `{` + core.HexToRGB(task.Color) + `}{` + d.dependencyMarkers(task, barName(d.Cfg, task)) + `}`
+}
+
+// rowColumn returns the grid column of t within its week row
+func (d Day) rowColumn(t time.Time) int {
+	wd := time.Monday
+	if d.Cfg != nil {
+		wd = d.Cfg.WeekStart
+	}
+	return int((7 + t.Weekday() - wd) % 7)
+}
+
+// segmentEnd returns the last day of the bar segment starting today: the task
+// end, the end of the week row or month, or the day before a weekend
+func (d Day) segmentEnd(task *SpanningTask) time.Time {
+	dayDate := d.getDayDate()
+	end := dayDate.AddDate(0, 0, 6-d.rowColumn(dayDate))
+	if monthEnd := time.Date(dayDate.Year(), dayDate.Month()+1, 0, 0, 0, 0, 0, time.UTC); monthEnd.Before(end) {
+		end = monthEnd
+	}
+	if d.workingDaysOnly() {
+		if last := dayDate.AddDate(0, 0, weekdaysFrom(dayDate)-1); last.Before(end) {
+			end = last
+		}
+	}
+	if task.EndDate.Before(end) {
+		end = task.EndDate
+	}
+	return end
+}
+
+// segmentCorners returns tcolorbox options squaring off the sides of a bar
+// segment that connect to another segment, so only real task ends are rounded
+func (d Day) segmentCorners(task *SpanningTask) string {
+	openLeft := !d.getDayDate().Equal(task.StartDate)
+	openRight := d.segmentEnd(task).Before(task.EndDate)
+
+	switch {
+	case openLeft && openRight:
+		return "sharp corners"
+	case openLeft:
+		return "sharp corners=west"
+	case openRight:
+		return "sharp corners=east"
+	}
+	return ""
+}
+
+// barOptions returns the tcolorbox keys a segment adds to its task box: the
+// segment corners and the border style its category declares
+func (d Day) barOptions(task *SpanningTask) string {
+	var options []string
+	if corners := d.segmentCorners(task); corners != "" {
+		options = append(options, corners)
+	}
+	if style := d.Cfg.CategoryBorderStyle(task.Category); style != "" {
+		options = append(options, "task border style="+style)
+	}
+	if opacity := d.Cfg.CategoryOpacity(task.Category); opacity > 0 {
+		options = append(options, "task category/.style={colback=taskbgcolor!"+strconv.Itoa(opacity)+"!PageBackground}")
+	}
+	return strings.Join(options, ", ")
+}
+
+// continuedFromPreviousMonth reports whether the segment starting today is the
+// carried-over part of a task that began in an earlier month
+func (d Day) continuedFromPreviousMonth(task *SpanningTask) bool {
+	dayDate := d.getDayDate()
+	monthStart := time.Date(dayDate.Year(), dayDate.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return task.StartDate.Before(monthStart)
+}
+
+// continuesIntoNextMonth reports whether the segment starting today reaches the
+// end of the month while the task runs on into the next one
+func (d Day) continuesIntoNextMonth(task *SpanningTask) bool {
+	dayDate := d.getDayDate()
+	monthEnd := time.Date(dayDate.Year(), dayDate.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+	if !task.EndDate.After(monthEnd) {
+		return false
+	}
+
+	// The segment runs to the end of the week row
+	rowEnd := dayDate.AddDate(0, 0, 6-d.rowColumn(dayDate))
+	return !rowEnd.Before(monthEnd)
+}
+
+// continuationMarkers wraps a task title with carry-over markers at month boundaries
+func (d Day) continuationMarkers(task *SpanningTask, name string) string {
+	if d.refPrefix != "" {
+		return name
+	}
+	if d.continuedFromPreviousMonth(task) {
+		name = `\ContinuedMarker{}` + name
+	}
+	if d.continuesIntoNextMonth(task) {
+		name += `\ContinuesMarker{}`
+	}
+	return name
+}
+
+// compactStackSize is the number of bars starting in one cell above which
+// duration labels are dropped to save space
+const compactStackSize = 2
+
+// durationLabel returns " (12d)" or a date range for the title of a task's
+// first segment, or "" when disabled or the bar is too narrow or crowded
+func (d Day) durationLabel(task *SpanningTask) string {
+	if d.Cfg == nil || !d.Cfg.Layout.TaskStyling.ShowTaskDurations || task.IsMilestone {
+		return ""
+	}
+	dayDate := d.getDayDate()
+	if !dayDate.Equal(task.StartDate) || !d.segmentEnd(task).After(dayDate) {
+		return ""
+	}
+
+	starting := 0
+	for _, t := range d.Tasks {
+		if d.startsSegment(t) {
+			starting++
+		}
+	}
+	if starting > compactStackSize {
+		return ""
+	}
+
+	if strings.EqualFold(d.Cfg.Layout.TaskStyling.DurationFormat, "range") {
+		loc := d.locale()
+		return `~{\tiny(` + loc.ShortDate(task.StartDate) + `--` + loc.ShortDate(task.EndDate) + `)}`
+	}
+	return `~{\tiny(` + strconv.Itoa(d.Cfg.GetDurationBetween(task.StartDate, task.EndDate)) + `d)}`
+}
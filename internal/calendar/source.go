@@ -0,0 +1,35 @@
+package calendar
+
+import "strings"
+
+// Source markers are written to the LaTeX log as the monthly day cells and
+// task bars are typeset, so a compilation error can be traced back to the
+// task and day being drawn when it happened. \wlog is a LaTeX kernel command,
+// so template overlays need no extra definitions.
+const (
+	SourceMarkerPrefix = "plannergen:"
+	SourceDay          = "day"
+	SourceTask         = "task"
+	SourceEnd          = "end"
+)
+
+// sourceMarker returns the LaTeX that logs the start of a day or task
+func sourceMarker(kind, id string) string {
+	return `\wlog{` + SourceMarkerPrefix + kind + `=` + SourceID(id) + `}`
+}
+
+// sourceEndMarker logs the end of a day cell
+const sourceEndMarker = `\wlog{` + SourceMarkerPrefix + SourceEnd + `}`
+
+// SourceID reduces an ID to the characters that are written to the log
+// unchanged, replacing the others with "-"
+func SourceID(id string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', strings.ContainsRune(".:/-", r):
+			return r
+		default:
+			return '-'
+		}
+	}, id)
+}
@@ -0,0 +1,69 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"phd-dissertation-planner/internal/core"
+)
+
+func TestSourceID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{"T4.2", "T4.2"},
+		{"SS:T4/a-1", "SS:T4/a-1"},
+		{"T4_3", "T4-3"},
+		{"T 4", "T-4"},
+		{`a\b{c}%`, "a-b-c--"},
+		{"Ü1", "-1"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := SourceID(tt.id); got != tt.want {
+			t.Errorf("SourceID(%q) = %q, want %q", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestSourceMarker(t *testing.T) {
+	tests := []struct {
+		kind, id string
+		want     string
+	}{
+		{SourceDay, "2026-03-14", `\wlog{plannergen:day=2026-03-14}`},
+		{SourceTask, "T4.2", `\wlog{plannergen:task=T4.2}`},
+		{SourceTask, "T4_3 {x}", `\wlog{plannergen:task=T4-3--x-}`},
+	}
+	for _, tt := range tests {
+		if got := sourceMarker(tt.kind, tt.id); got != tt.want {
+			t.Errorf("sourceMarker(%q, %q) = %q, want %q", tt.kind, tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestDayCellLogsSourceMarkers(t *testing.T) {
+	cfg := &core.Config{}
+	year := &Year{Number: 2026}
+	month := NewMonth(time.Monday, year, &Quarter{Number: 1, Year: year}, time.March, cfg)
+	ApplySpanningTasksToMonth(month, []SpanningTask{
+		{ID: "T4_3", Name: "RNA-seq analysis", StartDate: date(2026, 3, 14), EndDate: date(2026, 3, 16)},
+	})
+
+	var cell string
+	for _, week := range month.Weeks {
+		for _, day := range week.Days {
+			if day.Time.Equal(date(2026, 3, 14)) {
+				cell = day.Day(nil, true)
+			}
+		}
+	}
+	day := strings.Index(cell, `\wlog{plannergen:day=2026-03-14}`)
+	task := strings.Index(cell, `\wlog{plannergen:task=T4-3}`)
+	end := strings.LastIndex(cell, sourceEndMarker)
+	if day < 0 || task < day || end < task || !strings.HasSuffix(cell, sourceEndMarker) {
+		t.Errorf("day cell does not log day, task and end markers in order:\n%s", cell)
+	}
+}
@@ -0,0 +1,57 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ============================================================================
+// SWIMLANE LAYOUT
+// ============================================================================
+
+// renderSwimlaneOverlay renders the day's tasks into fixed per-category lanes
+// so a category always occupies the same row of every cell. Lanes held by a
+// task continuing from an earlier day are left blank, keeping its bar clear.
+func (d Day) renderSwimlaneOverlay(activeTasks []*SpanningTask, maxCols int) *TaskOverlay {
+	lanes := d.Cfg.GetSwimlanes()
+	height := d.Cfg.GetSwimlaneHeight()
+
+	laneIndex := make(map[string]int, len(lanes))
+	for i, lane := range lanes {
+		laneIndex[lane] = i
+	}
+
+	starting := make([][]*SpanningTask, len(lanes))
+	last := -1
+	for _, task := range activeTasks {
+		if !d.startsSegment(task) {
+			continue
+		}
+		i, ok := laneIndex[strings.ToUpper(strings.TrimSpace(task.Category))]
+		if !ok {
+			continue
+		}
+		starting[i] = append(starting[i], task)
+		if i > last {
+			last = i
+		}
+	}
+
+	// Lanes below the last starting task need no placeholder
+	var sb strings.Builder
+	for i := 0; i <= last; i++ {
+		var lane strings.Builder
+		for j, task := range starting[i] {
+			if j > 0 {
+				lane.WriteString(`\vspace{` + d.stackingSpacing() + `}`)
+			}
+			lane.WriteString(d.taskOverlayBox(task))
+		}
+		fmt.Fprintf(&sb, `\SwimlaneSlot{%s}{%s}`, height, lane.String())
+	}
+
+	return &TaskOverlay{
+		content: sb.String(),
+		cols:    maxCols,
+	}
+}
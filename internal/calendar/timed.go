@@ -0,0 +1,77 @@
+package calendar
+
+import (
+	"fmt"
+	"time"
+
+	"phd-dissertation-planner/internal/core"
+)
+
+// ============================================================================
+// INTRA-DAY TASKS
+// ============================================================================
+
+// isIntraDay reports whether the task starts and ends at given times on one day
+func (t *SpanningTask) isIntraDay() bool {
+	return (t.StartTime > 0 || t.EndTime > 0) && t.StartDate.Equal(t.EndDate) && t.EndTime > t.StartTime
+}
+
+// hoursApart reports whether two intra-day tasks on the same day do not
+// overlap in time, when overlap detection works at hour precision
+func (d Day) hoursApart(a, b *SpanningTask) bool {
+	if d.Cfg == nil || !d.Cfg.UsesHourPrecision() || !a.isIntraDay() || !b.isIntraDay() || !a.StartDate.Equal(b.StartDate) {
+		return false
+	}
+	return a.EndTime <= b.StartTime || b.EndTime <= a.StartTime
+}
+
+// dayWindow returns the hours of the day an intra-day bar is measured against,
+// the weekly page hour range
+func (d Day) dayWindow() (time.Duration, time.Duration) {
+	start, end := core.Defaults.WeeklyStartHour, core.Defaults.WeeklyEndHour
+	if d.Cfg != nil {
+		start, end = d.Cfg.GetWeeklyHours()
+	}
+	return time.Duration(start) * time.Hour, time.Duration(end) * time.Hour
+}
+
+// timedBar narrows the bar of an intra-day task to the part of the day cell
+// its hours cover
+func (d Day) timedBar(task *SpanningTask, box string) string {
+	if !task.isIntraDay() {
+		return box
+	}
+	from, to := d.dayWindow()
+	clamp := func(t time.Duration) float64 {
+		if t < from {
+			t = from
+		}
+		if t > to {
+			t = to
+		}
+		return float64(t-from) / float64(to-from)
+	}
+	offset := clamp(task.StartTime)
+	width := clamp(task.EndTime) - offset
+	if width < 0.25 {
+		// Keep short tasks wide enough for their title
+		width = 0.25
+		if offset+width > 1 {
+			offset = 1 - width
+		}
+	}
+	return fmt.Sprintf(`\TimedTaskBar{%.3f}{%.3f}{%s}`, offset, width, box)
+}
+
+// timeLabel prefixes the title of a task given with times with its hours
+func (d Day) timeLabel(task *SpanningTask) string {
+	if !task.isIntraDay() {
+		return ""
+	}
+	layout := "15:04"
+	if d.Cfg != nil && d.Cfg.AMPMTime {
+		layout = "3:04pm"
+	}
+	day := task.StartDate
+	return `{\tiny ` + day.Add(task.StartTime).Format(layout) + `--` + day.Add(task.EndTime).Format(layout) + `}~`
+}
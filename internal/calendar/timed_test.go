@@ -0,0 +1,34 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"phd-dissertation-planner/internal/core"
+)
+
+func TestTimedBar(t *testing.T) {
+	cfg := &core.Config{Weekly: core.WeeklyView{StartHour: 8, EndHour: 18}}
+	day := date(2026, 3, 13)
+	tests := []struct {
+		name     string
+		from, to time.Duration
+		end      time.Time
+		want     string
+	}{
+		{"whole day", 0, 0, day, "box"},
+		{"spans days", 9 * time.Hour, 11 * time.Hour, date(2026, 3, 14), "box"},
+		{"morning", 9 * time.Hour, 13 * time.Hour, day, `\TimedTaskBar{0.100}{0.400}{box}`},
+		{"clamped to the window", 6 * time.Hour, 20 * time.Hour, day, `\TimedTaskBar{0.000}{1.000}{box}`},
+		{"short widened", 12 * time.Hour, 12*time.Hour + 30*time.Minute, day, `\TimedTaskBar{0.400}{0.250}{box}`},
+		{"short at the end", 17*time.Hour + 30*time.Minute, 18 * time.Hour, day, `\TimedTaskBar{0.750}{0.250}{box}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &SpanningTask{StartDate: day, EndDate: tt.end, StartTime: tt.from, EndTime: tt.to}
+			if got := (Day{Time: day, Cfg: cfg}).timedBar(task, "box"); got != tt.want {
+				t.Errorf("timedBar = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"phd-dissertation-planner/internal/core"
+)
+
+// ============================================================================
+// ROADMAP TIMELINE
+// ============================================================================
+
+// timelineLabelLevels is the number of staggered label rows under the axis
+const timelineLabelLevels = 3
+
+// Timeline summarizes the plan as phase bands above a horizontal axis with
+// milestones marked along it
+type Timeline struct {
+	Start      time.Time // Axis start (first day of the first month)
+	End        time.Time // Axis end (first day after the last month)
+	Bands      []TimelineBand
+	Milestones []SpanningTask
+	Cfg        *core.Config
+}
+
+// TimelineBand is the span of one phase, placed in a lane so bands don't overlap
+type TimelineBand struct {
+	Name  string // Escaped phase name
+	Color string // Hex color of the phase
+	Start time.Time
+	End   time.Time
+	Lane  int
+}
+
+// NewTimeline builds the roadmap from the task set. Phases span from their
+// earliest start to their latest end.
+func NewTimeline(tasks []SpanningTask, cfg *core.Config) *Timeline {
+	localTasks := prepareSpanningTasks(tasks)
+	tl := &Timeline{Cfg: cfg}
+	if len(localTasks) == 0 {
+		return tl
+	}
+
+	latest := localTasks[0].EndDate
+	bandIndex := make(map[string]int)
+	for _, t := range localTasks {
+		if t.EndDate.After(latest) {
+			latest = t.EndDate
+		}
+		// Recurring milestones would crowd the overview axis
+		if t.IsMilestone && !t.IsRecurring {
+			tl.Milestones = append(tl.Milestones, t)
+		}
+		if t.Phase == "" {
+			continue
+		}
+
+		if i, ok := bandIndex[t.Phase]; ok {
+			if t.EndDate.After(tl.Bands[i].End) {
+				tl.Bands[i].End = t.EndDate
+			}
+			continue
+		}
+		bandIndex[t.Phase] = len(tl.Bands)
+		tl.Bands = append(tl.Bands, TimelineBand{
+			Name:  t.EscapedPhase,
+			Color: cfg.GenerateCategoryColor(t.Phase),
+			Start: t.StartDate,
+			End:   t.EndDate,
+		})
+	}
+
+	earliest := localTasks[0].StartDate
+	tl.Start = time.Date(earliest.Year(), earliest.Month(), 1, 0, 0, 0, 0, time.UTC)
+	tl.End = time.Date(latest.Year(), latest.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1, 0)
+
+	// Bands are already ordered by start; put each in the first lane that is free
+	var laneEnds []time.Time
+	for i := range tl.Bands {
+		lane := 0
+		for lane < len(laneEnds) && !tl.Bands[i].Start.After(laneEnds[lane]) {
+			lane++
+		}
+		if lane == len(laneEnds) {
+			laneEnds = append(laneEnds, time.Time{})
+		}
+		laneEnds[lane] = tl.Bands[i].End
+		tl.Bands[i].Lane = lane
+	}
+
+	return tl
+}
+
+// HasContent reports whether there is anything to draw
+func (tl *Timeline) HasContent() bool {
+	return len(tl.Bands) > 0 || len(tl.Milestones) > 0
+}
+
+// Span returns the covered period as "Mon YYYY -- Mon YYYY"
+func (tl *Timeline) Span() string {
+	if tl.Start.IsZero() {
+		return ""
+	}
+	loc := core.LookupLocale(core.DefaultLocaleCode)
+	if tl.Cfg != nil {
+		loc = tl.Cfg.GetLocale()
+	}
+	last := tl.End.AddDate(0, -1, 0)
+	return fmt.Sprintf("%s %d -- %s %d", loc.ShortMonthName(tl.Start.Month()), tl.Start.Year(), loc.ShortMonthName(last.Month()), last.Year())
+}
+
+// TikZ renders the timeline. Bands are stacked above the axis, milestone
+// labels are staggered below it so neighbouring labels don't collide.
+func (tl *Timeline) TikZ() string {
+	if !tl.HasContent() {
+		return ""
+	}
+
+	var sb strings.Builder
+	loc := core.LookupLocale(core.DefaultLocaleCode)
+	if tl.Cfg != nil {
+		loc = tl.Cfg.GetLocale()
+	}
+	pos := func(t time.Time) float64 { return axisPosition(tl.Start, tl.End, t) }
+
+	sb.WriteString(`\begin{tikzpicture}[x=\linewidth, y=1.6em, font=\scriptsize]` + "\n")
+
+	// Phase bands, lane 0 closest to the axis
+	for _, b := range tl.Bands {
+		y := float64(b.Lane) + 0.6
+		color := fmt.Sprintf(`{rgb,255:%s}`, rgbComponents(core.HexToRGB(b.Color)))
+		x0, x1 := pos(b.Start), pos(b.End.AddDate(0, 0, 1))
+		fmt.Fprintf(&sb, `\fill[fill=%s, fill opacity=0.6, rounded corners=2pt] (%.4f,%.2f) rectangle (%.4f,%.2f);`+"\n",
+			color, x0, y-0.4, x1, y+0.4)
+		fmt.Fprintf(&sb, `\node[anchor=west, inner sep=2pt] at (%.4f,%.2f) {\strut %s};`+"\n", x0, y, b.Name)
+	}
+
+	// Axis with month ticks; label only quarters on long plans
+	months := 0
+	for m := tl.Start; m.Before(tl.End); m = m.AddDate(0, 1, 0) {
+		months++
+	}
+	sb.WriteString(`\draw[thick, ->] (0,0) -- (1.01,0);` + "\n")
+	for m := tl.Start; m.Before(tl.End); m = m.AddDate(0, 1, 0) {
+		x := pos(m)
+		fmt.Fprintf(&sb, `\draw (%.4f,0.12) -- (%.4f,-0.12);`+"\n", x, x)
+		if months <= 24 || (m.Month()-1)%3 == 0 {
+			label := loc.ShortMonthName(m.Month())
+			if m.Month() == time.January || m.Equal(tl.Start) {
+				label += fmt.Sprintf(" %d", m.Year())
+			}
+			fmt.Fprintf(&sb, `\node[below, font=\tiny] at (%.4f,-0.12) {%s};`+"\n", pos(m.AddDate(0, 0, 15)), label)
+		}
+	}
+
+	// Milestones as diamonds on the axis with staggered, linked labels
+	for i, t := range tl.Milestones {
+		x := pos(t.StartDate)
+		y := -1.4 - float64(i%timelineLabelLevels)*1.3
+		color := fmt.Sprintf(`{rgb,255:%s}`, rgbComponents(core.HexToRGB(t.Color)))
+		fmt.Fprintf(&sb, `\draw[black!30] (%.4f,0) -- (%.4f,%.2f);`+"\n", x, x, y+0.3)
+		fmt.Fprintf(&sb, `\fill[fill=%s, draw=black!60] ([yshift=4pt]%.4f,0) -- ([xshift=4pt]%.4f,0) -- ([yshift=-4pt]%.4f,0) -- ([xshift=-4pt]%.4f,0) -- cycle;`+"\n",
+			color, x, x, x, x)
+		fmt.Fprintf(&sb, `\node[align=center, text width=0.16\linewidth] at (%.4f,%.2f) {\hyperlink{%s}{%s}\\{\tiny %s}};`+"\n",
+			x, y, Day{Time: t.StartDate}.ref(), barName(tl.Cfg, &t), loc.ShortDate(t.StartDate))
+	}
+
+	sb.WriteString(`\end{tikzpicture}`)
+	return sb.String()
+}
@@ -0,0 +1,192 @@
+package calendar
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"phd-dissertation-planner/internal/core"
+	"phd-dissertation-planner/internal/templates"
+)
+
+// ============================================================================
+// WEEKLY VIEW
+// ============================================================================
+
+// weeklyRefPrefix keeps weekly day cells from duplicating the monthly day targets
+const weeklyRefPrefix = "weekly-"
+
+// weeklyComposer is the composer name that renders weekly planner pages
+const weeklyComposer = "weekly"
+
+// NewWeek creates a full seven-day week beginning on start.
+// Unlike month rows, every column holds a day even across month boundaries.
+func NewWeek(start time.Time, cfg *core.Config) *Week {
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	week := &Week{Weekday: start.Weekday(), Year: &Year{Number: start.Year()}}
+
+	for i := 0; i < 7; i++ {
+		week.Days[i] = Day{Time: start.AddDate(0, 0, i), Tasks: nil, Cfg: cfg, refPrefix: weeklyRefPrefix}
+	}
+
+	return week
+}
+
+// WeekStartOn returns the first day of the week containing t for the given week start
+func WeekStartOn(t time.Time, wd time.Weekday) time.Time {
+	shift := (7 + t.Weekday() - wd) % 7
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -int(shift))
+}
+
+// StartDate returns the first calendar day of the week, including days that
+// belong to a neighbouring month and are blank in the month grid
+func (w *Week) StartDate() time.Time {
+	for i, day := range w.Days {
+		if !day.Time.IsZero() {
+			return day.Time.AddDate(0, 0, -i)
+		}
+	}
+	return time.Time{}
+}
+
+// EndDate returns the last calendar day of the week
+func (w *Week) EndDate() time.Time {
+	start := w.StartDate()
+	if start.IsZero() {
+		return start
+	}
+	return start.AddDate(0, 0, 6)
+}
+
+// Ref returns the hyperlink target for the weekly page of this week
+func (w *Week) Ref() string {
+	return w.ref()
+}
+
+// HasWeeklyPage reports whether a weekly page, and so the week's hyperlink
+// target, is generated for this week
+func (w *Week) HasWeeklyPage() bool {
+	var cfg *core.Config
+	for _, d := range w.Days {
+		if d.Cfg != nil {
+			cfg = d.Cfg
+			break
+		}
+	}
+	if cfg == nil || !(cfg.UsesComposer(weeklyComposer) || cfg.Weekly.Interleave) {
+		return false
+	}
+	if !cfg.Weekly.SkipEmpty {
+		return true
+	}
+
+	// Skipped weeks are those without an active task on any of their days,
+	// including days the month grid leaves blank
+	start, end := w.StartDate(), w.EndDate()
+	for _, task := range cfg.VisibleTasks() {
+		if !task.StartDate.After(end) && !task.EndDate.Before(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasTasks reports whether any day in the week has an active task
+func (w *Week) HasTasks() bool {
+	for _, d := range w.Days {
+		if len(d.Tasks) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// locale returns the configured locale of the week's days
+func (w *Week) locale() core.Locale {
+	for _, d := range w.Days {
+		if d.Cfg != nil {
+			return d.Cfg.GetLocale()
+		}
+	}
+	return core.LookupLocale(core.DefaultLocaleCode)
+}
+
+// Breadcrumb creates the year / quarter / month / week trail for weekly pages
+func (w *Week) Breadcrumb() string {
+	start := w.StartDate()
+	loc := w.locale()
+	quarter := (int(start.Month())-1)/3 + 1
+	monthRef := "month-" + strconv.Itoa(start.Year()) + "-" + strconv.Itoa(int(start.Month()))
+
+	return templates.Items{
+		templates.NewIntItem(start.Year()),
+		templates.NewTextItem("Q" + strconv.Itoa(quarter)),
+		templates.NewTextItem(loc.MonthName(start.Month())).RefText(monthRef),
+		templates.NewTextItem(loc.Text("week") + " " + strconv.Itoa(w.weekNumber())).RefText(w.ref()).Bold(true),
+	}.Table(true)
+}
+
+// DateRange returns the localized "start – end" label for the week
+func (w *Week) DateRange() string {
+	loc := w.locale()
+	return loc.ShortDate(w.StartDate()) + ` -- ` + loc.ShortDate(w.EndDate())
+}
+
+// PrevNext creates navigation items for the previous and next weeks
+func (w *Week) PrevNext() templates.Items {
+	start := w.StartDate()
+	week := w.locale().Text("week")
+	prev := Week{Year: &Year{Number: start.AddDate(0, 0, -7).Year()}}
+	prev.Days[0] = Day{Time: start.AddDate(0, 0, -7)}
+	next := Week{Year: &Year{Number: start.AddDate(0, 0, 7).Year()}}
+	next.Days[0] = Day{Time: start.AddDate(0, 0, 7)}
+
+	return templates.Items{
+		templates.NewTextItem(`$\langle$ ` + week + ` ` + strconv.Itoa(prev.weekNumber())).RefText(prev.ref()),
+		templates.NewTextItem(week + ` ` + strconv.Itoa(next.weekNumber()) + ` $\rangle$`).RefText(next.ref()),
+	}
+}
+
+// DayHeader returns the weekday header row with day numbers for the weekly task strip
+func (w *Week) DayHeader() string {
+	loc := w.locale()
+	names := make([]string, 0, 8)
+	names = append(names, "")
+
+	for _, d := range w.Days {
+		label := loc.ShortWeekdayName(d.Time.Weekday()) + ` ` + strconv.Itoa(d.Time.Day())
+		// Link the header to the day in the monthly grid
+		monthDay := Day{Time: d.Time}
+		names = append(names, `\hfil{}\rule{0pt}{2.5ex}\rule[-1ex]{0pt}{0pt}`+templates.Link(monthDay.ref(), label))
+	}
+
+	return strings.Join(names, " & ")
+}
+
+// WeekdayNames returns the localized full weekday names in column order
+func (w *Week) WeekdayNames() []string {
+	loc := w.locale()
+	names := make([]string, 0, 7)
+	for _, d := range w.Days {
+		names = append(names, loc.WeekdayName(d.Time.Weekday()))
+	}
+	return names
+}
+
+// ApplySpanningTasksToWeek applies spanning tasks to the days of a weekly view
+func ApplySpanningTasksToWeek(week *Week, tasks []SpanningTask) {
+	localTasks := prepareSpanningTasks(tasks)
+
+	for i := range week.Days {
+		dayDate := week.Days[i].getDayDate()
+		for j := range localTasks {
+			if localTasks[j].StartDate.After(dayDate) {
+				break
+			}
+			if !dayDate.After(localTasks[j].EndDate) {
+				week.Days[i].Tasks = append(week.Days[i].Tasks, &localTasks[j])
+			}
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+
+	"phd-dissertation-planner/internal/core"
+)
+
+func TestWeekNumberLinksOnlyToGeneratedWeeklyPages(t *testing.T) {
+	weeklyPages := core.Pages{{RenderBlocks: core.RenderBlocks{{FuncName: weeklyComposer}}}}
+	task := core.Task{ID: "T1", StartDate: date(2026, 3, 4), EndDate: date(2026, 3, 5)}
+
+	tests := []struct {
+		name     string
+		cfg      core.Config
+		start    int // Day of March 2026 the week starts on
+		wantLink bool
+	}{
+		{"no weekly pages", core.Config{}, 2, false},
+		{"weekly page block", core.Config{Pages: weeklyPages}, 2, true},
+		{"interleaved weeks", core.Config{Weekly: core.WeeklyView{Interleave: true}}, 2, true},
+		{"skipped empty week", core.Config{Pages: weeklyPages, Weekly: core.WeeklyView{SkipEmpty: true}, Tasks: []core.Task{task}}, 9, false},
+		{"kept week with a task", core.Config{Pages: weeklyPages, Weekly: core.WeeklyView{SkipEmpty: true}, Tasks: []core.Task{task}}, 2, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			week := NewWeek(date(2026, 3, tt.start), &tt.cfg)
+			got := week.WeekNumber(false)
+			target := `\hyperlink{week-2026-03-` + date(2026, 3, tt.start).Format("02") + `}`
+			if strings.Contains(got, target) != tt.wantLink || strings.Contains(got, `\hyperlink`) != tt.wantLink {
+				t.Errorf("WeekNumber = %q, want link to %s: %v", got, target, tt.wantLink)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package calendar
+
+import (
+	"time"
+
+	"phd-dissertation-planner/internal/core"
+)
+
+// ============================================================================
+// WORKING-DAY BARS
+// ============================================================================
+
+// workingDaysOnly reports whether task bars skip weekends
+func (d Day) workingDaysOnly() bool {
+	return d.Cfg != nil && d.Cfg.WorkingDays.Bars
+}
+
+// weekdaysFrom counts the consecutive weekdays starting at t
+func weekdaysFrom(t time.Time) int {
+	n := 0
+	for !core.IsWeekend(t) && n < 7 {
+		n++
+		t = t.AddDate(0, 0, 1)
+	}
+	return n
+}
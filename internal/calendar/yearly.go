@@ -0,0 +1,94 @@
+package calendar
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"phd-dissertation-planner/internal/core"
+	"phd-dissertation-planner/internal/templates"
+)
+
+// ============================================================================
+// YEARLY OVERVIEW
+// ============================================================================
+
+// Ref returns the hyperlink target for the year overview page
+func (y Year) Ref() string {
+	return y.ref()
+}
+
+// Months returns the twelve months of the year in order
+func (y Year) Months() Months {
+	return y.SideMonths()
+}
+
+// PhaseLegend returns each phase active during the year once, in order of first appearance
+func (y Year) PhaseLegend() []SubPhaseLegendItem {
+	seen := make(map[string]bool)
+	var legend []SubPhaseLegendItem
+
+	for _, month := range y.Months() {
+		for _, phase := range month.GetTaskColorsByPhase() {
+			for _, item := range phase.SubPhases {
+				if seen[item.Name] {
+					continue
+				}
+				seen[item.Name] = true
+				legend = append(legend, item)
+			}
+		}
+	}
+
+	return legend
+}
+
+// MiniCalendar renders a compact month grid for the year overview. Days are
+// shaded with the color of the earliest-starting phase active on that day, so
+// consecutive days of a phase form a colored band.
+func (m *Month) MiniCalendar() string {
+	loc := m.locale()
+
+	var sb strings.Builder
+	sb.WriteString(`\begin{tabular}{@{}*{7}{c}@{}}` + "\n")
+	sb.WriteString(`\multicolumn{7}{c}{` + templates.Link(m.ref(), `\textbf{`+loc.MonthName(m.Month)+`}`) + `} \\` + "\n")
+
+	initials := make([]string, 0, 7)
+	for i := 0; i < 7; i++ {
+		name := loc.ShortWeekdayName((m.Weekday + time.Weekday(i)) % 7)
+		initials = append(initials, `{\tiny `+string([]rune(name)[:1])+`}`)
+	}
+	sb.WriteString(strings.Join(initials, " & ") + ` \\ \hline` + "\n")
+
+	for _, week := range m.Weeks {
+		if !week.HasDays() {
+			continue
+		}
+
+		cells := make([]string, 0, 7)
+		for _, day := range week.Days {
+			cells = append(cells, day.miniCell())
+		}
+		sb.WriteString(strings.Join(cells, " & ") + ` \\` + "\n")
+	}
+
+	sb.WriteString(`\end{tabular}`)
+	return sb.String()
+}
+
+// miniCell renders a day for the mini calendar, shaded by its phase color
+func (d Day) miniCell() string {
+	if d.Time.IsZero() {
+		return ""
+	}
+
+	text := `{\scriptsize ` + templates.Link(d.ref(), strconv.Itoa(d.Time.Day())) + `}`
+
+	for _, task := range d.Tasks {
+		if task.Phase != "" {
+			return `\MiniDayCell{` + core.HexToRGB(d.Cfg.GenerateCategoryColor(task.Phase)) + `}{` + text + `}`
+		}
+	}
+
+	return text
+}
@@ -0,0 +1,73 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"phd-dissertation-planner/internal/core"
+)
+
+func TestMiniCell(t *testing.T) {
+	cfg := &core.Config{}
+	phase := &SpanningTask{ID: "T1", Phase: "Aim 1"}
+	link := `{\scriptsize \hyperlink{2026-03-13T00:00:00Z}{13}}`
+
+	tests := []struct {
+		name string
+		day  Day
+		want string
+	}{
+		{"blank", Day{Cfg: cfg}, ""},
+		{"no tasks", Day{Time: date(2026, 3, 13), Cfg: cfg}, link},
+		{"no phase", Day{Time: date(2026, 3, 13), Cfg: cfg, Tasks: []*SpanningTask{{ID: "T0"}}}, link},
+		{"shaded by phase", Day{Time: date(2026, 3, 13), Cfg: cfg, Tasks: []*SpanningTask{{ID: "T0"}, phase}},
+			`\MiniDayCell{` + core.HexToRGB(cfg.GenerateCategoryColor("Aim 1")) + `}{` + link + `}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.day.miniCell(); got != tt.want {
+				t.Errorf("miniCell = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestYearOverview(t *testing.T) {
+	cfg := &core.Config{}
+	year := NewYear(time.Monday, 2026, cfg)
+	months := year.Months()
+	if len(months) != 12 || months[0].Month != time.January || months[11].Month != time.December {
+		t.Fatalf("Months = %d months, want January to December", len(months))
+	}
+
+	march := months[2]
+	ApplySpanningTasksToMonth(march, []SpanningTask{
+		{ID: "T1", Name: "Pilot", Phase: "Aim 1", StartDate: date(2026, 3, 2), EndDate: date(2026, 3, 4)},
+		{ID: "T2", Name: "Survey", Phase: "Aim 2", StartDate: date(2026, 3, 9), EndDate: date(2026, 3, 9)},
+	})
+
+	got := march.MiniCalendar()
+	for _, want := range []string{
+		`\begin{tabular}{@{}*{7}{c}@{}}`,
+		`\multicolumn{7}{c}{\hyperlink{` + march.ref() + `}{\textbf{March}}}`,
+		`{\tiny M} & {\tiny T} & {\tiny W}`,
+		`\MiniDayCell{`,
+		`\end{tabular}`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("MiniCalendar lacks %q:\n%s", want, got)
+		}
+	}
+	if n := strings.Count(got, `\MiniDayCell{`); n != 4 {
+		t.Errorf("MiniCalendar shades %d days, want the 4 days with a phase", n)
+	}
+
+	var legend []string
+	for _, item := range year.PhaseLegend() {
+		legend = append(legend, item.Name)
+	}
+	if strings.Join(legend, ",") != "Aim 1,Aim 2" {
+		t.Errorf("PhaseLegend = %v, want Aim 1 and Aim 2 once each", legend)
+	}
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// abbreviation replaces one phrase of task names on task bars
+type abbreviation struct {
+	pattern *regexp.Regexp
+	short   string
+}
+
+// compileAbbreviations checks the abbreviations and prepares their patterns,
+// longest phrase first so "Institutional Review Board" wins over "Board"
+func (s *TaskStyling) compileAbbreviations() error {
+	phrases := make([]string, 0, len(s.Abbreviations))
+	for phrase := range s.Abbreviations {
+		if strings.TrimSpace(phrase) == "" {
+			return NewConfigError("", "layout.task_styling.abbreviations", "a phrase to abbreviate is empty", nil)
+		}
+		phrases = append(phrases, phrase)
+	}
+	sort.Slice(phrases, func(i, j int) bool {
+		if len(phrases[i]) != len(phrases[j]) {
+			return len(phrases[i]) > len(phrases[j])
+		}
+		return phrases[i] < phrases[j]
+	})
+
+	s.abbreviations = make([]abbreviation, 0, len(phrases))
+	for _, phrase := range phrases {
+		s.abbreviations = append(s.abbreviations, abbreviation{
+			pattern: phrasePattern(strings.TrimSpace(phrase)),
+			short:   s.Abbreviations[phrase],
+		})
+	}
+	return nil
+}
+
+// phrasePattern matches phrase case-insensitively as whole words, so "Board"
+// leaves "Keyboard" alone
+func phrasePattern(phrase string) *regexp.Regexp {
+	expr := regexp.QuoteMeta(phrase)
+	if isWordRune([]rune(phrase)[0]) {
+		expr = `\b` + expr
+	}
+	if runes := []rune(phrase); isWordRune(runes[len(runes)-1]) {
+		expr += `\b`
+	}
+	return regexp.MustCompile(`(?i)` + expr)
+}
+
+// isWordRune reports whether \b treats r as part of a word, which in Go's
+// regexp syntax only holds for ASCII
+func isWordRune(r rune) bool {
+	return r < unicode.MaxASCII && (r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// Abbreviate shortens the phrases of a task name for its task bar
+func (s TaskStyling) Abbreviate(name string) string {
+	for _, a := range s.abbreviations {
+		name = a.pattern.ReplaceAllLiteralString(name, a.short)
+	}
+	return name
+}
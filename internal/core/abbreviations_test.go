@@ -0,0 +1,29 @@
+package core
+
+import "testing"
+
+func TestAbbreviate(t *testing.T) {
+	s := TaskStyling{Abbreviations: map[string]string{
+		"Institutional Review Board": "IRB",
+		"board":                      "Bd",
+		"Dept.":                      "D.",
+	}}
+	if err := s.compileAbbreviations(); err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]string{
+		"Submit institutional review board form": "Submit IRB form",
+		"Board meeting on the keyboard":          "Bd meeting on the keyboard",
+		"Dept. seminar":                          "D. seminar",
+	}
+	for name, want := range tests {
+		if got := s.Abbreviate(name); got != want {
+			t.Errorf("Abbreviate(%q) = %q, want %q", name, got, want)
+		}
+	}
+
+	s.Abbreviations = map[string]string{" ": "x"}
+	if err := s.compileAbbreviations(); err == nil {
+		t.Error("expected an error for an empty phrase")
+	}
+}
@@ -0,0 +1,136 @@
+package core
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Academic calendar event kinds
+const (
+	AcademicSemesterStart = "semester_start"
+	AcademicSemesterEnd   = "semester_end"
+	AcademicExams         = "exams"
+	AcademicRegistration  = "registration"
+	AcademicBreak         = "break"
+	AcademicEventOther    = "event"
+)
+
+// academicBandColors shades the days covered by multi-day events of each kind
+var academicBandColors = map[string]string{
+	AcademicExams:      "255,235,205",
+	AcademicBreak:      "230,240,230",
+	AcademicEventOther: "235,235,245",
+}
+
+// AcademicEvent is an entry of the institution's academic calendar
+type AcademicEvent struct {
+	Kind  string
+	Name  string
+	Start time.Time
+	End   time.Time
+}
+
+// BandColor returns the "r,g,b" background for days covered by the event, or
+// "" for kinds that are shown as labels only
+func (e AcademicEvent) BandColor() string {
+	if e.End.Equal(e.Start) {
+		return ""
+	}
+	return academicBandColors[e.Kind]
+}
+
+// AcademicCalendarConfig points to the academic calendar overlay file
+type AcademicCalendarConfig struct {
+	// File is a CSV with columns kind,name,start_date[,end_date]
+	File string `yaml:"file" env:"PLANNER_ACADEMIC_CALENDAR"`
+}
+
+// LoadAcademicCalendar reads the configured academic calendar, if any
+func (c *Config) LoadAcademicCalendar() error {
+	path := strings.TrimSpace(c.AcademicCalendar.File)
+	if path == "" {
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return NewFileError(path, "open academic calendar", err)
+	}
+	defer f.Close()
+
+	events, err := parseAcademicCSV(f)
+	if err != nil {
+		return NewFileError(path, "parse academic calendar", err)
+	}
+	c.academicEvents = events
+	return nil
+}
+
+// AcademicEventsOn returns the academic events covering t
+func (c *Config) AcademicEventsOn(t time.Time) []AcademicEvent {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	var events []AcademicEvent
+	for _, e := range c.academicEvents {
+		if !day.Before(e.Start) && !day.After(e.End) {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// parseAcademicCSV reads "kind,name,start_date[,end_date]" rows; a header row is skipped
+func parseAcademicCSV(r io.Reader) ([]AcademicEvent, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var events []AcademicEvent
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 3 {
+			return nil, fmt.Errorf("row %d: expected kind, name and start date", row)
+		}
+
+		start, err := time.Parse(DateFormatISO, strings.TrimSpace(record[2]))
+		if err != nil {
+			if row == 1 {
+				continue // Header
+			}
+			return nil, fmt.Errorf("row %d: invalid start date %q", row, record[2])
+		}
+		end := start
+		if len(record) > 3 && strings.TrimSpace(record[3]) != "" {
+			if end, err = time.Parse(DateFormatISO, strings.TrimSpace(record[3])); err != nil {
+				return nil, fmt.Errorf("row %d: invalid end date %q", row, record[3])
+			}
+			if end.Before(start) {
+				return nil, fmt.Errorf("row %d: end date before start date", row)
+			}
+		}
+
+		kind := strings.ToLower(strings.TrimSpace(record[0]))
+		switch kind {
+		case AcademicSemesterStart, AcademicSemesterEnd, AcademicExams, AcademicRegistration, AcademicBreak:
+		default:
+			kind = AcademicEventOther
+		}
+
+		events = append(events, AcademicEvent{
+			Kind:  kind,
+			Name:  strings.TrimSpace(record[1]),
+			Start: start,
+			End:   end,
+		})
+	}
+	return events, nil
+}
@@ -0,0 +1,74 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BaselineConfig configures schedule variance against a saved baseline
+type BaselineConfig struct {
+	// File is a CSV of the plan as originally agreed. Its dates become the
+	// baseline of tasks with the same ID that have no Baseline Start/End columns.
+	File string `yaml:"file"`
+	// ToleranceDays is how many days a task may finish late before it is
+	// flagged as slipped
+	ToleranceDays int `yaml:"tolerance_days"`
+}
+
+// HasBaseline reports whether the task carries baseline dates
+func (t Task) HasBaseline() bool {
+	return !t.BaselineStart.IsZero() || !t.BaselineEnd.IsZero()
+}
+
+// StartVariance returns how many days later than baselined the task starts
+func (t Task) StartVariance() int {
+	return daysBetween(t.BaselineStart, t.StartDate)
+}
+
+// FinishVariance returns how many days later than baselined the task ends
+func (t Task) FinishVariance() int {
+	return daysBetween(t.BaselineEnd, t.EndDate)
+}
+
+// IsSlipped reports whether the task finishes later than its baseline by
+// more than the configured tolerance
+func (c *Config) IsSlipped(t Task) bool {
+	return t.HasBaseline() && t.FinishVariance() > c.Baseline.ToleranceDays
+}
+
+// ApplyBaseline fills baseline dates from the configured baseline file. Dates
+// read from Baseline Start/End columns take precedence.
+func (c *Config) ApplyBaseline(tasks []Task) error {
+	path := strings.TrimSpace(c.Baseline.File)
+	if path == "" {
+		return nil
+	}
+
+	baseline, err := NewReader(path).ReadTasks()
+	if err != nil {
+		return fmt.Errorf("baseline: %w", err)
+	}
+	if err := c.ApplyTimezone(baseline); err != nil {
+		return err
+	}
+	byID := make(map[string]Task, len(baseline))
+	for _, t := range baseline {
+		if id := strings.TrimSpace(t.ID); id != "" {
+			byID[id] = t
+		}
+	}
+
+	for i := range tasks {
+		b, ok := byID[strings.TrimSpace(tasks[i].ID)]
+		if !ok {
+			continue
+		}
+		if tasks[i].BaselineStart.IsZero() {
+			tasks[i].BaselineStart = b.StartDate
+		}
+		if tasks[i].BaselineEnd.IsZero() {
+			tasks[i].BaselineEnd = b.EndDate
+		}
+	}
+	return nil
+}
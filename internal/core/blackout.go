@@ -0,0 +1,85 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Blackout is a period with no work on the plan, such as conference travel
+// or a vacation
+type Blackout struct {
+	Name  string `yaml:"name"`
+	Start string `yaml:"start"` // YYYY-MM-DD
+	End   string `yaml:"end"`   // YYYY-MM-DD, defaults to Start
+}
+
+// blackoutRange is a parsed blackout
+type blackoutRange struct {
+	name       string
+	start, end time.Time
+}
+
+// LoadBlackouts parses the configured blackout periods
+func (c *Config) LoadBlackouts() error {
+	c.blackouts = nil
+	for i, b := range c.Blackouts {
+		field := fmt.Sprintf("blackouts[%d]", i)
+		start, err := time.Parse(DateFormatISO, strings.TrimSpace(b.Start))
+		if err != nil {
+			return NewConfigError("", field+".start", "invalid date "+b.Start, err)
+		}
+		end := start
+		if s := strings.TrimSpace(b.End); s != "" {
+			if end, err = time.Parse(DateFormatISO, s); err != nil {
+				return NewConfigError("", field+".end", "invalid date "+s, err)
+			}
+		}
+		if end.Before(start) {
+			return NewConfigError("", field+".end", "blackout ends before it starts", nil)
+		}
+		c.blackouts = append(c.blackouts, blackoutRange{name: strings.TrimSpace(b.Name), start: start, end: end})
+	}
+	return nil
+}
+
+// BlackoutOn returns the name of the blackout covering t, if any
+func (c *Config) BlackoutOn(t time.Time) (string, bool) {
+	day := dayOf(t)
+	for _, b := range c.blackouts {
+		if !day.Before(b.start) && !day.After(b.end) {
+			return b.name, true
+		}
+	}
+	return "", false
+}
+
+// BlackoutConflicts returns a warning for every task with dates from the CSV
+// that runs across a blackout. Auto-scheduled tasks already skip blackout days.
+func (c *Config) BlackoutConflicts(tasks []Task) []ValidationIssue {
+	var issues []ValidationIssue
+	for _, t := range tasks {
+		if t.AutoScheduled || t.StartDate.IsZero() || t.EndDate.IsZero() {
+			continue
+		}
+		start, end := dayOf(t.StartDate), dayOf(t.EndDate)
+		for _, b := range c.blackouts {
+			if start.After(b.end) || end.Before(b.start) {
+				continue
+			}
+			name := b.name
+			if name == "" {
+				name = "blackout"
+			}
+			issues = append(issues, ValidationIssue{
+				Type:  "blackout_conflict",
+				Field: "Start Date",
+				Value: t.StartDate.Format(DateFormatISO),
+				Message: fmt.Sprintf("Task %s (%s to %s) runs across %s (%s to %s)",
+					t.ID, start.Format(DateFormatISO), end.Format(DateFormatISO),
+					name, b.start.Format(DateFormatISO), b.end.Format(DateFormatISO)),
+			})
+		}
+	}
+	return issues
+}
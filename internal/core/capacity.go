@@ -0,0 +1,122 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CapacityConfig limits the hours of effort planned per week
+type CapacityConfig struct {
+	// HoursPerWeek is everyone's weekly capacity; 0 disables the check
+	// unless People sets a capacity for someone
+	HoursPerWeek float64 `yaml:"hours_per_week"`
+	// People overrides the weekly capacity per assignee
+	People map[string]float64 `yaml:"people"`
+}
+
+// CapacityOverload is one assignee's week planned beyond capacity
+type CapacityOverload struct {
+	Week     time.Time // First day of the week
+	Assignee string    // Empty for unassigned work
+	Hours    float64
+	Capacity float64
+}
+
+// String describes the overload for console reports
+func (o CapacityOverload) String() string {
+	who := o.Assignee
+	if who == "" {
+		who = "unassigned"
+	}
+	return fmt.Sprintf("week of %s: %s has %.1fh planned, capacity %.1fh",
+		o.Week.Format("2006-01-02"), who, o.Hours, o.Capacity)
+}
+
+// capacityFor returns the weekly capacity of an assignee, or 0 for no limit
+func (c *Config) capacityFor(assignee string) float64 {
+	for name, hours := range c.Capacity.People {
+		if strings.EqualFold(strings.TrimSpace(name), strings.TrimSpace(assignee)) {
+			return hours
+		}
+	}
+	return c.Capacity.HoursPerWeek
+}
+
+// weekOf returns the first day of the calendar week containing t
+func (c *Config) weekOf(t time.Time) time.Time {
+	d := dayOf(t)
+	return d.AddDate(0, 0, -int((7+d.Weekday()-c.WeekStart)%7))
+}
+
+// CheckCapacity spreads each task's Effort hours evenly over its weekdays
+// (or all its days when it only covers a weekend), sums them per assignee and
+// week, and returns the weeks where someone's load exceeds capacity. The
+// overloaded weeks are remembered for calendar shading.
+func (c *Config) CheckCapacity(tasks []Task) []CapacityOverload {
+	c.overloadedWeeks = nil
+	if c.Capacity.HoursPerWeek <= 0 && len(c.Capacity.People) == 0 {
+		return nil
+	}
+
+	type key struct {
+		week     time.Time
+		assignee string
+	}
+	load := make(map[key]float64)
+	names := make(map[string]string)
+	for _, t := range tasks {
+		if t.EffortHours <= 0 || t.StartDate.IsZero() || t.EndDate.IsZero() {
+			continue
+		}
+		var days []time.Time
+		for d := dayOf(t.StartDate); !d.After(dayOf(t.EndDate)); d = d.AddDate(0, 0, 1) {
+			if !IsWeekend(d) {
+				days = append(days, d)
+			}
+		}
+		if len(days) == 0 {
+			for d := dayOf(t.StartDate); !d.After(dayOf(t.EndDate)); d = d.AddDate(0, 0, 1) {
+				days = append(days, d)
+			}
+		}
+
+		person := strings.ToLower(strings.TrimSpace(t.Assignee))
+		names[person] = strings.TrimSpace(t.Assignee)
+		perDay := t.EffortHours / float64(len(days))
+		for _, d := range days {
+			load[key{c.weekOf(d), person}] += perDay
+		}
+	}
+
+	var overloads []CapacityOverload
+	for k, hours := range load {
+		capacity := c.capacityFor(names[k.assignee])
+		if capacity > 0 && hours > capacity+1e-9 {
+			overloads = append(overloads, CapacityOverload{
+				Week: k.week, Assignee: names[k.assignee], Hours: hours, Capacity: capacity,
+			})
+		}
+	}
+	sort.Slice(overloads, func(i, j int) bool {
+		if !overloads[i].Week.Equal(overloads[j].Week) {
+			return overloads[i].Week.Before(overloads[j].Week)
+		}
+		return overloads[i].Assignee < overloads[j].Assignee
+	})
+
+	if len(overloads) > 0 {
+		c.overloadedWeeks = make(map[time.Time]bool, len(overloads))
+		for _, o := range overloads {
+			c.overloadedWeeks[o.Week] = true
+		}
+	}
+	return overloads
+}
+
+// IsOverCapacity reports whether the week containing t was found over
+// capacity by the last CheckCapacity call
+func (c *Config) IsOverCapacity(t time.Time) bool {
+	return c.overloadedWeeks[c.weekOf(t)]
+}
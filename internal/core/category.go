@@ -0,0 +1,514 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// TaskCategory is a task category declared in the config. Tasks are matched
+// to it by name, ignoring case and extra spaces.
+type TaskCategory struct {
+	Name        string `yaml:"name"`
+	DisplayName string `yaml:"display_name"` // Legend label; defaults to the name
+	// Color is "#RRGGBB" or an R,G,B triple; empty picks a hue from the palette
+	Color string `yaml:"color"`
+	// Priority is given to tasks of the category that have none in the CSV
+	Priority    string `yaml:"priority"`
+	Description string `yaml:"description"`
+	// Hidden leaves the category's tasks off the pages and legends; stats,
+	// validation and reports still count them
+	Hidden bool `yaml:"hidden"`
+	// BorderStyle overrides layout.task_styling.border_style for the
+	// category's bars
+	BorderStyle string `yaml:"border_style"`
+	// Icon is an emoji or short text put before the names of the category's
+	// tasks on bars and beside its legend entry
+	Icon string `yaml:"icon"`
+	// Opacity is the strength of the bar fill in percent, overriding
+	// task_styling.background_opacity; 0 keeps the theme's
+	Opacity int `yaml:"opacity"`
+	// Bold and Italic emphasize the names of the category's tasks on bars
+	Bold   bool `yaml:"bold"`
+	Italic bool `yaml:"italic"`
+	// ZOrder biases the stacking in day cells: bars of categories with a
+	// higher value come first and are the last to collapse into "+N more"
+	ZOrder int `yaml:"z_order"`
+}
+
+// Label returns the display name, or the name when none is set
+func (c TaskCategory) Label() string {
+	if label := strings.TrimSpace(c.DisplayName); label != "" {
+		return label
+	}
+	return strings.TrimSpace(c.Name)
+}
+
+// CategoryColor is a category as shown in a legend, with the xcolor name
+// the templates define for it
+type CategoryColor struct {
+	Label  string
+	RGB    string
+	XColor string // e.g. "category-methodology-paper"
+}
+
+// CategoryUsage is a category of the plan as listed on the legend page, with
+// the number of its tasks and their total length in days
+type CategoryUsage struct {
+	CategoryColor
+	Pattern string // TikZ hatching, or "" for plain bars
+	Icon    string
+	Tasks   int
+	Days    int // Task lengths summed, end days included
+}
+
+// Assigned category colors
+const (
+	firstAssignedHue = 210.0 // Hue of the first slot
+	// maxDistinctHues is how many hues are told apart easily; with more
+	// categories, every other hue is darker
+	maxDistinctHues        = 12
+	alternateLightnessStep = 0.15
+	// similarColorDistance is the RGB distance under which two category
+	// colors are hatched differently
+	similarColorDistance = 80.0
+)
+
+// hatchings are the TikZ patterns of categories with close colors, in order
+// of use; the first is no pattern
+var hatchings = []string{"", "north east lines", "north west lines", "crosshatch", "horizontal lines", "vertical lines", "dots", "grid"}
+
+// priorities are the values of the Priority column, most important first
+var priorities = []string{"critical", "high", "medium", "low"}
+
+// DefaultCategories returns the categories declared when the config files
+// give none
+func DefaultCategories() []TaskCategory {
+	return []TaskCategory{
+		{Name: "PROPOSAL", DisplayName: "Proposal", Description: "PhD proposal related tasks"},
+		{Name: "LASER", DisplayName: "Laser", Description: "Laser system setup and maintenance"},
+		{Name: "IMAGING", DisplayName: "Imaging", Description: "Imaging experiments and data collection"},
+		{Name: "ADMIN", DisplayName: "Admin", Description: "Administrative tasks and paperwork"},
+		{Name: "DISSERTATION", DisplayName: "Dissertation", Description: "Dissertation writing and defense"},
+		{Name: "RESEARCH", DisplayName: "Research", Description: "General research activities"},
+		{Name: "PUBLICATION", DisplayName: "Publication", Description: "Publication and manuscript writing"},
+	}
+}
+
+// validateCategories checks the declared categories
+func (cfg *Config) validateCategories() error {
+	seen := make(map[string]bool, len(cfg.Categories))
+	for i, c := range cfg.Categories {
+		field := fmt.Sprintf("categories[%d]", i)
+		key := CategoryKey(c.Name)
+		if key == "" {
+			return NewConfigError("", field+".name", "category name is required", nil)
+		}
+		if seen[key] {
+			return NewConfigError("", field+".name", fmt.Sprintf("category %q is declared twice", c.Name), nil)
+		}
+		seen[key] = true
+		if _, ok := parseCategoryColor(c.Color); !ok {
+			return NewConfigError("", field+".color",
+				fmt.Sprintf("%q must be a hex color such as #3A7BD5 or an R,G,B triple such as 58,123,213", c.Color), nil)
+		}
+		if p := strings.TrimSpace(c.Priority); p != "" && !isPriority(p) {
+			return NewConfigError("", field+".priority",
+				fmt.Sprintf("unknown priority %q, expected one of %s", c.Priority, strings.Join(priorities, ", ")), nil)
+		}
+		if !isBorderStyle(c.BorderStyle) {
+			return NewConfigError("", field+".border_style",
+				fmt.Sprintf("unknown border style %q, expected one of %s", c.BorderStyle, strings.Join(BorderStyles(), ", ")), nil)
+		}
+		if c.Opacity < 0 || c.Opacity > 100 {
+			return NewConfigError("", field+".opacity", fmt.Sprintf("opacity %d must be a percentage between 0 and 100", c.Opacity), nil)
+		}
+	}
+	return nil
+}
+
+// isPriority reports whether p is one of the known priorities
+func isPriority(p string) bool {
+	for _, known := range priorities {
+		if strings.EqualFold(p, known) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseCategoryColor returns a declared color as "#RRGGBB"; an empty color
+// is valid and returns ""
+func parseCategoryColor(color string) (string, bool) {
+	color = strings.TrimSpace(color)
+	switch {
+	case color == "":
+		return "", true
+	case isRGBTriple(color):
+		parts := strings.Split(color, ",")
+		rgb := make([]any, len(parts))
+		for i, part := range parts {
+			rgb[i], _ = strconv.Atoi(strings.TrimSpace(part))
+		}
+		return fmt.Sprintf("#%02X%02X%02X", rgb...), true
+	case strings.HasPrefix(color, "#") && len(color) == 7:
+		if _, err := strconv.ParseUint(color[1:], 16, 32); err != nil {
+			return "", false
+		}
+		return strings.ToUpper(color), true
+	}
+	return "", false
+}
+
+// ApplyCategoryPriorities gives tasks without a priority the priority of
+// their declared category
+func (c *Config) ApplyCategoryPriorities(tasks []Task) {
+	for i := range tasks {
+		if strings.TrimSpace(tasks[i].Priority) != "" {
+			continue
+		}
+		if category, ok := c.LookupCategory(tasks[i].Category); ok && category.Priority != "" {
+			tasks[i].Priority = category.Priority
+		}
+	}
+}
+
+// VisibleTasks returns the tasks to lay out: those not in a hidden category
+func (c *Config) VisibleTasks() []Task {
+	hidden := make(map[string]bool)
+	for _, category := range c.Categories {
+		if category.Hidden {
+			hidden[CategoryKey(category.Name)] = true
+		}
+	}
+	if len(hidden) == 0 {
+		return c.Tasks
+	}
+	visible := make([]Task, 0, len(c.Tasks))
+	for _, t := range c.Tasks {
+		if !hidden[CategoryKey(t.Category)] {
+			visible = append(visible, t)
+		}
+	}
+	return visible
+}
+
+// setCategoryColors sets the legend colors of the declared categories that
+// are not hidden
+func (cfg *Config) setCategoryColors() {
+	cfg.Layout.CategoryColors = make([]CategoryColor, 0, len(cfg.Categories))
+	for _, c := range cfg.Categories {
+		if c.Hidden {
+			continue
+		}
+		cfg.Layout.CategoryColors = append(cfg.Layout.CategoryColors, CategoryColor{
+			Label: c.Label(),
+			RGB:   HexToRGB(cfg.GenerateCategoryColor(c.Name)),
+		})
+	}
+}
+
+// CategoryUsages returns the categories the tasks belong to, declared ones in
+// the order of the config and the others by name, with their task counts
+// and total days
+func (cfg *Config) CategoryUsages(tasks []Task) []CategoryUsage {
+	byKey := make(map[string]*CategoryUsage)
+	var undeclared []string
+	for _, t := range tasks {
+		key := CategoryKey(t.Category)
+		if key == "" {
+			continue
+		}
+		u, ok := byKey[key]
+		if !ok {
+			u = &CategoryUsage{CategoryColor: CategoryColor{
+				Label: strings.TrimSpace(t.Category),
+				RGB:   HexToRGB(cfg.GenerateCategoryColor(key)),
+			}, Pattern: cfg.CategoryPattern(key)}
+			if declared, ok := cfg.LookupCategory(key); ok {
+				u.Label = declared.Label()
+				u.Icon = declared.IconText()
+			} else {
+				undeclared = append(undeclared, key)
+			}
+			byKey[key] = u
+		}
+		u.Tasks++
+		if !t.StartDate.IsZero() && !t.EndDate.IsZero() {
+			u.Days += daysBetween(t.StartDate, t.EndDate) + 1
+		}
+	}
+	sort.Strings(undeclared)
+
+	usages := make([]CategoryUsage, 0, len(byKey))
+	for _, c := range cfg.Categories {
+		if u, ok := byKey[CategoryKey(c.Name)]; ok {
+			usages = append(usages, *u)
+		}
+	}
+	for _, key := range undeclared {
+		usages = append(usages, *byKey[key])
+	}
+	return usages
+}
+
+// AssignCategoryColors gives the categories of the tasks without a declared
+// color distinct colors from the palette: its slots (hues of the generated
+// scheme) are evenly spread and handed out in name order with a stride, so
+// that neighbours get far apart colors, and with many generated hues every
+// other one is darker. With palette patterns, it hatches the categories
+// whose colors are close to an earlier one's. It also sets the xcolor names
+// of the categories of the tasks and the declared ones.
+func (cfg *Config) AssignCategoryColors(tasks []Task) {
+	names := make(map[string]string)
+	var undeclared []string
+	for _, t := range tasks {
+		key := CategoryKey(t.Category)
+		if _, ok := names[key]; ok || key == "" {
+			continue
+		}
+		names[key] = strings.TrimSpace(t.Category)
+		if declared, ok := cfg.LookupCategory(key); !ok || declared.Color == "" {
+			undeclared = append(undeclared, key)
+		}
+	}
+	sort.Strings(undeclared)
+
+	base := cfg.styles()
+	palette := base.palette
+	n := len(undeclared)
+	stride := hueStride(n)
+	assigned := make(map[string]string, n)
+	for i, key := range undeclared {
+		slot := i * stride % n
+		if palette.Scheme == PaletteOkabeIto {
+			// Already ordered from the most distinct colors
+			slot = i
+		}
+		assigned[key] = palette.color(slot, n)
+	}
+	styles := &categoryStyles{palette: palette, declared: base.declared, colors: assigned}
+
+	planKeys := make([]string, 0, len(names))
+	for key := range names {
+		planKeys = append(planKeys, key)
+	}
+	sort.Strings(planKeys)
+	if palette.Patterns {
+		styles.patterns = styles.hatchPatterns(planKeys)
+	}
+	cfg.categoryStyles = styles
+
+	for _, c := range cfg.Categories {
+		if key := CategoryKey(c.Name); names[key] == "" {
+			names[key] = strings.TrimSpace(c.Name)
+		}
+	}
+	keys := make([]string, 0, len(names))
+	for key := range names {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	cfg.setCategoryColors()
+	cfg.Layout.TaskCategoryColors = make([]CategoryColor, 0, len(keys))
+	used := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		label := names[key]
+		if declared, ok := cfg.LookupCategory(key); ok {
+			label = declared.Label()
+		}
+		cfg.Layout.TaskCategoryColors = append(cfg.Layout.TaskCategoryColors, CategoryColor{
+			Label:  label,
+			RGB:    HexToRGB(cfg.GenerateCategoryColor(key)),
+			XColor: uniqueXColorName(names[key], used),
+		})
+	}
+}
+
+// hatchPatterns returns the TikZ pattern of each category, in order of keys,
+// whose color is close to that of an earlier category: the first pattern
+// that none of those close categories has, none being the first
+func (s *categoryStyles) hatchPatterns(keys []string) map[string]string {
+	patterns := make(map[string]string)
+	for i, key := range keys {
+		color := s.generateColor(key)
+		taken := make(map[string]bool)
+		for _, earlier := range keys[:i] {
+			if colorDistance(color, s.generateColor(earlier)) < similarColorDistance {
+				taken[patterns[earlier]] = true
+			}
+		}
+		for _, pattern := range hatchings {
+			if !taken[pattern] {
+				if pattern != "" {
+					patterns[key] = pattern
+				}
+				break
+			}
+		}
+	}
+	return patterns
+}
+
+// colorDistance returns the distance between two "#RRGGBB" colors in RGB space
+func colorDistance(a, b string) float64 {
+	var ca, cb [3]int
+	fmt.Sscanf(HexToRGB(a), "%d,%d,%d", &ca[0], &ca[1], &ca[2])
+	fmt.Sscanf(HexToRGB(b), "%d,%d,%d", &cb[0], &cb[1], &cb[2])
+	var sum float64
+	for c := range ca {
+		d := float64(ca[c] - cb[c])
+		sum += d * d
+	}
+	return math.Sqrt(sum)
+}
+
+// hueStride returns the step through n hue slots between categories next to
+// each other by name: the whole number nearest n divided by the golden ratio
+// that visits every slot
+func hueStride(n int) int {
+	if n < 3 {
+		return 1
+	}
+	stride := int(math.Round(float64(n) / math.Phi))
+	for gcd(stride, n) != 1 {
+		stride++
+	}
+	return stride
+}
+
+// gcd returns the greatest common divisor of a and b
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// uniqueXColorName returns "category-" and the category name in lower case
+// with runs of other characters than letters and digits as dashes, numbered
+// when that name is already used
+func uniqueXColorName(category string, used map[string]bool) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(category) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	name := "category-" + b.String()
+	for n := 2; used[name]; n++ {
+		name = fmt.Sprintf("category-%s-%d", b.String(), n)
+	}
+	used[name] = true
+	return name
+}
+
+// categoryStyles are the declared categories of a config by CategoryKey,
+// with colors normalized to "#RRGGBB", the palette coloring the others, and
+// the colors and patterns AssignCategoryColors gave them. A config's styles
+// are replaced rather than changed, so copies of the config share them.
+type categoryStyles struct {
+	palette  Palette
+	declared map[string]TaskCategory
+	colors   map[string]string
+	patterns map[string]string
+}
+
+// newCategoryStyles returns the styles of the declared categories, coloring
+// the others from palette
+func newCategoryStyles(categories []TaskCategory, palette Palette) *categoryStyles {
+	return &categoryStyles{palette: palette, declared: indexCategories(categories)}
+}
+
+// styles returns the category styles of the config, made from its
+// categories and palette for configs not built by NewConfig
+func (cfg *Config) styles() *categoryStyles {
+	if cfg == nil {
+		return newCategoryStyles(DefaultCategories(), themes[ThemeClassic].Palette)
+	}
+	if cfg.categoryStyles == nil {
+		return newCategoryStyles(cfg.Categories, cfg.Layout.Palette)
+	}
+	return cfg.categoryStyles
+}
+
+// CategoryPattern returns the TikZ pattern hatching the bars of a category,
+// or "" for plain bars
+func (cfg *Config) CategoryPattern(category string) string {
+	return cfg.styles().patterns[CategoryKey(category)]
+}
+
+// CategoryBorderStyle returns the border style declared for a category's
+// bars, or "" when the category uses the global one
+func (cfg *Config) CategoryBorderStyle(category string) string {
+	c, _ := cfg.LookupCategory(category)
+	return c.BorderStyle
+}
+
+// CategoryOpacity returns the fill opacity in percent declared for a
+// category's bars, or 0 when the category uses the theme's
+func (cfg *Config) CategoryOpacity(category string) int {
+	c, _ := cfg.LookupCategory(category)
+	return c.Opacity
+}
+
+// CategoryZOrder returns the stacking bias declared for a category
+func (cfg *Config) CategoryZOrder(category string) int {
+	c, _ := cfg.LookupCategory(category)
+	return c.ZOrder
+}
+
+// IconText returns the icon without emoji variation selectors, which XeLaTeX
+// prints as missing glyphs
+func (c TaskCategory) IconText() string {
+	return strings.TrimSpace(strings.Map(func(r rune) rune {
+		if r == '\uFE0E' || r == '\uFE0F' {
+			return -1
+		}
+		return r
+	}, c.Icon))
+}
+
+// CategoryIcon returns the icon declared for a category's tasks, or ""
+func (cfg *Config) CategoryIcon(category string) string {
+	c, _ := cfg.LookupCategory(category)
+	return c.IconText()
+}
+
+// color returns the declared or assigned color of a category
+func (s *categoryStyles) color(category string) (string, bool) {
+	key := CategoryKey(category)
+	if declared, ok := s.declared[key]; ok && declared.Color != "" {
+		return declared.Color, true
+	}
+	color, ok := s.colors[key]
+	return color, ok
+}
+
+// LookupCategory returns the declared category matching name
+func (cfg *Config) LookupCategory(name string) (TaskCategory, bool) {
+	category, ok := cfg.styles().declared[CategoryKey(name)]
+	return category, ok
+}
+
+// indexCategories keys categories by CategoryKey
+func indexCategories(categories []TaskCategory) map[string]TaskCategory {
+	index := make(map[string]TaskCategory, len(categories))
+	for _, c := range categories {
+		c.Color, _ = parseCategoryColor(c.Color)
+		c.Priority = strings.TrimSpace(c.Priority)
+		index[CategoryKey(c.Name)] = c
+	}
+	return index
+}
@@ -0,0 +1,138 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCategoriesFromConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	yml := "categories:\n  - name: Lab Work\n    color: 31,119,180\n    priority: high\n"
+	if err := os.WriteFile(path, []byte(yml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := NewConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.GenerateCategoryColor("lab  work"); got != "#1F77B4" {
+		t.Errorf("declared color = %s, want #1F77B4", got)
+	}
+	if _, ok := cfg.LookupCategory("Proposal"); ok {
+		t.Error("categories in the config file should replace the defaults")
+	}
+
+	tasks := []Task{{ID: "A", Category: "Lab Work"}, {ID: "B", Category: "Lab Work", Priority: "Low"}, {ID: "C", Category: "Other"}}
+	cfg.ApplyCategoryPriorities(tasks)
+	if tasks[0].Priority != "high" || tasks[1].Priority != "Low" || tasks[2].Priority != "" {
+		t.Errorf("priorities = %q, %q, %q; want high, Low and none", tasks[0].Priority, tasks[1].Priority, tasks[2].Priority)
+	}
+
+	if _, err := NewConfigWithOverrides([]string{"categories=[{name: X, color: blue}]"}, path); err == nil {
+		t.Error("invalid color: expected an error")
+	}
+}
+
+func TestHiddenCategories(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Categories = []TaskCategory{{Name: "Admin", Hidden: true}, {Name: "Research"}}
+	cfg.setCategoryColors()
+	cfg.Tasks = []Task{{ID: "A", Category: " admin"}, {ID: "R", Category: "Research"}, {ID: "O", Category: "Other"}}
+
+	visible := cfg.VisibleTasks()
+	if len(visible) != 2 || visible[0].ID != "R" || visible[1].ID != "O" {
+		t.Errorf("visible tasks = %v, want R and O", visible)
+	}
+	if len(cfg.Tasks) != 3 {
+		t.Error("hidden tasks should stay in the plan")
+	}
+	if len(cfg.Layout.CategoryColors) != 1 || cfg.Layout.CategoryColors[0].Label != "Research" {
+		t.Errorf("legend = %v, want Research only", cfg.Layout.CategoryColors)
+	}
+}
+
+func TestAssignCategoryColors(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Categories = []TaskCategory{{Name: "Writing", Color: "#112233"}}
+
+	var tasks []Task
+	for i := 0; i < 14; i++ {
+		tasks = append(tasks, Task{ID: fmt.Sprint(i), Category: fmt.Sprintf("Aim %d", i)})
+	}
+	tasks = append(tasks, Task{ID: "W", Category: "writing"}, Task{ID: "X", Category: "Aim 0"})
+	cfg.AssignCategoryColors(tasks)
+
+	seen := make(map[string]string)
+	for i := 0; i < 14; i++ {
+		category := fmt.Sprintf("Aim %d", i)
+		color := cfg.GenerateCategoryColor(category)
+		if other, ok := seen[color]; ok {
+			t.Errorf("%s and %s share color %s", category, other, color)
+		}
+		seen[color] = category
+	}
+	if got := cfg.GenerateCategoryColor("Writing"); got != "#112233" {
+		t.Errorf("declared color = %s, want #112233", got)
+	}
+	if other := DefaultConfig(); other.GenerateCategoryColor("Aim 0") == cfg.GenerateCategoryColor("Aim 0") {
+		t.Error("assigned colors should stay with their config")
+	}
+	if n := len(cfg.Layout.TaskCategoryColors); n != 15 {
+		t.Fatalf("%d xcolor names, want 15", n)
+	}
+	if got := cfg.Layout.TaskCategoryColors[0]; got.XColor != "category-aim-0" || got.RGB != HexToRGB(cfg.GenerateCategoryColor("Aim 0")) {
+		t.Errorf("first xcolor = %+v", got)
+	}
+}
+
+func TestCategoryUsages(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Categories = []TaskCategory{{Name: "Writing", DisplayName: "Thesis writing"}, {Name: "Admin"}, {Name: "Lab"}}
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+	cfg.Tasks = []Task{
+		{ID: "1", Category: "Zoo", StartDate: day(1), EndDate: day(1)},
+		{ID: "2", Category: "Admin", StartDate: day(1), EndDate: day(3)},
+		{ID: "3", Category: "writing", StartDate: day(2), EndDate: day(11)},
+		{ID: "4", Category: "Writing", StartDate: day(12), EndDate: day(12)},
+	}
+
+	usages := cfg.CategoryUsages(cfg.Tasks)
+	want := []struct {
+		label       string
+		tasks, days int
+	}{{"Thesis writing", 2, 11}, {"Admin", 1, 3}, {"Zoo", 1, 1}}
+	if len(usages) != len(want) {
+		t.Fatalf("got %d categories, want %d: %v", len(usages), len(want), usages)
+	}
+	for i, w := range want {
+		if u := usages[i]; u.Label != w.label || u.Tasks != w.tasks || u.Days != w.days {
+			t.Errorf("category %d = %s %d tasks %d days, want %s %d tasks %d days", i, u.Label, u.Tasks, u.Days, w.label, w.tasks, w.days)
+		}
+	}
+}
+
+func TestCategoryIcon(t *testing.T) {
+	c := TaskCategory{Name: "Lab", Icon: " ⚗️ "}
+	if got := c.IconText(); got != "⚗" {
+		t.Errorf("icon = %q, want ⚗ without the variation selector", got)
+	}
+	if got := (TaskCategory{Name: "Lab", Icon: "[L]"}).IconText(); got != "[L]" {
+		t.Errorf("icon = %q, want [L]", got)
+	}
+}
+
+func TestCategoryOpacityValidate(t *testing.T) {
+	for _, tc := range []struct {
+		opacity int
+		wantErr bool
+	}{{0, false}, {60, false}, {100, false}, {-1, true}, {101, true}} {
+		cfg := Config{Categories: []TaskCategory{{Name: "Lab", Opacity: tc.opacity}}}
+		if err := cfg.validateCategories(); (err != nil) != tc.wantErr {
+			t.Errorf("opacity %d: err = %v, want error %v", tc.opacity, err, tc.wantErr)
+		}
+	}
+}
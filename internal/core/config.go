@@ -57,7 +57,6 @@
 	"time"
 
 	"github.com/caarlos0/env/v6"
-	"github.com/goccy/go-yaml"
 )
 
 type Config struct {
@@ -71,6 +70,17 @@ type Config struct {
 	AMPMTime            bool
 	AddLastHalfHour     bool
 
+	// Locale selects month/weekday names and date formats (e.g. "de-DE")
+	Locale string `yaml:"locale" env:"PLANNER_LOCALE"`
+
+	// Strings overrides the locale's text of the generated pages by key, e.g.
+	// to translate a language without a built-in bundle
+	Strings map[string]string `yaml:"strings"`
+
+	// Timezone is the IANA zone (e.g. "America/Chicago") that timestamps with
+	// a UTC offset and "today" are placed in; empty means UTC
+	Timezone string `yaml:"timezone" env:"PLANNER_TIMEZONE"`
+
 	// Data source configuration
 	CSVFilePath string `env:"PLANNER_CSV_FILE"`
 	StartYear   int    `env:"PLANNER_START_YEAR"`
@@ -85,6 +95,20 @@ type Config struct {
 	// Months with tasks (populated from CSV)
 	MonthsWithTasks []MonthYear
 
+	// CalendarStart and CalendarEnd clip or pad the generated months to a
+	// fixed range (YYYY-MM or YYYY-MM-DD); empty follows the tasks
+	CalendarStart string `yaml:"calendar_start" env:"PLANNER_CALENDAR_START"`
+	CalendarEnd   string `yaml:"calendar_end" env:"PLANNER_CALENDAR_END"`
+
+	// CalendarMonths is the range applied from CalendarStart and CalendarEnd
+	CalendarMonths *MonthRange `yaml:"-"`
+
+	// PreviewRange limits the rebuilt months, from --range; nil renders all
+	PreviewRange *MonthRange `yaml:"-"`
+
+	// DryRun runs the whole pipeline without writing any file, from --dry-run
+	DryRun bool `yaml:"-"`
+
 	Pages Pages
 
 	Layout Layout
@@ -92,6 +116,191 @@ type Config struct {
 	// OutputDir is the directory where generated .tex and .pdf files will be written
 	// Defaults to "build" when not provided via environment or config
 	OutputDir string `env:"PLANNER_OUTPUT_DIR"`
+
+	// TemplatesDir is an optional directory of .tpl files that replace the
+	// built-in templates with the same name; missing files fall back to defaults
+	TemplatesDir string `yaml:"templates_dir" env:"PLANNER_TEMPLATES_DIR"`
+
+	// LaTeXEngine compiles the PDF: "latexmk", "xelatex", "tectonic", or
+	// "auto" (default) for the first of those installed
+	LaTeXEngine string `yaml:"latex_engine" env:"PLANNER_LATEX_ENGINE"`
+
+	// Theme names the built-in set of visual settings (classic, minimal,
+	// high-contrast, pastel) applied under the config files
+	Theme string `yaml:"theme" env:"PLANNER_THEME"`
+
+	// Profile names the output profile (print, digital, tablet or one of
+	// Profiles) whose settings apply over the config files
+	Profile string `yaml:"profile" env:"PLANNER_PROFILE"`
+
+	// Profiles defines output profiles as key.path=value overrides, replacing
+	// a built-in profile of the same name
+	Profiles map[string][]string `yaml:"profiles"`
+
+	// BuildFooter stamps each page with the generator version, commit and
+	// build day, so a printed planner can be traced to the build that made it
+	BuildFooter bool `yaml:"build_footer" env:"PLANNER_BUILD_FOOTER"`
+
+	// Categories declare task categories with their legend label, color and
+	// default priority; tasks of other categories get generated colors
+	Categories []TaskCategory `yaml:"categories"`
+
+	// TemplateHelpers controls which optional template helper functions are callable
+	TemplateHelpers TemplateHelpers `yaml:"template_helpers"`
+
+	// Weekly configures the one-week-per-page planner view
+	Weekly WeeklyView `yaml:"weekly"`
+
+	// Daily configures the per-day agenda pages
+	Daily DailyView `yaml:"daily"`
+
+	// Gantt configures the Gantt chart pages
+	Gantt GanttView `yaml:"gantt"`
+
+	// MonthStrip configures the vertical one-day-per-row month pages
+	MonthStrip MonthStripView `yaml:"month_strip"`
+
+	// Cover sets the title, author, institution and logo of the cover page
+	Cover CoverView `yaml:"cover"`
+
+	// Holidays configures holiday sources and whether they shorten durations
+	Holidays HolidayConfig `yaml:"holidays"`
+
+	// WorkingDays makes bars and durations skip weekends
+	WorkingDays WorkingDaysConfig `yaml:"working_days"`
+
+	// Today marks the generation date and shades the days before it
+	Today TodayConfig `yaml:"today"`
+
+	// AcademicCalendar overlays semesters, exam weeks and deadlines
+	AcademicCalendar AcademicCalendarConfig `yaml:"academic_calendar"`
+
+	// Scheduling computes dates for tasks given only a Duration
+	Scheduling SchedulingConfig `yaml:"scheduling"`
+
+	// Leveling shifts lower-priority tasks to cap weekly workload
+	Leveling LevelingConfig `yaml:"leveling"`
+
+	// Simulation configures Monte Carlo schedule risk analysis
+	Simulation SimulationConfig `yaml:"simulation"`
+
+	// Capacity limits the planned hours of effort per week
+	Capacity CapacityConfig `yaml:"capacity"`
+
+	// Blackouts are periods without work, skipped by the auto-scheduler
+	Blackouts []Blackout `yaml:"blackouts"`
+
+	// TaskTemplates are reusable sequences of tasks, by name, that a CSV row
+	// expands to through its Template column
+	TaskTemplates map[string][]TemplateStep `yaml:"task_templates"`
+
+	// Milestones decide which tasks read from the CSV are milestones
+	Milestones MilestoneRules `yaml:"milestones"`
+
+	// Filter limits the laid-out tasks to some categories or tags
+	Filter TaskFilter `yaml:"filter"`
+
+	// RecurringMilestones are generated and merged into the task set
+	RecurringMilestones []RecurringMilestone `yaml:"recurring_milestones"`
+
+	// Splitting cuts very long tasks into month- or phase-aligned segments
+	Splitting SplittingConfig `yaml:"splitting"`
+
+	// Baseline flags tasks finishing later than originally planned
+	Baseline BaselineConfig `yaml:"baseline"`
+
+	// Scenario compares the plan against a proposed alternative
+	Scenario ScenarioConfig `yaml:"scenario"`
+
+	// MonthOverrides set layout and daily keys for some months only
+	MonthOverrides []MonthOverride `yaml:"month_overrides"`
+
+	// holidays indexes loaded holiday names by ISO date
+	holidays map[string]string
+	// blackouts holds the parsed blackout periods
+	blackouts []blackoutRange
+	// academicEvents holds the loaded academic calendar
+	academicEvents []AcademicEvent
+	// levelingMoves records the tasks moved by LevelTasks
+	levelingMoves []LevelingMove
+	// overloadedWeeks marks the weeks CheckCapacity found over capacity
+	overloadedWeeks map[time.Time]bool
+	// scenarioShifts records the tasks that change in the proposed plan
+	scenarioShifts []TaskShift
+	// monthOverridden marks a config returned by ForMonth with overrides
+	monthOverridden bool
+	// categoryStyles holds the declared categories and the colors and
+	// patterns given to the others
+	categoryStyles *categoryStyles
+}
+
+// TodayConfig configures the "you are here" marking of printed planners
+type TodayConfig struct {
+	// Highlight emphasizes the as-of day cell
+	Highlight bool `yaml:"highlight"`
+	// ShadePast lightly shades every day before the as-of date
+	ShadePast bool `yaml:"shade_past"`
+	// AsOf overrides the generation date (YYYY-MM-DD), for reproducible output
+	AsOf string `yaml:"as_of" env:"PLANNER_AS_OF"`
+}
+
+// GanttView configures Gantt chart pages
+type GanttView struct {
+	// RowsPerPage is the maximum number of rows (tasks and phase headings) per page
+	RowsPerPage int `yaml:"rows_per_page"`
+}
+
+// MonthStripView configures vertical month-strip pages
+type MonthStripView struct {
+	// RowHeight is the height of each day row (a TikZ length, e.g. "6mm")
+	RowHeight string `yaml:"row_height"`
+}
+
+// CoverView configures the cover page
+type CoverView struct {
+	Title       string `yaml:"title"`
+	Author      string `yaml:"author"`
+	Institution string `yaml:"institution"`
+	// Logo is an image printed above the title, relative to the working directory
+	Logo string `yaml:"logo"`
+	// LogoWidth is the printed width of the logo (a LaTeX length, e.g. "4cm")
+	LogoWidth string `yaml:"logo_width"`
+}
+
+// DailyView configures daily agenda pages
+type DailyView struct {
+	// SkipEmpty omits days without any active task
+	SkipEmpty bool `yaml:"skip_empty"`
+	// NotesLines is the number of ruled note lines below the agenda
+	NotesLines int `yaml:"notes_lines"`
+}
+
+// Weekly page styles
+const (
+	WeeklyStyleHours = "hours" // one row per hour for each day
+	WeeklyStyleFree  = "free"  // open writing space for each day
+)
+
+// WeeklyView configures weekly planner pages
+type WeeklyView struct {
+	// Style is "hours" (hour rows) or "free" (blank space per day)
+	Style string `yaml:"style"`
+	// StartHour and EndHour bound the hour rows (24h clock, end exclusive)
+	StartHour int `yaml:"start_hour"`
+	EndHour   int `yaml:"end_hour"`
+	// Interleave renders each month's weeks right after its monthly page
+	Interleave bool `yaml:"interleave"`
+	// SkipEmpty omits weeks without any active task
+	SkipEmpty bool `yaml:"skip_empty"`
+}
+
+// TemplateHelpers enables or disables optional template helper functions.
+// Helpers required by the built-in templates are always available.
+type TemplateHelpers struct {
+	// Enabled, when non-empty, restricts optional helpers to the listed names
+	Enabled []string `yaml:"enabled"`
+	// Disabled lists optional helpers that templates may not call
+	Disabled []string `yaml:"disabled"`
 }
 
 type Debug struct {
@@ -103,6 +312,21 @@ type Debug struct {
 type Page struct {
 	Name         string
 	RenderBlocks RenderBlocks
+
+	// Header and Footer are templates printed at the top and bottom of each
+	// of the page's sheets, e.g. "{{.MonthName}} {{.Year}} — Aim 2 focus"
+	Header string `yaml:"header"`
+	Footer string `yaml:"footer"`
+}
+
+// HasHeaderFooter reports whether any page sets a header or footer template
+func (p Pages) HasHeaderFooter() bool {
+	for _, page := range p {
+		if strings.TrimSpace(page.Header) != "" || strings.TrimSpace(page.Footer) != "" {
+			return true
+		}
+	}
+	return false
 }
 
 type RenderBlocks []RenderBlock
@@ -112,6 +336,9 @@ type Module struct {
 	Cfg  Config
 	Tpl  string
 	Body interface{}
+	// Date is the first day the module shows, for header and footer
+	// templates; zero on pages not tied to a date
+	Date time.Time
 }
 
 type RenderBlock struct {
@@ -128,16 +355,6 @@ type Colors struct {
 
 // RGBColor removed - using algorithmic colors
 
-type AlgorithmicColors struct {
-	Proposal     string
-	Laser        string
-	Imaging      string
-	Admin        string
-	Dissertation string
-	Research     string
-	Publication  string
-}
-
 type LaTeX struct {
 	TabColSep             string  `yaml:"tabcolsep"`
 	HeaderSideMonthsWidth string  `yaml:"headersidemonthswidth"`
@@ -156,16 +373,34 @@ type LaTeX struct {
 }
 
 type TaskStyling struct {
-	// Core task appearance
+	// Core task appearance: FontSize is the size of bar titles
+	// (\TaskTitleSize), BodyFontSize that of descriptions (\TaskFontSize)
 	FontSize       string `yaml:"fontsize"`
+	BodyFontSize   string `yaml:"body_fontsize"`
 	BarHeight      string `yaml:"bar_height"`
 	BorderWidth    string `yaml:"border_width"`
 	ShowObjectives bool   `yaml:"show_objectives"`
 
+	// ShowDependencies draws arrows between dependent tasks on month pages
+	ShowDependencies bool `yaml:"show_dependencies"`
+
+	// HighlightCriticalPath emphasizes tasks on the critical path
+	HighlightCriticalPath bool `yaml:"highlight_critical_path"`
+
+	// ShowTaskDurations appends the duration to task bar titles when the bar
+	// is wide enough; DurationFormat picks "days" ("(12d)") or "range" dates
+	ShowTaskDurations bool   `yaml:"show_task_durations"`
+	DurationFormat    string `yaml:"duration_format"`
+
 	// Visual styling
 	BackgroundOpacity int `yaml:"background_opacity"`
 	BorderOpacity     int `yaml:"border_opacity"`
 
+	// BorderStyle draws bar borders "solid" (default), "dashed" or "dotted";
+	// categories may set their own. Shadow adds a drop shadow under bars
+	BorderStyle string `yaml:"border_style"`
+	Shadow      bool   `yaml:"shadow"`
+
 	// Task box spacing and padding
 	Spacing TaskStylingSpacing `yaml:"spacing"`
 
@@ -174,6 +409,11 @@ type TaskStyling struct {
 
 	// Milestone-specific styling
 	Milestone TaskStylingMilestone `yaml:"milestone"`
+
+	// Abbreviations shorten phrases of task names on task bars, e.g.
+	// "Institutional Review Board: IRB"; agendas and indexes keep full names
+	Abbreviations map[string]string `yaml:"abbreviations"`
+	abbreviations []abbreviation
 }
 
 type TaskStylingSpacing struct {
@@ -192,6 +432,38 @@ type TaskStylingMilestone struct {
 	BorderWidth       string `yaml:"border_width"`
 	BorderOpacity     int    `yaml:"border_opacity"`
 	BackgroundOpacity int    `yaml:"background_opacity"`
+
+	// Style draws milestones as a "box" bar (default), or as a "diamond" or
+	// "star" marker pinned to the start date
+	Style string `yaml:"style"`
+}
+
+// Milestone styles
+const (
+	MilestoneStyleBox     = "box"
+	MilestoneStyleDiamond = "diamond"
+	MilestoneStyleStar    = "star"
+)
+
+// Task bar border styles
+const (
+	BorderStyleSolid  = "solid"
+	BorderStyleDashed = "dashed"
+	BorderStyleDotted = "dotted"
+)
+
+// BorderStyles lists the task bar border styles
+func BorderStyles() []string {
+	return []string{BorderStyleSolid, BorderStyleDashed, BorderStyleDotted}
+}
+
+// isBorderStyle reports whether s names a border style; empty means the default
+func isBorderStyle(s string) bool {
+	switch s {
+	case "", BorderStyleSolid, BorderStyleDashed, BorderStyleDotted:
+		return true
+	}
+	return false
 }
 
 type TColorBoxOverlay struct {
@@ -220,6 +492,30 @@ type Spacing struct {
 type Document struct {
 	FontSize  string
 	ParIndent string
+
+	// FontFamily is the default family of the document text: sans or serif
+	FontFamily string `yaml:"font_family"`
+
+	// Font is an installed OpenType font (e.g. "TeX Gyre Heros") for that
+	// family, and HeaderFont one for page titles and headers; empty keeps
+	// Latin Modern and the document font
+	Font       string `yaml:"font"`
+	HeaderFont string `yaml:"header_font"`
+
+	// IconFont is an installed font for category icons, such as "Noto Emoji"
+	// or "Symbola"; XeLaTeX draws emoji in monochrome from such a font
+	IconFont string `yaml:"icon_font"`
+
+	// HeaderSize is the LaTeX size command of page titles, such as \Large
+	HeaderSize string `yaml:"header_size"`
+
+	// Preamble is extra LaTeX put at the end of the preamble, such as
+	// \usepackage lines
+	Preamble string `yaml:"preamble"`
+
+	// MacrosFile is a .tex file, relative to the working directory, whose
+	// content follows the built-in macros so it can redefine them
+	MacrosFile string `yaml:"macros_file"`
 }
 
 type Constraints struct {
@@ -250,8 +546,20 @@ type Layout struct {
 	Lengths Lengths
 	Colors  Colors
 	// TaskColors removed - using algorithmic colors
-	AlgorithmicColors AlgorithmicColors
-	LaTeX             LaTeX `yaml:"latex"`
+	// CategoryColors are the declared categories for the default legend
+	CategoryColors []CategoryColor `yaml:"-"`
+	// TaskCategoryColors are the categories of the plan and the declared
+	// ones, for the xcolor names of the templates
+	TaskCategoryColors []CategoryColor `yaml:"-"`
+	LaTeX              LaTeX           `yaml:"latex"`
+
+	// Palette, Shading and PageColors are set by the theme unless given here
+	Palette    Palette    `yaml:"palette"`
+	Shading    Shading    `yaml:"shading"`
+	PageColors PageColors `yaml:"page_colors"`
+
+	// Links styles the navigation links, hidden for print by default
+	Links Links `yaml:"links"`
 
 	// Centralized task styling and spacing
 	TaskStyling TaskStyling `yaml:"task_styling"`
@@ -261,14 +569,150 @@ type Layout struct {
 	Calendar     Calendar
 	Stacking     Stacking
 	LayoutEngine LayoutEngine `yaml:"layout_engine"`
+
+	// PageNumbering sets page numbers, month sections and running heads
+	PageNumbering PageNumbering `yaml:"page_numbering"`
+
+	// Grid sizes the week rows of the month grid to the page
+	Grid Grid `yaml:"grid"`
+}
+
+// Grid sizes the week rows of the month grid
+type Grid struct {
+	// Fill stretches the week rows so the grid fills the text height left by
+	// the paper margins; otherwise day cells are latex.monthlycellheight high
+	Fill bool `yaml:"fill"`
+	// Padding is the text height kept clear of the week rows when filling,
+	// for the page header and the legend below the grid
+	Padding string `yaml:"padding"`
+}
+
+// validate checks the grid padding and, when filling, the paper margins the
+// row height is computed from
+func (g Grid) validate(paper Paper) error {
+	if g.Padding != "" {
+		if err := validateDimension(g.Padding); err != nil {
+			return NewConfigError("", "layout.grid.padding", err.Error(), nil)
+		}
+	}
+	if !g.Fill {
+		return nil
+	}
+	margins := []struct{ field, length string }{
+		{"layout.paper.height", paper.Height},
+		{"layout.paper.margin.top", paper.Margin.Top},
+		{"layout.paper.margin.bottom", paper.Margin.Bottom},
+	}
+	for _, m := range margins {
+		if err := validateDimension(m.length); err != nil {
+			return NewConfigError("", m.field, err.Error(), nil)
+		}
+	}
+	return nil
+}
+
+// PageNumbering configures page numbers, month sections and running heads
+type PageNumbering struct {
+	// Position prints page numbers in the footer: "left", "center" or
+	// "right"; empty leaves pages unnumbered
+	Position string `yaml:"position"`
+	// Style is the numbering style: arabic (default), roman, Roman or alph
+	Style string `yaml:"style"`
+	// MonthSections numbers the month pages as sections, shown in the
+	// running head and the PDF bookmarks
+	MonthSections bool `yaml:"month_sections"`
+	// RunningHead prints the current month at the top left of each page
+	RunningHead bool `yaml:"running_head"`
+}
+
+// Page number positions and styles
+var (
+	pageNumberPositions = []string{"left", "center", "right"}
+	pageNumberStyles    = []string{"arabic", "roman", "Roman", "alph"}
+)
+
+// Marks reports whether month pages mark a section or running head
+func (p PageNumbering) Marks() bool {
+	return p.MonthSections || p.RunningHead
+}
+
+// validate checks the position and style
+func (p PageNumbering) validate() error {
+	switch p.Position {
+	case "", "left", "center", "right":
+	default:
+		return NewConfigError("", "layout.page_numbering.position",
+			fmt.Sprintf("unknown position %q, expected one of %s", p.Position, strings.Join(pageNumberPositions, ", ")), nil)
+	}
+	switch p.Style {
+	case "", "arabic", "roman", "Roman", "alph":
+	default:
+		return NewConfigError("", "layout.page_numbering.style",
+			fmt.Sprintf("unknown style %q, expected one of %s", p.Style, strings.Join(pageNumberStyles, ", ")), nil)
+	}
+	return nil
 }
 
 type Calendar struct {
 	TaskKernSpacing string `yaml:"taskkernspacing"`
+
+	// LayoutMode selects how tasks share a day cell: "stacked" (default) packs
+	// them dynamically, "swimlanes" gives each category a fixed row
+	LayoutMode string `yaml:"layout_mode"`
+	// Swimlanes orders the category lanes; unlisted categories follow in
+	// order of first appearance
+	Swimlanes []string `yaml:"swimlanes"`
+	// SwimlaneHeight is the height reserved for each lane
+	SwimlaneHeight string `yaml:"swimlane_height"`
+	// MiniCalendars shows small previous/next month calendars on month pages
+	MiniCalendars bool `yaml:"mini_calendars"`
+	// WeekNumbers selects the week gutter numbering: "sequential" (default)
+	// counts weeks from January 1st, "iso" uses ISO-8601 week numbers
+	WeekNumbers string `yaml:"week_numbers"`
+	// MaxTasksPerDay caps the task bars starting in a day cell; the rest
+	// collapse into a "+N more" link to the overflow appendix (0 = no limit)
+	MaxTasksPerDay int `yaml:"max_tasks_per_day"`
+	// OverflowMode selects where collapsed tasks are listed: "appendix"
+	// (default) links to the overflow page, "footnotes" numbers them at the
+	// bottom of the month page
+	OverflowMode string `yaml:"overflow_mode"`
+	// OverlapPrecision is "day" (default) to stack any tasks sharing a day, or
+	// "hour" to let tasks given with times share a track when their hours
+	// do not overlap
+	OverlapPrecision string `yaml:"overlap_precision"`
+	// PhaseBands draws each phase's span as a thin band behind the task bars
+	PhaseBands bool `yaml:"phase_bands"`
 	// Other parameters hardcoded in calendar.go
 }
 
+// Calendar layout modes
+const (
+	CalendarLayoutStacked   = "stacked"
+	CalendarLayoutSwimlanes = "swimlanes"
+)
+
+// Overlap detection precisions
+const (
+	OverlapPrecisionDay  = "day"
+	OverlapPrecisionHour = "hour"
+)
+
+// Where tasks collapsed into "+N more" are listed
+const (
+	OverflowAppendix  = "appendix"
+	OverflowFootnotes = "footnotes"
+)
+
+// Week numbering schemes for the calendar gutter
+const (
+	WeekNumbersSequential = "sequential"
+	WeekNumbersISO        = "iso"
+)
+
 type Stacking struct {
+	// Spacing is the gap between task bars stacked in a day cell or lane
+	Spacing string `yaml:"spacing"`
+
 	BaseHeight float64 `yaml:"base_height"`
 	MinHeight  float64 `yaml:"min_height"`
 	MaxHeight  float64 `yaml:"max_height"`
@@ -372,6 +816,10 @@ type Paper struct {
 	Width  string `env:"PLANNER_LAYOUT_PAPER_WIDTH"`
 	Height string `env:"PLANNER_LAYOUT_PAPER_HEIGHT"`
 
+	// Bleed extends the paper on every side for trimming after printing,
+	// moving the margins out with it; empty for none
+	Bleed string `yaml:"bleed"`
+
 	Margin Margin
 
 	ReverseMargins bool
@@ -411,38 +859,32 @@ func FilterUniqueModules(array []Module) []Module {
 // NewConfig creates a new configuration from config files and environment variables
 // Starts with sensible defaults and overlays file and environment configuration
 func NewConfig(pathConfigs ...string) (Config, error) {
-	var (
-		bts []byte
-		err error
-	)
+	return NewConfigWithOverrides(nil, pathConfigs...)
+}
 
-	// Start with default configuration
+// NewConfigWithOverrides creates a configuration like NewConfig, then sets
+// the keys of PLANNERGEN_* environment variables and of overrides
+// (key.path=value, e.g. from --set) over it, before defaults and validation
+func NewConfigWithOverrides(overrides []string, pathConfigs ...string) (Config, error) {
+	// Read the layers once to learn the theme and output profile, then again
+	// over the theme so that every key they set wins over it, with the
+	// profile's keys set over the config files
 	cfg := DefaultConfig()
-
-	// Overlay configuration from files
-	for _, filepath := range pathConfigs {
-		// Skip missing files instead of failing
-		if bts, err = os.ReadFile(strings.ToLower(filepath)); err != nil {
-			if os.IsNotExist(err) {
-				// File doesn't exist, skip it
-				continue
-			}
-			return cfg, fmt.Errorf("read file: %w", err)
-		}
-
-		// Skip empty files
-		if len(strings.TrimSpace(string(bts))) == 0 {
-			continue
-		}
-
-		if err = yaml.Unmarshal(bts, &cfg); err != nil {
-			return cfg, fmt.Errorf("yaml unmarshal: %w", err)
-		}
+	if err := cfg.loadLayers(nil, overrides, pathConfigs); err != nil {
+		return cfg, err
 	}
-
-	// Overlay environment variables
-	if err = env.Parse(&cfg); err != nil {
-		return cfg, fmt.Errorf("env parse: %w", err)
+	theme, err := LookupTheme(cfg.Theme)
+	if err != nil {
+		return cfg, err
+	}
+	profile, err := cfg.profileOverrides()
+	if err != nil {
+		return cfg, err
+	}
+	cfg = DefaultConfig()
+	theme.apply(&cfg)
+	if err := cfg.loadLayers(profile, overrides, pathConfigs); err != nil {
+		return cfg, err
 	}
 
 	// Apply fallbacks for unset values
@@ -457,8 +899,25 @@ func NewConfig(pathConfigs ...string) (Config, error) {
 	// Set defaults for layout engine configuration
 	cfg.setLayoutEngineDefaults()
 
-	// Set algorithmic colors for predefined categories
-	cfg.setAlgorithmicColors()
+	// Color categories as declared or with the palette, then set the legend
+	// colors of the declared categories
+	if err := cfg.validateTheme(); err != nil {
+		return cfg, err
+	}
+	if err := cfg.validateCategories(); err != nil {
+		return cfg, err
+	}
+	if err := cfg.validateStrings(); err != nil {
+		return cfg, err
+	}
+	if err := cfg.Layout.TaskStyling.compileAbbreviations(); err != nil {
+		return cfg, err
+	}
+	if err := cfg.validateMonthOverrides(); err != nil {
+		return cfg, err
+	}
+	cfg.categoryStyles = newCategoryStyles(cfg.Categories, cfg.Layout.Palette)
+	cfg.setCategoryColors()
 
 	// Validate layout engine configuration
 	if err := cfg.validateLayoutEngineConfig(); err != nil {
@@ -475,6 +934,45 @@ func NewConfig(pathConfigs ...string) (Config, error) {
 	return cfg, nil
 }
 
+// loadLayers overlays the config files, each over the ones before it, then
+// the PLANNER_* variables, the output profile's keys, the PLANNERGEN_*
+// variables and the overrides
+func (cfg *Config) loadLayers(profile, overrides []string, pathConfigs []string) error {
+	for _, filepath := range pathConfigs {
+		// Fall back to the lower-case name, which older versions always read
+		bts, err := os.ReadFile(filepath)
+		if os.IsNotExist(err) && strings.ToLower(filepath) != filepath {
+			bts, err = os.ReadFile(strings.ToLower(filepath))
+		}
+		// Skip missing files instead of failing, so optional layers can be listed
+		if err != nil {
+			if os.IsNotExist(err) {
+				// File doesn't exist, skip it
+				continue
+			}
+			return fmt.Errorf("read file: %w", err)
+		}
+
+		// Skip empty files
+		if len(strings.TrimSpace(string(bts))) == 0 {
+			continue
+		}
+
+		if err = cfg.decodeConfigFile(filepath, bts); err != nil {
+			return err
+		}
+	}
+
+	// Overlay environment variables
+	if err := env.Parse(cfg); err != nil {
+		return fmt.Errorf("env parse: %w", err)
+	}
+	layers := make([]string, 0, len(profile)+len(overrides))
+	layers = append(layers, profile...)
+	layers = append(layers, EnvOverrides(os.Environ())...)
+	return cfg.ApplyOverrides(append(layers, overrides...))
+}
+
 // setDateRangeFromCSV reads the CSV file and sets the start and end years
 func (cfg *Config) setDateRangeFromCSV() error {
 	reader := NewReader(cfg.CSVFilePath)
@@ -562,19 +1060,6 @@ func (cfg *Config) setDateRangeFromCSV() error {
 	return nil
 }
 
-// setAlgorithmicColors sets the algorithmic colors for predefined categories
-func (cfg *Config) setAlgorithmicColors() {
-	cfg.Layout.AlgorithmicColors = AlgorithmicColors{
-		Proposal:     HexToRGB(GenerateCategoryColor("PROPOSAL")),
-		Laser:        HexToRGB(GenerateCategoryColor("LASER")),
-		Imaging:      HexToRGB(GenerateCategoryColor("IMAGING")),
-		Admin:        HexToRGB(GenerateCategoryColor("ADMIN")),
-		Dissertation: HexToRGB(GenerateCategoryColor("DISSERTATION")),
-		Research:     HexToRGB(GenerateCategoryColor("RESEARCH")),
-		Publication:  HexToRGB(GenerateCategoryColor("PUBLICATION")),
-	}
-}
-
 // getStringWithDefault returns the config value if it's not empty, otherwise the default
 func (c *Config) getStringWithDefault(value, defaultValue string) string {
 	if value != "" {
@@ -860,6 +1345,137 @@ func (c *Config) GetYear() int {
 	return time.Now().Year()
 }
 
+// UsesComposer reports whether any configured page renders with the named composer
+func (c *Config) UsesComposer(funcName string) bool {
+	for _, page := range c.Pages {
+		for _, block := range page.RenderBlocks {
+			if block.FuncName == funcName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetWeeklyStyle returns the weekly page style, falling back to hour rows
+func (c *Config) GetWeeklyStyle() string {
+	style := strings.ToLower(c.getTrimmedStringWithDefault(c.Weekly.Style, WeeklyStyleHours))
+	if style != WeeklyStyleFree {
+		return WeeklyStyleHours
+	}
+	return style
+}
+
+// GetWeeklyHours returns the validated [start, end) hour range for weekly pages
+func (c *Config) GetWeeklyHours() (int, int) {
+	start, end := c.Weekly.StartHour, c.Weekly.EndHour
+	if start < 0 || start > 23 || end <= start || end > 24 {
+		return Defaults.WeeklyStartHour, Defaults.WeeklyEndHour
+	}
+	return start, end
+}
+
+// UsesHourPrecision reports whether overlap detection compares times of day
+func (c *Config) UsesHourPrecision() bool {
+	return strings.EqualFold(strings.TrimSpace(c.Layout.Calendar.OverlapPrecision), OverlapPrecisionHour)
+}
+
+// IsSwimlaneLayout reports whether day cells use fixed per-category lanes
+func (c *Config) IsSwimlaneLayout() bool {
+	return strings.EqualFold(strings.TrimSpace(c.Layout.Calendar.LayoutMode), CalendarLayoutSwimlanes)
+}
+
+// UsesOverflowFootnotes reports whether collapsed tasks are listed as month
+// page footnotes instead of on the overflow appendix
+func (c *Config) UsesOverflowFootnotes() bool {
+	return strings.EqualFold(strings.TrimSpace(c.Layout.Calendar.OverflowMode), OverflowFootnotes)
+}
+
+// GetSwimlanes returns the ordered category lanes: configured lanes first,
+// then remaining task categories in order of first appearance. Tasks without
+// a category share a final unnamed lane.
+func (c *Config) GetSwimlanes() []string {
+	seen := make(map[string]bool)
+	var lanes []string
+	add := func(category string) {
+		key := strings.ToUpper(strings.TrimSpace(category))
+		if key == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		lanes = append(lanes, key)
+	}
+
+	for _, category := range c.Layout.Calendar.Swimlanes {
+		add(category)
+	}
+	uncategorized := false
+	for _, task := range c.VisibleTasks() {
+		if strings.TrimSpace(task.Category) == "" {
+			uncategorized = true
+		}
+		add(task.Category)
+	}
+	if uncategorized {
+		lanes = append(lanes, "")
+	}
+
+	return lanes
+}
+
+// UsesISOWeekNumbers reports whether week gutters show ISO-8601 week numbers
+func (c *Config) UsesISOWeekNumbers() bool {
+	return strings.EqualFold(strings.TrimSpace(c.Layout.Calendar.WeekNumbers), WeekNumbersISO)
+}
+
+// GetStackingSpacing returns the gap between stacked task bars with fallback to default
+func (c *Config) GetStackingSpacing() string {
+	return c.getTrimmedStringWithDefault(c.Layout.Stacking.Spacing, Defaults.StackingSpacing)
+}
+
+// GetGridPadding returns the text height kept clear of a filled month grid
+// with fallback to default
+func (c *Config) GetGridPadding() string {
+	return c.getTrimmedStringWithDefault(c.Layout.Grid.Padding, Defaults.GridPadding)
+}
+
+// GetSwimlaneHeight returns the height of one swimlane with fallback to default
+func (c *Config) GetSwimlaneHeight() string {
+	return c.getTrimmedStringWithDefault(c.Layout.Calendar.SwimlaneHeight, Defaults.SwimlaneHeight)
+}
+
+// GetMilestoneStyle returns the milestone style, falling back to box bars
+func (c *Config) GetMilestoneStyle() string {
+	switch style := strings.ToLower(strings.TrimSpace(c.Layout.TaskStyling.Milestone.Style)); style {
+	case MilestoneStyleDiamond, MilestoneStyleStar:
+		return style
+	}
+	return MilestoneStyleBox
+}
+
+// GetBorderStyle returns the border style of task bars, falling back to solid
+func (c *Config) GetBorderStyle() string {
+	return c.getTrimmedStringWithDefault(c.Layout.TaskStyling.BorderStyle, BorderStyleSolid)
+}
+
+// GetAsOfDate returns the date the planner is generated as of: today.as_of
+// when set and valid, otherwise the current date (UTC midnight)
+func (c *Config) GetAsOfDate() time.Time {
+	if asOf := strings.TrimSpace(c.Today.AsOf); asOf != "" {
+		if t, err := time.Parse(DateFormatISO, asOf); err == nil {
+			return t
+		}
+	}
+	return dayOf(time.Now().In(c.GetLocation()))
+}
+
+// GetLocale returns the configured locale, falling back to English
+func (c *Config) GetLocale() Locale {
+	loc := LookupLocale(c.getTrimmedStringWithDefault(c.Locale, DefaultLocaleCode))
+	loc.Strings = c.Strings
+	return loc
+}
+
 // HasCSVData returns true if a CSV file path is configured
 func (c *Config) HasCSVData() bool {
 	return strings.TrimSpace(c.CSVFilePath) != ""
@@ -267,10 +267,21 @@ func (cm *ConfigManager) registerEnvironmentVariables() {
 		},
 		"PLANNER_LOG_LEVEL": {
 			Key:          "PLANNER_LOG_LEVEL",
-			Description:  "Logging level (silent/info/debug)",
+			Description:  "Logging level (trace/debug/info/warn/error/silent)",
 			DefaultValue: "info",
 			Validator:    validateLogLevel,
 		},
+		"PLANNER_LOG_FORMAT": {
+			Key:          "PLANNER_LOG_FORMAT",
+			Description:  "Log output format (text/json)",
+			DefaultValue: "text",
+			Validator:    validateLogFormat,
+		},
+		"PLANNER_QUIET": {
+			Key:         "PLANNER_QUIET",
+			Description: "Hide progress output, keeping warnings and errors (true/false)",
+			Validator:   validateBoolean,
+		},
 		"DEV_TEMPLATES": {
 			Key:         "DEV_TEMPLATES",
 			Description: "Use filesystem templates instead of embedded (development)",
@@ -360,8 +371,9 @@ func (cm *ConfigManager) applyDefaults(config *Config) {
 	// Apply layout engine defaults
 	config.setLayoutEngineDefaults()
 
-	// Set algorithmic colors
-	config.setAlgorithmicColors()
+	// Set the legend colors of the declared categories
+	config.categoryStyles = newCategoryStyles(config.Categories, config.Layout.Palette)
+	config.setCategoryColors()
 
 	// Set date range from CSV if available
 	if config.CSVFilePath != "" {
@@ -637,7 +649,7 @@ func validateBoolean(value string) error {
 }
 
 func validateLogLevel(value string) error {
-	validLevels := []string{"silent", "info", "debug"}
+	validLevels := []string{"trace", "debug", "info", "warn", "error", "fatal", "silent"}
 	for _, level := range validLevels {
 		if value == level {
 			return nil
@@ -645,3 +657,10 @@ func validateLogLevel(value string) error {
 	}
 	return fmt.Errorf("log level must be one of: %s", strings.Join(validLevels, ", "))
 }
+
+func validateLogFormat(value string) error {
+	if value != "text" && value != "json" {
+		return fmt.Errorf("log format must be text or json")
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package core
+
+import "testing"
+
+func TestStackingSpacing(t *testing.T) {
+	cfg := DefaultConfig()
+	if got := cfg.GetStackingSpacing(); got != "1mm" {
+		t.Errorf("default spacing = %s, want 1mm", got)
+	}
+	if err := cfg.ApplyOverrides([]string{"layout.stacking.spacing=0.5mm"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.GetStackingSpacing(); got != "0.5mm" {
+		t.Errorf("spacing = %s, want 0.5mm", got)
+	}
+}
@@ -0,0 +1,285 @@
+package core
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// CPMTask holds the Critical Path Method results for one task. Dates are
+// inclusive days, like task start and end dates.
+type CPMTask struct {
+	ID          string
+	EarlyStart  time.Time
+	EarlyFinish time.Time
+	LateStart   time.Time
+	LateFinish  time.Time
+	// TotalFloat is the number of days the task can slip without moving the
+	// end of the project
+	TotalFloat int
+}
+
+// Critical reports whether the task has no float
+func (t CPMTask) Critical() bool {
+	return t.TotalFloat <= 0
+}
+
+// CPMResult is the outcome of a Critical Path Method pass over a plan
+type CPMResult struct {
+	Start  time.Time // First day of the plan
+	Finish time.Time // Last day of the plan
+	Tasks  map[string]CPMTask
+}
+
+// CriticalCount returns the number of tasks without float
+func (r CPMResult) CriticalCount() int {
+	n := 0
+	for _, t := range r.Tasks {
+		if t.Critical() {
+			n++
+		}
+	}
+	return n
+}
+
+// IsCritical reports whether the task with the given ID has zero slack
+func (r CPMResult) IsCritical(id string) bool {
+	t, ok := r.Tasks[strings.TrimSpace(id)]
+	return ok && t.Critical()
+}
+
+// CriticalPath returns the IDs of the tasks without float in a Critical Path
+// Method pass, so that the highlighted bars agree with the slack report
+// whatever the dependency types and lags. Returns nil when no task has
+// dependencies or they form a cycle.
+func (c *Config) CriticalPath(tasks []Task) map[string]bool {
+	hasDeps := false
+	for _, t := range tasks {
+		if len(t.Dependencies) > 0 {
+			hasDeps = true
+			break
+		}
+	}
+	if !hasDeps {
+		return nil
+	}
+
+	cpm, err := c.ComputeCPM(tasks)
+	if err != nil {
+		return nil
+	}
+	critical := make(map[string]bool)
+	for id, t := range cpm.Tasks {
+		if t.Critical() {
+			critical[id] = true
+		}
+	}
+	return critical
+}
+
+// BySlack returns the tasks ordered from least to most slack, then by early start
+func (r CPMResult) BySlack() []CPMTask {
+	tasks := make([]CPMTask, 0, len(r.Tasks))
+	for _, t := range r.Tasks {
+		tasks = append(tasks, t)
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		if tasks[i].TotalFloat != tasks[j].TotalFloat {
+			return tasks[i].TotalFloat < tasks[j].TotalFloat
+		}
+		if !tasks[i].EarlyStart.Equal(tasks[j].EarlyStart) {
+			return tasks[i].EarlyStart.Before(tasks[j].EarlyStart)
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+	return tasks
+}
+
+// ComputeCPM runs the forward and backward passes of the Critical Path Method.
+// Durations come from each task's dates. The forward pass starts a task at
+// its planned start or after its latest predecessor plus any lag, whichever
+// is later; the backward pass finishes it before its earliest successor must
+// start. Days, lags and float are counted on the working-day calendar
+// ScheduleTasks uses, so the slack matches the scheduled dates; a nil config
+// counts every day. Tasks without dates or IDs are left out. Dependency
+// cycles are an error.
+func (c *Config) ComputeCPM(tasks []Task) (CPMResult, error) {
+	result := CPMResult{Tasks: make(map[string]CPMTask)}
+
+	order, err := topologicalOrder(tasks)
+	if err != nil {
+		return result, err
+	}
+
+	dated := func(t Task) bool {
+		return strings.TrimSpace(t.ID) != "" && !t.StartDate.IsZero() && !t.EndDate.IsZero()
+	}
+
+	index := make(map[string]int, len(tasks))
+	for i, t := range tasks {
+		if !dated(t) {
+			continue
+		}
+		index[strings.TrimSpace(t.ID)] = i
+		if result.Start.IsZero() || t.StartDate.Before(result.Start) {
+			result.Start = dayOf(t.StartDate)
+		}
+	}
+	if len(index) == 0 {
+		return result, nil
+	}
+
+	// Working-day offsets from the project start; finishes are exclusive
+	cal := newWorkCalendar(c, result.Start)
+	offset := cal.offset
+	duration := make([]int, len(tasks))
+	es := make([]int, len(tasks))
+	ef := make([]int, len(tasks))
+	successors := make([][]int, len(tasks))
+
+	projectFinish := 0
+	for _, i := range order {
+		t := tasks[i]
+		if !dated(t) {
+			continue
+		}
+		duration[i] = offset(t.EndDate.AddDate(0, 0, 1)) - offset(t.StartDate)
+		es[i] = offset(t.StartDate)
+		for _, dep := range t.Dependencies {
+			j, ok := index[strings.TrimSpace(dep)]
+			if !ok || j == i {
+				continue
+			}
+			successors[j] = append(successors[j], i)
+			if start := successorOffset(t.DepType(dep), es[j], ef[j], t.Lag(dep), duration[i]); start > es[i] {
+				es[i] = start
+			}
+		}
+		ef[i] = es[i] + duration[i]
+		if ef[i] > projectFinish {
+			projectFinish = ef[i]
+		}
+	}
+
+	ls := make([]int, len(tasks))
+	lf := make([]int, len(tasks))
+	for k := len(order) - 1; k >= 0; k-- {
+		i := order[k]
+		if !dated(tasks[i]) {
+			continue
+		}
+		lf[i] = projectFinish
+		for _, j := range successors[i] {
+			// The latest finish keeping j's latest start feasible
+			id := strings.TrimSpace(tasks[i].ID)
+			kind := tasks[j].DepType(id)
+			finish := ls[j] - tasks[j].Lag(id)
+			if kind.ToFinish() {
+				finish += duration[j]
+			}
+			if kind.FromStart() {
+				finish += duration[i]
+			}
+			if finish < lf[i] {
+				lf[i] = finish
+			}
+		}
+		ls[i] = lf[i] - duration[i]
+	}
+
+	day := cal.day
+	for id, i := range index {
+		result.Tasks[id] = CPMTask{
+			ID:          id,
+			EarlyStart:  day(es[i]),
+			EarlyFinish: day(ef[i] - 1),
+			LateStart:   day(ls[i]),
+			LateFinish:  day(lf[i] - 1),
+			TotalFloat:  ls[i] - es[i],
+		}
+	}
+	result.Finish = day(projectFinish - 1)
+
+	return result, nil
+}
+
+// workCalendar numbers the working days from a start day, for CPM passes
+// counted like the scheduler counts durations and lags
+type workCalendar struct {
+	start   time.Time
+	working func(time.Time) bool
+	days    []time.Time // Working days from start, in order, extended as needed
+}
+
+// newWorkCalendar returns the working days of c from start; every day works
+// for a nil config
+func newWorkCalendar(c *Config, start time.Time) *workCalendar {
+	working := func(time.Time) bool { return true }
+	if c != nil {
+		working = c.isWorkingDay
+	}
+	return &workCalendar{start: dayOf(start), working: working}
+}
+
+// extend numbers working days until the last one is on or after t, or a year
+// of days without work has passed
+func (w *workCalendar) extend(t time.Time) {
+	next := w.start
+	if n := len(w.days); n > 0 {
+		next = w.days[n-1].AddDate(0, 0, 1)
+	}
+	for idle := 0; (len(w.days) == 0 || w.days[len(w.days)-1].Before(t)) && idle < 366; {
+		if w.working(next) {
+			w.days = append(w.days, next)
+			idle = 0
+		} else {
+			idle++
+		}
+		next = next.AddDate(0, 0, 1)
+	}
+}
+
+// offset returns the number of working days from the start up to, but not
+// including, t
+func (w *workCalendar) offset(t time.Time) int {
+	t = dayOf(t)
+	if t.Before(w.start) {
+		return 0
+	}
+	w.extend(t)
+	return sort.Search(len(w.days), func(i int) bool { return !w.days[i].Before(t) })
+}
+
+// day returns the working day at offset n; offsets before the start count
+// back from it
+func (w *workCalendar) day(n int) time.Time {
+	if n < 0 {
+		t := w.start
+		for idle := 0; n < 0 && idle < 366; {
+			if t = t.AddDate(0, 0, -1); w.working(t) {
+				n++
+				idle = 0
+			} else {
+				idle++
+			}
+		}
+		return t
+	}
+	for len(w.days) <= n {
+		before := len(w.days)
+		last := w.start
+		if before > 0 {
+			last = w.days[before-1]
+		}
+		w.extend(last.AddDate(0, 0, 1))
+		if len(w.days) == before {
+			return last.AddDate(0, 0, n-before+1)
+		}
+	}
+	return w.days[n]
+}
+
+// dayOf truncates t to midnight UTC on the same calendar day
+func dayOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,71 @@
+package core
+
+import "testing"
+
+func TestComputeCPM(t *testing.T) {
+	// A (3d) feeds B (5d) and C (2d); D (1d) needs both
+	tasks := []Task{
+		{ID: "A", StartDate: date(t, "2026-03-01"), EndDate: date(t, "2026-03-03")},
+		{ID: "B", StartDate: date(t, "2026-03-04"), EndDate: date(t, "2026-03-08"), Dependencies: []string{"A"}},
+		{ID: "C", StartDate: date(t, "2026-03-04"), EndDate: date(t, "2026-03-05"), Dependencies: []string{"A"}},
+		{ID: "D", StartDate: date(t, "2026-03-09"), EndDate: date(t, "2026-03-09"), Dependencies: []string{"B", "C"}},
+	}
+
+	result, err := (&Config{}).ComputeCPM(tasks)
+	if err != nil {
+		t.Fatalf("ComputeCPM: %v", err)
+	}
+	if !result.Finish.Equal(date(t, "2026-03-09")) {
+		t.Errorf("expected finish 2026-03-09, got %s", result.Finish.Format("2006-01-02"))
+	}
+	if c := result.Tasks["C"]; c.TotalFloat != 3 || !c.LateStart.Equal(date(t, "2026-03-07")) {
+		t.Errorf("C: expected 3 days float from 2026-03-07, got %d from %s", c.TotalFloat, c.LateStart.Format("2006-01-02"))
+	}
+	if result.CriticalCount() != 3 {
+		t.Errorf("expected A, B and D critical, got %d critical tasks", result.CriticalCount())
+	}
+}
+
+func TestCriticalPathFollowsDependencyTypes(t *testing.T) {
+	tasks := []Task{
+		{ID: "A", StartDate: date(t, "2025-03-01"), EndDate: date(t, "2025-03-10")},
+		{ID: "B", StartDate: date(t, "2025-03-01"), EndDate: date(t, "2025-03-12")},
+		{ID: "C", StartDate: date(t, "2025-03-11"), EndDate: date(t, "2025-03-20"), Dependencies: []string{"A", "B"}, DepTypes: map[string]DependencyType{"B": StartToStart}},
+	}
+	critical := (&Config{}).CriticalPath(tasks)
+	// B finishes after A but only constrains the start of C
+	if !critical["A"] || critical["B"] || !critical["C"] {
+		t.Errorf("critical = %v, want A and C", critical)
+	}
+}
+
+func TestComputeCPMCountsLagsInWorkingDays(t *testing.T) {
+	cfg := &Config{WorkingDays: WorkingDaysConfig{Durations: true}}
+	// A ends on a Friday; B waits two working days, so starts on Wednesday
+	tasks := []Task{
+		{ID: "A", StartDate: date(t, "2026-03-02"), EndDate: date(t, "2026-03-06")},
+		{ID: "B", Duration: 3, Dependencies: []string{"A"}, Lags: map[string]int{"A": 2}},
+		{ID: "C", StartDate: date(t, "2026-03-02"), EndDate: date(t, "2026-03-13")},
+	}
+	if err := cfg.ScheduleTasks(tasks); err != nil {
+		t.Fatalf("ScheduleTasks: %v", err)
+	}
+	if !tasks[1].StartDate.Equal(date(t, "2026-03-11")) || !tasks[1].EndDate.Equal(date(t, "2026-03-13")) {
+		t.Fatalf("B scheduled %s to %s, want 2026-03-11 to 2026-03-13",
+			tasks[1].StartDate.Format(DateFormatISO), tasks[1].EndDate.Format(DateFormatISO))
+	}
+
+	result, err := cfg.ComputeCPM(tasks)
+	if err != nil {
+		t.Fatalf("ComputeCPM: %v", err)
+	}
+	for _, id := range []string{"A", "B"} {
+		if task := result.Tasks[id]; task.TotalFloat != 0 {
+			t.Errorf("%s: float %d, want 0 with the lag over the weekend", id, task.TotalFloat)
+		}
+	}
+	if b := result.Tasks["B"]; !b.EarlyStart.Equal(tasks[1].StartDate) || !b.LateStart.Equal(tasks[1].StartDate) {
+		t.Errorf("B: early start %s, late start %s, want the scheduled %s", b.EarlyStart.Format(DateFormatISO),
+			b.LateStart.Format(DateFormatISO), tasks[1].StartDate.Format(DateFormatISO))
+	}
+}
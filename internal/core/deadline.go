@@ -0,0 +1,44 @@
+package core
+
+import (
+	"fmt"
+	"time"
+)
+
+// MissesDeadline reports whether the task is scheduled to end after its
+// hard deadline
+func (t Task) MissesDeadline() bool {
+	return !t.Deadline.IsZero() && !t.EndDate.IsZero() && dayOf(t.EndDate).After(dayOf(t.Deadline))
+}
+
+// DeadlineMisses returns a warning for every task scheduled to end after its
+// deadline. Run it after scheduling so computed end dates are checked too.
+func DeadlineMisses(tasks []Task) []ValidationIssue {
+	var issues []ValidationIssue
+	for _, t := range tasks {
+		if !t.MissesDeadline() {
+			continue
+		}
+		late := int(dayOf(t.EndDate).Sub(dayOf(t.Deadline)).Hours() / 24)
+		issues = append(issues, ValidationIssue{
+			Type:  "deadline_missed",
+			Field: "Deadline",
+			Value: t.Deadline.Format("2006-01-02"),
+			Message: fmt.Sprintf("Task %s ends %s, %d day(s) after its deadline %s",
+				t.ID, t.EndDate.Format("2006-01-02"), late, t.Deadline.Format("2006-01-02")),
+		})
+	}
+	return issues
+}
+
+// DeadlinesOn returns the tasks whose deadline falls on the given day
+func (c *Config) DeadlinesOn(day time.Time) []Task {
+	day = dayOf(day)
+	var due []Task
+	for _, t := range c.VisibleTasks() {
+		if !t.Deadline.IsZero() && dayOf(t.Deadline).Equal(day) {
+			due = append(due, t)
+		}
+	}
+	return due
+}
@@ -39,7 +39,7 @@
 // DefaultConfig returns a Config with sensible defaults
 // This serves as the baseline configuration before loading from YAML or environment
 func DefaultConfig() Config {
-	return Config{
+	cfg := Config{
 		Debug: Debug{
 			ShowFrame: false,
 			ShowLinks: false,
@@ -51,8 +51,37 @@ func DefaultConfig() Config {
 		ClearTopRightCorner: false,
 		AMPMTime:            false,
 		AddLastHalfHour:     false,
+		Locale:              DefaultLocaleCode,
 		OutputDir:           "generated",
+		BuildFooter:         true,
+		Categories:          DefaultCategories(),
 		Layout:              DefaultLayout(),
+		Weekly:              DefaultWeeklyView(),
+		Daily:               DefaultDailyView(),
+		Gantt:               GanttView{RowsPerPage: 40},
+		MonthStrip:          MonthStripView{RowHeight: "6mm"},
+		Cover:               CoverView{Title: "PhD Dissertation Planner", LogoWidth: "4cm"},
+	}
+	themes[ThemeClassic].apply(&cfg)
+	return cfg
+}
+
+// DefaultDailyView returns daily agenda page defaults
+func DefaultDailyView() DailyView {
+	return DailyView{
+		SkipEmpty:  true,
+		NotesLines: 12,
+	}
+}
+
+// DefaultWeeklyView returns weekly page defaults
+func DefaultWeeklyView() WeeklyView {
+	return WeeklyView{
+		Style:      WeeklyStyleHours,
+		StartHour:  Defaults.WeeklyStartHour,
+		EndHour:    Defaults.WeeklyEndHour,
+		Interleave: false,
+		SkipEmpty:  true,
 	}
 }
 
@@ -96,8 +125,9 @@ func DefaultLaTeX() LaTeX {
 // DefaultDocument returns Document configuration defaults
 func DefaultDocument() Document {
 	return Document{
-		FontSize:  "10pt",
-		ParIndent: "0pt",
+		FontSize:   "10pt",
+		ParIndent:  "0pt",
+		HeaderSize: "\\Large",
 	}
 }
 
@@ -134,6 +164,7 @@ func DefaultLayoutCalendarLayout() LayoutCalendarLayout {
 func DefaultTaskStyling() TaskStyling {
 	return TaskStyling{
 		FontSize:          "\\footnotesize",
+		BodyFontSize:      "\\footnotesize",
 		BarHeight:         "1.5mm",
 		BorderWidth:       "0.5pt",
 		ShowObjectives:    true,
@@ -184,6 +215,9 @@ type ConfigDefaults struct {
 	TaskCellMargin        string
 	TaskCellSpacing       string
 	HeaderAngleSizeOffset string
+	SwimlaneHeight        string
+	StackingSpacing       string
+	GridPadding           string
 
 	// Typography defaults
 	HyphenPenalty    int
@@ -195,6 +229,10 @@ type ConfigDefaults struct {
 
 	// Task color defaults
 	DefaultTaskColor string
+
+	// Weekly page defaults
+	WeeklyStartHour int
+	WeeklyEndHour   int
 }
 
 // Defaults provides easy access to default values
@@ -205,6 +243,9 @@ type ConfigDefaults struct {
 	TaskCellMargin:        "1mm",
 	TaskCellSpacing:       "0.5mm",
 	HeaderAngleSizeOffset: "2pt",
+	SwimlaneHeight:        "2.6em",
+	StackingSpacing:       "1mm",
+	GridPadding:           "4.5cm",
 
 	// Typography
 	HyphenPenalty:    50,
@@ -216,4 +257,8 @@ type ConfigDefaults struct {
 
 	// Task colors
 	DefaultTaskColor: "224,50,212", // Magenta fallback
+
+	// Weekly pages
+	WeeklyStartHour: 8,
+	WeeklyEndHour:   18,
 }
@@ -0,0 +1,124 @@
+package core
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DependencyType is the relationship between a task and a predecessor
+type DependencyType string
+
+// Dependency types, written as a prefix of the predecessor ID such as "SS:T4".
+// Without a prefix a dependency is finish-to-start.
+const (
+	FinishToStart  DependencyType = "FS" // Starts after the predecessor finishes
+	StartToStart   DependencyType = "SS" // Starts once the predecessor has started
+	FinishToFinish DependencyType = "FF" // Finishes once the predecessor has finished
+	StartToFinish  DependencyType = "SF" // Finishes once the predecessor has started
+)
+
+// dependencyTypePattern matches the optional type prefix of a dependency entry
+var dependencyTypePattern = regexp.MustCompile(`^(?i)(FS|SS|FF|SF)\s*:\s*(.+)$`)
+
+// dependencyLagPattern matches a dependency with a lag or lead, e.g. "T12+3d"
+// or "T12-2d". The day suffix is required so IDs containing a dash still work.
+var dependencyLagPattern = regexp.MustCompile(`^(.+?)\s*([+-])\s*(\d+)\s*[dD]$`)
+
+// ParseDependency splits a dependency entry into the predecessor ID and the
+// lag in days. A positive lag waits after the predecessor finishes; a negative
+// one (a lead) lets the task start before it finishes.
+func ParseDependency(spec string) (id string, lag int) {
+	spec = strings.TrimSpace(spec)
+	m := dependencyLagPattern.FindStringSubmatch(spec)
+	if m == nil {
+		return spec, 0
+	}
+	lag, _ = strconv.Atoi(m[3])
+	if m[2] == "-" {
+		lag = -lag
+	}
+	return strings.TrimSpace(m[1]), lag
+}
+
+// ParseDependencyType splits the optional type prefix off a dependency entry,
+// e.g. "SS:T4+2d" gives StartToStart and "T4+2d"
+func ParseDependencyType(spec string) (DependencyType, string) {
+	spec = strings.TrimSpace(spec)
+	m := dependencyTypePattern.FindStringSubmatch(spec)
+	if m == nil {
+		return FinishToStart, spec
+	}
+	return DependencyType(strings.ToUpper(m[1])), strings.TrimSpace(m[2])
+}
+
+// DepType returns the relationship between the task and the given predecessor
+func (t Task) DepType(dep string) DependencyType {
+	if kind, ok := t.DepTypes[strings.TrimSpace(dep)]; ok {
+		return kind
+	}
+	return FinishToStart
+}
+
+// FromStart reports whether the dependency is measured from the predecessor's start
+func (k DependencyType) FromStart() bool {
+	return k == StartToStart || k == StartToFinish
+}
+
+// ToFinish reports whether the dependency constrains the task's finish
+func (k DependencyType) ToFinish() bool {
+	return k == FinishToFinish || k == StartToFinish
+}
+
+// successorStart returns the earliest start of a task lasting length days
+// (inclusive) that depends on a predecessor running predStart..predEnd
+func successorStart(kind DependencyType, predStart, predEnd time.Time, lag, length int) time.Time {
+	from := dayOf(predEnd).AddDate(0, 0, 1)
+	if kind.FromStart() {
+		from = dayOf(predStart)
+	}
+	from = from.AddDate(0, 0, lag)
+	if kind.ToFinish() {
+		from = from.AddDate(0, 0, -length)
+	}
+	return from
+}
+
+// successorOffset is successorStart for day offsets with exclusive finishes
+func successorOffset(kind DependencyType, predStart, predFinish, lag, duration int) int {
+	from := predFinish
+	if kind.FromStart() {
+		from = predStart
+	}
+	from += lag
+	if kind.ToFinish() {
+		from -= duration
+	}
+	return from
+}
+
+// Lag returns the lag in days between the task and the given predecessor
+func (t Task) Lag(dep string) int {
+	return t.Lags[strings.TrimSpace(dep)]
+}
+
+// FormatLag renders a lag as "+3d" or "-2d", or "" for none
+func FormatLag(days int) string {
+	switch {
+	case days > 0:
+		return "+" + strconv.Itoa(days) + "d"
+	case days < 0:
+		return strconv.Itoa(days) + "d"
+	}
+	return ""
+}
+
+// DependencyLabel renders a dependency's type and lag for arrow labels, such
+// as "SS+2d"; finish-to-start dependencies show only their lag
+func DependencyLabel(kind DependencyType, lag int) string {
+	if kind == "" || kind == FinishToStart {
+		return FormatLag(lag)
+	}
+	return string(kind) + FormatLag(lag)
+}
@@ -0,0 +1,116 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FieldChange is one column that differs between two versions of a task
+type FieldChange struct {
+	Field    string
+	Old, New string
+}
+
+// String renders the change as "Status: planned -> completed"
+func (f FieldChange) String() string {
+	return fmt.Sprintf("%s: %s -> %s", f.Field, quoteEmpty(f.Old), quoteEmpty(f.New))
+}
+
+// quoteEmpty shows empty values as "" so that cleared fields stay visible
+func quoteEmpty(s string) string {
+	if s == "" {
+		return `""`
+	}
+	return s
+}
+
+// TaskDiff is a task that was added, removed or modified between two
+// versions of a plan
+type TaskDiff struct {
+	TaskShift
+	Phase   string        // Phase in the new version, or the old one for removed tasks
+	Changes []FieldChange // Columns other than the dates that changed
+}
+
+// Modified reports whether a task present in both versions changed
+func (d TaskDiff) Modified() bool {
+	return !d.Added && !d.Removed
+}
+
+// DiffTasks compares two versions of a plan, matching tasks like
+// CompareSchedules, and returns every added, removed or modified task in
+// old-plan order followed by the added ones
+func DiffTasks(old, new []Task) []TaskDiff {
+	byKey := make(map[string]Task, len(new))
+	for _, t := range new {
+		byKey[taskKey(t)] = t
+	}
+
+	var diffs []TaskDiff
+	seen := make(map[string]bool, len(old))
+	for _, o := range old {
+		seen[taskKey(o)] = true
+		shift := TaskShift{
+			ID: o.ID, Name: o.Name, IsMilestone: o.IsMilestone,
+			CurrentStart: dayOf(o.StartDate), CurrentEnd: dayOf(o.EndDate),
+		}
+		n, ok := byKey[taskKey(o)]
+		if !ok {
+			shift.Removed = true
+			diffs = append(diffs, TaskDiff{TaskShift: shift, Phase: o.Phase})
+			continue
+		}
+		shift.Name, shift.IsMilestone = n.Name, n.IsMilestone
+		shift.ProposedStart, shift.ProposedEnd = dayOf(n.StartDate), dayOf(n.EndDate)
+		diff := TaskDiff{TaskShift: shift, Phase: n.Phase, Changes: fieldChanges(o, n)}
+		if shift.Changed() || len(diff.Changes) > 0 {
+			diffs = append(diffs, diff)
+		}
+	}
+	for _, n := range new {
+		if seen[taskKey(n)] {
+			continue
+		}
+		diffs = append(diffs, TaskDiff{
+			TaskShift: TaskShift{
+				ID: n.ID, Name: n.Name, IsMilestone: n.IsMilestone,
+				ProposedStart: dayOf(n.StartDate), ProposedEnd: dayOf(n.EndDate), Added: true,
+			},
+			Phase: n.Phase,
+		})
+	}
+	return diffs
+}
+
+// fieldChanges lists the columns other than Start and End Date that differ
+func fieldChanges(o, n Task) []FieldChange {
+	deadline := func(t Task) string {
+		if t.Deadline.IsZero() {
+			return ""
+		}
+		return t.Deadline.Format(DateFormatISO)
+	}
+	fields := []FieldChange{
+		{"Task", o.Name, n.Name},
+		{"Phase", o.Phase, n.Phase},
+		{"Category", o.Category, n.Category},
+		{"Status", o.Status, n.Status},
+		{"Assignee", o.Assignee, n.Assignee},
+		{"Priority", o.Priority, n.Priority},
+		{"Dependencies", strings.Join(o.Dependencies, ","), strings.Join(n.Dependencies, ",")},
+		{"Milestone", strconv.FormatBool(o.IsMilestone), strconv.FormatBool(n.IsMilestone)},
+		{"Progress", strconv.Itoa(o.Progress), strconv.Itoa(n.Progress)},
+		{"Duration", strconv.Itoa(o.Duration), strconv.Itoa(n.Duration)},
+		{"Deadline", deadline(o), deadline(n)},
+		{"Objective", o.Description, n.Description},
+	}
+
+	var changes []FieldChange
+	for _, f := range fields {
+		if strings.TrimSpace(f.Old) != strings.TrimSpace(f.New) {
+			changes = append(changes, f)
+		}
+	}
+	return changes
+}
@@ -0,0 +1,158 @@
+package core
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// EarnedValue holds schedule-based earned value figures for a group of tasks.
+// Values are measured in task-days rather than money: a task's budget is its
+// planned duration, so an index of 1.0 means work is on plan.
+type EarnedValue struct {
+	Name  string
+	Tasks int
+	// BAC is the budget at completion: the planned days of all tasks
+	BAC float64
+	// PV is the planned value: the days of work scheduled to be done by the as-of date
+	PV float64
+	// EV is the earned value: the days of work actually done, from progress
+	EV float64
+	// AC is the actual cost: the days spent, from the Actual Days column
+	AC float64
+	// Actuals counts the tasks reporting actual days; without any there is
+	// no cost to measure
+	Actuals int
+}
+
+// SPI returns the schedule performance index (EV/PV); 1 when nothing is planned yet
+func (e EarnedValue) SPI() float64 {
+	if e.PV == 0 {
+		return 1
+	}
+	return e.EV / e.PV
+}
+
+// HasCost reports whether any task reports actual days, so that AC, CPI
+// and CV mean something
+func (e EarnedValue) HasCost() bool {
+	return e.Actuals > 0
+}
+
+// CPI returns the cost performance index (EV/AC); 1 when nothing was spent
+func (e EarnedValue) CPI() float64 {
+	if e.AC == 0 {
+		return 1
+	}
+	return e.EV / e.AC
+}
+
+// SV returns the schedule variance in task-days (EV-PV)
+func (e EarnedValue) SV() float64 {
+	return e.EV - e.PV
+}
+
+// CV returns the cost variance in task-days (EV-AC)
+func (e EarnedValue) CV() float64 {
+	return e.EV - e.AC
+}
+
+// PercentComplete returns the share of the budget earned, 0-100
+func (e EarnedValue) PercentComplete() float64 {
+	if e.BAC == 0 {
+		return 0
+	}
+	return e.EV / e.BAC * 100
+}
+
+// add accumulates one task's figures
+func (e *EarnedValue) add(o EarnedValue) {
+	e.Tasks += o.Tasks
+	e.BAC += o.BAC
+	e.PV += o.PV
+	e.EV += o.EV
+	e.AC += o.AC
+	e.Actuals += o.Actuals
+}
+
+// EarnedValueReport is the plan's earned value overall and per category
+type EarnedValueReport struct {
+	AsOf       time.Time
+	Overall    EarnedValue
+	Categories []EarnedValue // Sorted by category name
+}
+
+// ComputeEarnedValue measures the plan against the as-of date. Planned value
+// follows the baseline dates when a task has them and the current dates
+// otherwise; earned value comes from the Progress column (completed tasks
+// count as done); actual cost is the days spent from the Actual Days column,
+// for the tasks that give them. Milestones and undated tasks carry no budget.
+func (c *Config) ComputeEarnedValue(tasks []Task) EarnedValueReport {
+	asOf := dayOf(c.GetAsOfDate())
+	report := EarnedValueReport{AsOf: asOf, Overall: EarnedValue{Name: "Overall"}}
+
+	byCategory := make(map[string]*EarnedValue)
+	for _, t := range tasks {
+		if t.IsMilestone || t.StartDate.IsZero() || t.EndDate.IsZero() {
+			continue
+		}
+
+		planStart, planEnd := t.StartDate, t.EndDate
+		if !t.BaselineStart.IsZero() && !t.BaselineEnd.IsZero() {
+			planStart, planEnd = t.BaselineStart, t.BaselineEnd
+		}
+		budget := float64(c.elapsedDays(planStart, planEnd, planEnd))
+		if budget == 0 {
+			continue
+		}
+
+		progress := t.Completion()
+
+		ev := EarnedValue{
+			Tasks: 1,
+			BAC:   budget,
+			PV:    float64(c.elapsedDays(planStart, planEnd, asOf)),
+			EV:    budget * progress,
+		}
+		if t.ActualDays > 0 {
+			ev.AC = t.ActualDays
+			ev.Actuals = 1
+		}
+
+		category := strings.TrimSpace(t.Category)
+		if byCategory[category] == nil {
+			byCategory[category] = &EarnedValue{Name: category}
+		}
+		byCategory[category].add(ev)
+		report.Overall.add(ev)
+	}
+
+	for _, e := range byCategory {
+		report.Categories = append(report.Categories, *e)
+	}
+	sort.Slice(report.Categories, func(i, j int) bool {
+		return report.Categories[i].Name < report.Categories[j].Name
+	})
+	return report
+}
+
+// Completion returns the share of the task done, 0-1, from the Progress
+// column; completed tasks count as done
+func (t Task) Completion() float64 {
+	if strings.EqualFold(strings.TrimSpace(t.Status), "completed") {
+		return 1
+	}
+	return float64(t.Progress) / 100
+}
+
+// elapsedDays counts the duration days of start..end that fall on or before asOf
+func (c *Config) elapsedDays(start, end, asOf time.Time) int {
+	start, end, asOf = dayOf(start), dayOf(end), dayOf(asOf)
+	if asOf.Before(start) {
+		return 0
+	}
+	if asOf.Before(end) {
+		end = asOf
+	}
+	return c.GetDurationBetween(start, end.AddDate(0, 0, 1))
+}
@@ -0,0 +1,24 @@
+package core
+
+import "testing"
+
+func TestEarnedValueActualCost(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Today.AsOf = "2025-03-10"
+	tasks := []Task{
+		{ID: "A", Category: "Lab", StartDate: date(t, "2025-03-01"), EndDate: date(t, "2025-03-20"), Progress: 50},
+	}
+	report := cfg.ComputeEarnedValue(tasks)
+	if report.Overall.HasCost() || report.Overall.AC != 0 {
+		t.Errorf("AC = %.1f without actual days, want no cost", report.Overall.AC)
+	}
+
+	tasks[0].ActualDays = 8
+	report = cfg.ComputeEarnedValue(tasks)
+	if !report.Overall.HasCost() || report.Overall.AC != 8 {
+		t.Errorf("AC = %.1f, want the 8 actual days", report.Overall.AC)
+	}
+	if cpi := report.Overall.CPI(); cpi != report.Overall.EV/8 {
+		t.Errorf("CPI = %.2f, want EV/AC", cpi)
+	}
+}
@@ -3,7 +3,7 @@
 // This module defines structured error types for different failure scenarios:
 //
 // ConfigError: Configuration loading/validation failures
-//   - Includes file path, field name, and descriptive message
+//   - Includes file path, line number, field name, and descriptive message
 //   - Use NewConfigError() to create with proper wrapping
 //
 // FileError: File operation failures
@@ -48,6 +48,7 @@
 // ConfigError represents an error that occurred during configuration loading or validation
 type ConfigError struct {
 	File    string // Configuration file that caused the error
+	Line    int    // Line in the file (if known)
 	Field   string // Specific field that caused the error (optional)
 	Message string // Human-readable error message
 	Err     error  // Underlying error (optional)
@@ -55,6 +56,9 @@ type ConfigError struct {
 
 func (e *ConfigError) Error() string {
 	msg := fmt.Sprintf("config error in %s", e.File)
+	if e.Line > 0 {
+		msg += fmt.Sprintf(" at line %d", e.Line)
+	}
 	if e.Field != "" {
 		msg += fmt.Sprintf(", field '%s'", e.Field)
 	}
@@ -0,0 +1,75 @@
+package core
+
+import "strings"
+
+// TaskFilter selects the tasks to lay out by category and tag, to produce
+// focused planners from one dataset. Categories match by prefix and tags
+// exactly, both ignoring case.
+type TaskFilter struct {
+	IncludeCategories []string `yaml:"include_categories"`
+	ExcludeCategories []string `yaml:"exclude_categories"`
+	IncludeTags       []string `yaml:"include_tags"`
+	ExcludeTags       []string `yaml:"exclude_tags"`
+}
+
+// IsEmpty reports whether the filter keeps every task
+func (f TaskFilter) IsEmpty() bool {
+	return len(f.IncludeCategories) == 0 && len(f.ExcludeCategories) == 0 &&
+		len(f.IncludeTags) == 0 && len(f.ExcludeTags) == 0
+}
+
+// Keep reports whether a task passes the filter: it must match an included
+// category and an included tag when those lists are set, and no excluded
+// category or tag
+func (f TaskFilter) Keep(t Task) bool {
+	if len(f.IncludeCategories) > 0 && !matchesCategory(t.Category, f.IncludeCategories) {
+		return false
+	}
+	if len(f.IncludeTags) > 0 && !hasTag(t.Tags, f.IncludeTags) {
+		return false
+	}
+	return !matchesCategory(t.Category, f.ExcludeCategories) && !hasTag(t.Tags, f.ExcludeTags)
+}
+
+// Apply returns the tasks that pass the filter
+func (f TaskFilter) Apply(tasks []Task) []Task {
+	if f.IsEmpty() {
+		return tasks
+	}
+	kept := make([]Task, 0, len(tasks))
+	for _, t := range tasks {
+		if f.Keep(t) {
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
+
+// CategoryKey normalizes a category name for matching: upper case with
+// single spaces
+func CategoryKey(category string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(category), " "))
+}
+
+// matchesCategory reports whether category starts with any of the prefixes
+func matchesCategory(category string, prefixes []string) bool {
+	key := CategoryKey(category)
+	for _, prefix := range prefixes {
+		if p := CategoryKey(prefix); p != "" && strings.HasPrefix(key, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTag reports whether any of tags is one of wanted
+func hasTag(tags, wanted []string) bool {
+	for _, tag := range tags {
+		for _, w := range wanted {
+			if strings.EqualFold(strings.TrimSpace(tag), strings.TrimSpace(w)) {
+				return true
+			}
+		}
+	}
+	return false
+}
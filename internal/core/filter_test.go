@@ -0,0 +1,38 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTaskFilter(t *testing.T) {
+	tasks := []Task{
+		{ID: "W1", Category: "Dissertation  Writing", Tags: []string{"core"}},
+		{ID: "W2", Category: "Dissertation Writing", Tags: []string{"optional"}},
+		{ID: "P1", Category: "Publications", Tags: []string{"Core"}},
+		{ID: "A1", Category: "Aim 1"},
+	}
+	ids := func(f TaskFilter) string {
+		var kept []string
+		for _, task := range f.Apply(tasks) {
+			kept = append(kept, task.ID)
+		}
+		return strings.Join(kept, ",")
+	}
+
+	cases := []struct {
+		filter TaskFilter
+		want   string
+	}{
+		{TaskFilter{}, "W1,W2,P1,A1"},
+		{TaskFilter{IncludeCategories: []string{"dissertation", "PUB"}}, "W1,W2,P1"},
+		{TaskFilter{ExcludeCategories: []string{"aim"}}, "W1,W2,P1"},
+		{TaskFilter{IncludeTags: []string{"CORE"}}, "W1,P1"},
+		{TaskFilter{IncludeCategories: []string{"dissertation writing"}, ExcludeTags: []string{"optional"}}, "W1"},
+	}
+	for _, c := range cases {
+		if got := ids(c.filter); got != c.want {
+			t.Errorf("%+v kept %s, want %s", c.filter, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,350 @@
+package core
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Holiday is a named non-working day
+type Holiday struct {
+	Date time.Time
+	Name string
+}
+
+// WorkingDaysConfig controls how weekends affect bars and durations
+type WorkingDaysConfig struct {
+	// Bars splits multi-day task bars into weekday segments
+	Bars bool `yaml:"bars"`
+	// Durations counts business days (Monday to Friday) in task durations
+	Durations bool `yaml:"durations"`
+}
+
+// IsWeekend reports whether t falls on a Saturday or Sunday
+func IsWeekend(t time.Time) bool {
+	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+}
+
+// HolidayConfig configures where holidays come from and how they are used
+type HolidayConfig struct {
+	// File is a CSV (date,name[,end_date]) or ICS calendar of holidays
+	File string `yaml:"file" env:"PLANNER_HOLIDAYS_FILE"`
+	// Country selects a built-in holiday set (currently "US")
+	Country string `yaml:"country" env:"PLANNER_HOLIDAYS_COUNTRY"`
+	// ExcludeFromDuration skips holidays when counting task durations
+	ExcludeFromDuration bool `yaml:"exclude_from_duration"`
+}
+
+// holidayKey indexes holidays by calendar date
+func holidayKey(t time.Time) string {
+	return t.Format(DateFormatISO)
+}
+
+// LoadHolidays reads the configured holiday sources for the given years and
+// indexes them by date. Holidays from the file take precedence over built-ins
+// falling on the same day.
+func (c *Config) LoadHolidays(years []int) error {
+	var holidays []Holiday
+
+	if country := strings.TrimSpace(c.Holidays.Country); country != "" {
+		builtin, err := BuiltinHolidays(country, years)
+		if err != nil {
+			return NewConfigError("", "holidays.country", err.Error(), err)
+		}
+		holidays = append(holidays, builtin...)
+	}
+
+	if path := strings.TrimSpace(c.Holidays.File); path != "" {
+		loc, err := c.LoadLocation()
+		if err != nil {
+			return err
+		}
+		loaded, err := LoadHolidayFile(path, loc)
+		if err != nil {
+			return err
+		}
+		holidays = append(holidays, loaded...)
+	}
+
+	c.holidays = make(map[string]string, len(holidays))
+	for _, h := range holidays {
+		c.holidays[holidayKey(h.Date)] = h.Name
+	}
+	return nil
+}
+
+// HolidayOn returns the name of the holiday on t, if any
+func (c *Config) HolidayOn(t time.Time) (string, bool) {
+	name, ok := c.holidays[holidayKey(t)]
+	return name, ok
+}
+
+// GetHolidays returns the loaded holidays sorted by date
+func (c *Config) GetHolidays() []Holiday {
+	holidays := make([]Holiday, 0, len(c.holidays))
+	for key, name := range c.holidays {
+		date, err := time.Parse(DateFormatISO, key)
+		if err != nil {
+			continue
+		}
+		holidays = append(holidays, Holiday{Date: date, Name: name})
+	}
+	sort.Slice(holidays, func(i, j int) bool { return holidays[i].Date.Before(holidays[j].Date) })
+	return holidays
+}
+
+// GetDuration returns the task length in days as shown in the task index,
+// counting only business days when working_days.durations is set and leaving
+// out holidays when holidays.exclude_from_duration is set
+func (c *Config) GetDuration(task Task) int {
+	return c.GetDurationBetween(task.StartDate, task.EndDate)
+}
+
+// GetDurationBetween counts the days from start to end the same way as GetDuration
+func (c *Config) GetDurationBetween(start, end time.Time) int {
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+
+	days := 0
+	for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+		if c.WorkingDays.Durations && IsWeekend(d) {
+			continue
+		}
+		if c.Holidays.ExcludeFromDuration {
+			if _, ok := c.HolidayOn(d); ok {
+				continue
+			}
+		}
+		days++
+	}
+
+	if days < 1 {
+		days = 1
+	}
+	return days
+}
+
+// LoadHolidayFile reads holidays from a CSV or ICS file, chosen by extension.
+// Timed ICS events fall on their day in loc.
+func LoadHolidayFile(path string, loc *time.Location) ([]Holiday, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, NewFileError(path, "open holidays", err)
+	}
+	defer f.Close()
+
+	var holidays []Holiday
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ics", ".ical":
+		holidays, err = parseHolidayICS(f, loc)
+	default:
+		holidays, err = parseHolidayCSV(f)
+	}
+	if err != nil {
+		return nil, NewFileError(path, "parse holidays", err)
+	}
+	return holidays, nil
+}
+
+// parseHolidayCSV reads "date,name[,end_date]" rows; a header row is skipped
+func parseHolidayCSV(r io.Reader) ([]Holiday, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var holidays []Holiday
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 2 {
+			return nil, fmt.Errorf("row %d: expected date and name", row)
+		}
+
+		start, err := time.Parse(DateFormatISO, strings.TrimSpace(record[0]))
+		if err != nil {
+			if row == 1 {
+				continue // Header
+			}
+			return nil, fmt.Errorf("row %d: invalid date %q", row, record[0])
+		}
+		end := start
+		if len(record) > 2 && strings.TrimSpace(record[2]) != "" {
+			if end, err = time.Parse(DateFormatISO, strings.TrimSpace(record[2])); err != nil {
+				return nil, fmt.Errorf("row %d: invalid end date %q", row, record[2])
+			}
+		}
+
+		holidays = appendHolidayRange(holidays, start, end, strings.TrimSpace(record[1]))
+	}
+	return holidays, nil
+}
+
+// parseHolidayICS reads VEVENTs from an iCalendar file. DTEND is exclusive
+// for all-day events as in the iCalendar spec; timed events cover the days
+// they touch in loc.
+func parseHolidayICS(r io.Reader, loc *time.Location) ([]Holiday, error) {
+	// Unfold continuation lines first
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	var (
+		holidays   []Holiday
+		inEvent    bool
+		start, end time.Time
+		endIsDate  bool
+		summary    string
+	)
+	for _, line := range lines {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		prop, params, _ := strings.Cut(name, ";")
+
+		switch strings.ToUpper(prop) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent, start, end, endIsDate, summary = true, time.Time{}, time.Time{}, false, ""
+			}
+		case "DTSTART":
+			start, _ = parseICSDate(value, params, loc)
+		case "DTEND":
+			end, endIsDate = parseICSDate(value, params, loc)
+		case "SUMMARY":
+			summary = strings.NewReplacer(`\,`, ",", `\;`, ";", `\n`, " ", `\\`, `\`).Replace(value)
+		case "END":
+			if !inEvent || !strings.EqualFold(value, "VEVENT") {
+				continue
+			}
+			inEvent = false
+			if start.IsZero() {
+				continue
+			}
+			last := start
+			if end.After(start) {
+				last = end
+				if endIsDate {
+					last = end.AddDate(0, 0, -1)
+				}
+			}
+			holidays = appendHolidayRange(holidays, start, last, summary)
+		}
+	}
+	return holidays, nil
+}
+
+// parseICSDate parses DATE or DATE-TIME values to the calendar day, and
+// reports whether the value was a DATE. UTC ("Z") and TZID times are placed
+// on their day in loc; floating times keep the day as written.
+func parseICSDate(value, params string, loc *time.Location) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if len(value) < 8 {
+		return time.Time{}, false
+	}
+	if len(value) == 8 {
+		t, err := time.Parse("20060102", value)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return t, true
+	}
+
+	zone := time.UTC
+	if strings.HasSuffix(value, "Z") {
+		value = strings.TrimSuffix(value, "Z")
+	} else if tzid := icsParam(params, "TZID"); tzid != "" {
+		if z, err := time.LoadLocation(tzid); err == nil {
+			zone = z
+		}
+	} else {
+		zone = loc // Floating time
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, zone)
+	if err != nil {
+		t, err = time.Parse("20060102", value[:8])
+		if err != nil {
+			return time.Time{}, false
+		}
+		return t, false
+	}
+	return dayOf(wallClock(t, loc)), false
+}
+
+// icsParam returns the value of a property parameter such as TZID from
+// "TZID=Europe/Berlin;VALUE=DATE-TIME"
+func icsParam(params, name string) string {
+	for _, p := range strings.Split(params, ";") {
+		if key, value, ok := strings.Cut(p, "="); ok && strings.EqualFold(key, name) {
+			return strings.Trim(value, `"`)
+		}
+	}
+	return ""
+}
+
+// appendHolidayRange adds one holiday entry per day from start to end inclusive
+func appendHolidayRange(holidays []Holiday, start, end time.Time, name string) []Holiday {
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		holidays = append(holidays, Holiday{Date: d, Name: name})
+	}
+	return holidays
+}
+
+// BuiltinHolidays returns the public holidays of a supported country for the
+// given years. Only fixed and weekday-rule holidays are included; observed
+// dates for holidays falling on weekends are not shifted.
+func BuiltinHolidays(country string, years []int) ([]Holiday, error) {
+	switch strings.ToUpper(strings.TrimSpace(country)) {
+	case "US":
+		var holidays []Holiday
+		for _, y := range years {
+			holidays = append(holidays,
+				Holiday{time.Date(y, time.January, 1, 0, 0, 0, 0, time.UTC), "New Year's Day"},
+				Holiday{nthWeekday(y, time.January, time.Monday, 3), "Martin Luther King Jr. Day"},
+				Holiday{nthWeekday(y, time.February, time.Monday, 3), "Presidents' Day"},
+				Holiday{nthWeekday(y, time.May, time.Monday, -1), "Memorial Day"},
+				Holiday{time.Date(y, time.June, 19, 0, 0, 0, 0, time.UTC), "Juneteenth"},
+				Holiday{time.Date(y, time.July, 4, 0, 0, 0, 0, time.UTC), "Independence Day"},
+				Holiday{nthWeekday(y, time.September, time.Monday, 1), "Labor Day"},
+				Holiday{nthWeekday(y, time.October, time.Monday, 2), "Columbus Day"},
+				Holiday{time.Date(y, time.November, 11, 0, 0, 0, 0, time.UTC), "Veterans Day"},
+				Holiday{nthWeekday(y, time.November, time.Thursday, 4), "Thanksgiving"},
+				Holiday{time.Date(y, time.December, 25, 0, 0, 0, 0, time.UTC), "Christmas Day"},
+			)
+		}
+		return holidays, nil
+	default:
+		return nil, fmt.Errorf("no built-in holidays for country %q (supported: US); use holidays.file instead", country)
+	}
+}
+
+// nthWeekday returns the nth weekday of a month; n = -1 selects the last one
+func nthWeekday(year int, month time.Month, wd time.Weekday, n int) time.Time {
+	if n < 0 {
+		last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+		return last.AddDate(0, 0, -int((7+last.Weekday()-wd)%7))
+	}
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	return first.AddDate(0, 0, int((7+wd-first.Weekday())%7)+7*(n-1))
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseHolidayICS(t *testing.T) {
+	ics := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;VALUE=DATE:20261223\r\n" +
+		"DTEND;VALUE=DATE:20261226\r\n" +
+		"SUMMARY:Winter\\, Closure\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART:20260704T000000Z\r\n" +
+		"SUMMARY:Independence\r\n" +
+		"  Day\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	holidays, err := parseHolidayICS(strings.NewReader(ics), time.UTC)
+	if err != nil {
+		t.Fatalf("parseHolidayICS: %v", err)
+	}
+	if len(holidays) != 4 {
+		t.Fatalf("expected 4 holiday days, got %d", len(holidays))
+	}
+	if holidays[0].Name != "Winter, Closure" || holidays[2].Date.Day() != 25 {
+		t.Errorf("unexpected closure entries: %+v", holidays[:3])
+	}
+	if holidays[3].Name != "Independence Day" {
+		t.Errorf("folded summary not joined: %q", holidays[3].Name)
+	}
+}
+
+func TestParseICSDateZones(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Skip("no time zone database")
+	}
+
+	tests := []struct {
+		value, params string
+		loc           *time.Location
+		day           int
+	}{
+		{"20260704T000000Z", "", chicago, 3},
+		{"20260704T010000", "TZID=Europe/Berlin", time.UTC, 3},
+		{"20260704T010000", "", chicago, 4},
+		{"20260704", "VALUE=DATE", chicago, 4},
+	}
+	for _, tt := range tests {
+		got, _ := parseICSDate(tt.value, tt.params, tt.loc)
+		if got.Day() != tt.day {
+			t.Errorf("parseICSDate(%q, %q) = %s, want day %d", tt.value, tt.params, got.Format(DateFormatISO), tt.day)
+		}
+	}
+}
+
+func TestGetDurationExcludesHolidays(t *testing.T) {
+	cfg := Config{Holidays: HolidayConfig{ExcludeFromDuration: true}}
+	cfg.holidays = map[string]string{"2026-11-26": "Thanksgiving"}
+
+	task := Task{
+		StartDate: time.Date(2026, 11, 23, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 11, 30, 0, 0, 0, 0, time.UTC),
+	}
+	if got := cfg.GetDuration(task); got != 6 {
+		t.Errorf("expected 6 days, got %d", got)
+	}
+	if got := nthWeekday(2026, time.November, time.Thursday, 4); got.Day() != 26 {
+		t.Errorf("expected Thanksgiving on the 26th, got %v", got)
+	}
+}
+
+func TestGetDurationBusinessDays(t *testing.T) {
+	cfg := Config{WorkingDays: WorkingDaysConfig{Durations: true}}
+
+	// Friday to the following Wednesday: Fri, Mon, Tue
+	task := Task{
+		StartDate: time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC),
+	}
+	if got := cfg.GetDuration(task); got != 3 {
+		t.Errorf("expected 3 business days, got %d", got)
+	}
+}
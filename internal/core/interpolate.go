@@ -0,0 +1,112 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// expandEnv replaces ${NAME} and ${NAME:-default} in the values of a config
+// file with environment variables, as returned by lookup, so one file works
+// across machines and CI. Comments are left alone, $${ is a literal ${, and
+// an unset variable without a default is an error giving its line.
+func expandEnv(file string, bts []byte, lookup func(string) (string, bool)) ([]byte, error) {
+	if !strings.Contains(string(bts), "${") {
+		return bts, nil
+	}
+
+	lines := strings.Split(string(bts), "\n")
+	for i, line := range lines {
+		cut := commentStart(line)
+		value, err := expandLine(line[:cut], lookup)
+		if err != nil {
+			configErr := NewConfigError(file, "", err.Error(), nil)
+			configErr.Line = i + 1
+			return nil, configErr
+		}
+		lines[i] = value + line[cut:]
+	}
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+// expandLine replaces the variables of one line
+func expandLine(s string, lookup func(string) (string, bool)) (string, error) {
+	var sb strings.Builder
+	for {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			sb.WriteString(s)
+			return sb.String(), nil
+		}
+		// $${ escapes a literal ${
+		if start > 0 && s[start-1] == '$' {
+			sb.WriteString(s[:start-1] + "${")
+			s = s[start+2:]
+			continue
+		}
+		end := strings.IndexByte(s[start:], '}')
+		if end < 0 {
+			sb.WriteString(s)
+			return sb.String(), nil
+		}
+		end += start
+
+		sb.WriteString(s[:start])
+		name, fallback, hasFallback := strings.Cut(s[start+2:end], ":-")
+		switch value, ok := lookup(name); {
+		case !isVariableName(name):
+			// Not a variable, such as the LaTeX ${}^\circ$
+			sb.WriteString(s[start : end+1])
+		case ok:
+			sb.WriteString(value)
+		case hasFallback:
+			sb.WriteString(fallback)
+		default:
+			return "", fmt.Errorf("environment variable %s is not set; use ${%s:-default} to make it optional", name, name)
+		}
+		s = s[end+1:]
+	}
+}
+
+// isVariableName reports whether s is a shell variable name
+func isVariableName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case r == '_', r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// commentStart returns where the comment of a YAML line starts, or the end
+// of the line without one. A # starts a comment at the start of the line or
+// after a space, outside a quoted scalar.
+func commentStart(line string) int {
+	var quote byte
+	last := byte(0) // the last non-space byte outside quotes
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case (c == '"' || c == '\'') && (last == 0 || strings.IndexByte(":-[{,?", last) >= 0):
+			// Quotes open a scalar only where one starts
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return i
+		}
+		if quote == 0 && c != ' ' && c != '\t' {
+			last = c
+		}
+	}
+	return len(line)
+}
@@ -0,0 +1,36 @@
+package core
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestExpandEnv(t *testing.T) {
+	env := map[string]string{"HOME_DIR": "/home/ana", "YEAR": "2027"}
+	lookup := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+
+	src := "year: ${YEAR}\n" +
+		"logo: ${HOME_DIR}/logo.pdf # from ${UNSET}\n" +
+		"title: \"${TITLE:-PhD Plan} # kept\"\n" +
+		"cost: $${YEAR} ${}^\\circ$\n"
+	want := "year: 2027\n" +
+		"logo: /home/ana/logo.pdf # from ${UNSET}\n" +
+		"title: \"PhD Plan # kept\"\n" +
+		"cost: ${YEAR} ${}^\\circ$\n"
+	got, err := expandEnv("config.yaml", []byte(src), lookup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("expanded =\n%s\nwant\n%s", got, want)
+	}
+
+	_, err = expandEnv("config.yaml", []byte("a: 1\ntoken: ${API_TOKEN}\n"), lookup)
+	var configErr *ConfigError
+	if !errors.As(err, &configErr) || configErr.Line != 2 {
+		t.Errorf("unset variable: err = %v, want a config error at line 2", err)
+	}
+}
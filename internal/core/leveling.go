@@ -0,0 +1,223 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LevelingConfig configures the optional workload leveling pass
+type LevelingConfig struct {
+	// Enabled shifts lower-priority tasks until every assignee's weekly load fits
+	Enabled bool `yaml:"enabled"`
+	// MaxTasksPerWeek is the number of tasks one assignee may have active in a week
+	MaxTasksPerWeek int `yaml:"max_tasks_per_week"`
+}
+
+// defaultMaxTasksPerWeek is used when leveling is enabled without a limit
+const defaultMaxTasksPerWeek = 3
+
+// maxLevelingShift bounds how far a task may be pushed looking for capacity
+const maxLevelingShift = 3 * 365
+
+// LevelingMove records a task the leveling pass moved
+type LevelingMove struct {
+	TaskID   string
+	Name     string
+	Assignee string
+	From     time.Time
+	To       time.Time
+	Reason   string
+}
+
+// String describes the move for console reports
+func (m LevelingMove) String() string {
+	return fmt.Sprintf("%s %s: %s -> %s (%s)", m.TaskID, m.Name,
+		m.From.Format("2006-01-02"), m.To.Format("2006-01-02"), m.Reason)
+}
+
+// GetMaxTasksPerWeek returns the weekly workload limit per assignee
+func (c *Config) GetMaxTasksPerWeek() int {
+	if c.Leveling.MaxTasksPerWeek > 0 {
+		return c.Leveling.MaxTasksPerWeek
+	}
+	return defaultMaxTasksPerWeek
+}
+
+// LevelingMoves returns the moves made by the last LevelTasks call
+func (c *Config) LevelingMoves() []LevelingMove {
+	return c.levelingMoves
+}
+
+// LevelTasks shifts tasks later so that no assignee has more than the
+// configured number of tasks active in any week. Tasks are placed one at a
+// time once their dependencies are placed, highest priority first, so
+// lower-priority work yields. Milestones, completed tasks and unassigned tasks
+// are never moved to free capacity, but every task follows a pushed
+// predecessor. A moved task keeps its length.
+func (c *Config) LevelTasks(tasks []Task) error {
+	c.levelingMoves = nil
+	if !c.Leveling.Enabled {
+		return nil
+	}
+
+	order, err := topologicalOrder(tasks)
+	if err != nil {
+		return err
+	}
+	rank := make([]int, len(tasks))
+	for pos, i := range order {
+		rank[i] = pos
+	}
+
+	index := make(map[string]int, len(tasks))
+	for i := range tasks {
+		if id := strings.TrimSpace(tasks[i].ID); id != "" {
+			index[id] = i
+		}
+	}
+
+	// Ready list: a task becomes eligible once all its predecessors are placed
+	waiting := make([]int, len(tasks))
+	dependents := make([][]int, len(tasks))
+	for i := range tasks {
+		for _, dep := range tasks[i].Dependencies {
+			if j, ok := index[strings.TrimSpace(dep)]; ok && j != i {
+				waiting[i]++
+				dependents[j] = append(dependents[j], i)
+			}
+		}
+	}
+	var ready []int
+	for i, n := range waiting {
+		if n == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	limit := c.GetMaxTasksPerWeek()
+	load := make(map[string]map[time.Time]int)
+	moved := make([]bool, len(tasks))
+
+	for len(ready) > 0 {
+		sort.Slice(ready, func(a, b int) bool {
+			ta, tb := tasks[ready[a]], tasks[ready[b]]
+			if pa, pb := priorityRank(ta.Priority), priorityRank(tb.Priority); pa != pb {
+				return pa < pb
+			}
+			if !ta.StartDate.Equal(tb.StartDate) {
+				return ta.StartDate.Before(tb.StartDate)
+			}
+			return rank[ready[a]] < rank[ready[b]]
+		})
+		i := ready[0]
+		ready = ready[1:]
+		task := &tasks[i]
+
+		if !task.StartDate.IsZero() && !task.EndDate.IsZero() {
+			from := task.StartDate
+			length := task.EndDate.Sub(task.StartDate)
+
+			// Stay after predecessors that were pushed
+			earliest := task.StartDate
+			for _, dep := range task.Dependencies {
+				j, ok := index[strings.TrimSpace(dep)]
+				if !ok || !moved[j] {
+					continue
+				}
+				days := daysBetween(task.StartDate, task.EndDate) + 1
+				if next := successorStart(task.DepType(dep), tasks[j].StartDate, tasks[j].EndDate, task.Lag(dep), days); next.After(earliest) {
+					earliest = next
+				}
+			}
+
+			reason := ""
+			if earliest.After(task.StartDate) {
+				reason = "predecessor moved"
+			}
+			if levelable(*task) {
+				person := strings.ToLower(strings.TrimSpace(task.Assignee))
+				if load[person] == nil {
+					load[person] = make(map[time.Time]int)
+				}
+				start := earliest
+				for shift := 0; shift < maxLevelingShift && !fitsWeeks(load[person], start, start.Add(length), limit); shift++ {
+					start = start.AddDate(0, 0, 1)
+					reason = fmt.Sprintf("%s over %d tasks/week", task.Assignee, limit)
+				}
+				earliest = start
+			}
+
+			if earliest.After(task.StartDate) {
+				task.StartDate = earliest
+				task.EndDate = earliest.Add(length)
+				moved[i] = true
+				c.levelingMoves = append(c.levelingMoves, LevelingMove{
+					TaskID: task.ID, Name: task.Name, Assignee: task.Assignee,
+					From: from, To: task.StartDate, Reason: reason,
+				})
+			}
+			if !task.IsMilestone && strings.TrimSpace(task.Assignee) != "" {
+				person := strings.ToLower(strings.TrimSpace(task.Assignee))
+				if load[person] == nil {
+					load[person] = make(map[time.Time]int)
+				}
+				for _, week := range weeksSpanned(task.StartDate, task.EndDate) {
+					load[person][week]++
+				}
+			}
+		}
+
+		for _, j := range dependents[i] {
+			if waiting[j]--; waiting[j] == 0 {
+				ready = append(ready, j)
+			}
+		}
+	}
+
+	return nil
+}
+
+// levelable reports whether leveling may move the task
+func levelable(t Task) bool {
+	return !t.IsMilestone && strings.TrimSpace(t.Assignee) != "" &&
+		!strings.EqualFold(strings.TrimSpace(t.Status), "completed")
+}
+
+// priorityRank orders priorities from most to least important
+func priorityRank(priority string) int {
+	switch strings.ToLower(strings.TrimSpace(priority)) {
+	case "critical":
+		return 0
+	case "high":
+		return 1
+	case "low":
+		return 3
+	default:
+		return 2
+	}
+}
+
+// fitsWeeks reports whether one more task over start..end stays within limit
+func fitsWeeks(load map[time.Time]int, start, end time.Time, limit int) bool {
+	for _, week := range weeksSpanned(start, end) {
+		if load[week]+1 > limit {
+			return false
+		}
+	}
+	return true
+}
+
+// weeksSpanned returns the Monday of every week the range touches
+func weeksSpanned(start, end time.Time) []time.Time {
+	monday := func(t time.Time) time.Time {
+		d := dayOf(t)
+		return d.AddDate(0, 0, -((int(d.Weekday()) + 6) % 7))
+	}
+	var weeks []time.Time
+	for w := monday(start); !w.After(dayOf(end)); w = w.AddDate(0, 0, 7) {
+		weeks = append(weeks, w)
+	}
+	return weeks
+}
@@ -0,0 +1,31 @@
+package core
+
+import "testing"
+
+func TestLevelTasks(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Leveling = LevelingConfig{Enabled: true, MaxTasksPerWeek: 1}
+	tasks := []Task{
+		{ID: "low", Assignee: "Ana", Priority: "Low", StartDate: date(t, "2026-03-02"), EndDate: date(t, "2026-03-04")},
+		{ID: "high", Assignee: "Ana", Priority: "High", StartDate: date(t, "2026-03-03"), EndDate: date(t, "2026-03-05")},
+		{ID: "next", Assignee: "Bo", StartDate: date(t, "2026-03-05"), EndDate: date(t, "2026-03-06"), Dependencies: []string{"low"}},
+	}
+
+	if err := cfg.LevelTasks(tasks); err != nil {
+		t.Fatalf("LevelTasks: %v", err)
+	}
+
+	// The low-priority task moves to the next week; its dependent follows
+	if !tasks[1].StartDate.Equal(date(t, "2026-03-03")) {
+		t.Errorf("high-priority task should keep its dates, starts %s", tasks[1].StartDate.Format("2006-01-02"))
+	}
+	if !tasks[0].StartDate.Equal(date(t, "2026-03-09")) || !tasks[0].EndDate.Equal(date(t, "2026-03-11")) {
+		t.Errorf("low-priority task moved to %s..%s", tasks[0].StartDate.Format("2006-01-02"), tasks[0].EndDate.Format("2006-01-02"))
+	}
+	if !tasks[2].StartDate.Equal(date(t, "2026-03-12")) {
+		t.Errorf("dependent should follow its predecessor, starts %s", tasks[2].StartDate.Format("2006-01-02"))
+	}
+	if len(cfg.LevelingMoves()) != 2 {
+		t.Errorf("expected 2 moves, got %v", cfg.LevelingMoves())
+	}
+}
@@ -0,0 +1,200 @@
+// Package core - Locale provides month names, weekday names, and date formats
+// for rendering the calendar in languages other than English.
+//
+// Locales are selected with the top-level `locale` config key (for example
+// `locale: de-DE`). Unknown codes fall back to the language part of the code
+// ("de-AT" -> "de") and finally to English.
+//
+// Example usage:
+//
+//	loc := core.LookupLocale("de-DE")
+//	loc.MonthName(time.March)                    // "März"
+//	loc.FormatDate(t, loc.ShortDateFormat)       // "14. Okt"
+package core
+
+import (
+	"strings"
+	"time"
+)
+
+// DefaultLocaleCode is used when no locale is configured
+const DefaultLocaleCode = "en-US"
+
+// Locale holds the localized names and date layouts used when rendering
+type Locale struct {
+	Code          string
+	Months        [12]string
+	ShortMonths   [12]string
+	Weekdays      [7]string // Indexed by time.Weekday (Sunday = 0)
+	ShortWeekdays [7]string // Indexed by time.Weekday (Sunday = 0)
+
+	// Go reference layouts; month and weekday names are substituted from the tables above
+	ShortDateFormat string
+	LongDateFormat  string
+
+	// Strings overrides the text of the generated pages by key (see Text)
+	Strings map[string]string
+}
+
+// locales maps language codes to their locale definitions
+var locales = map[string]Locale{
+	"en": {
+		Code:            "en-US",
+		Months:          [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		ShortMonths:     [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		Weekdays:        [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		ShortWeekdays:   [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		ShortDateFormat: "Jan 02",
+		LongDateFormat:  "January 2, 2006",
+	},
+	"de": {
+		Code:            "de-DE",
+		Months:          [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		ShortMonths:     [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+		Weekdays:        [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		ShortWeekdays:   [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		ShortDateFormat: "02. Jan",
+		LongDateFormat:  "2. January 2006",
+	},
+	"fr": {
+		Code:            "fr-FR",
+		Months:          [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		ShortMonths:     [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		Weekdays:        [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		ShortWeekdays:   [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		ShortDateFormat: "02 Jan",
+		LongDateFormat:  "2 January 2006",
+	},
+	"es": {
+		Code:            "es-ES",
+		Months:          [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		ShortMonths:     [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		Weekdays:        [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		ShortWeekdays:   [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		ShortDateFormat: "02 Jan",
+		LongDateFormat:  "2 de January de 2006",
+	},
+	"it": {
+		Code:            "it-IT",
+		Months:          [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		ShortMonths:     [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		Weekdays:        [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		ShortWeekdays:   [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+		ShortDateFormat: "02 Jan",
+		LongDateFormat:  "2 January 2006",
+	},
+	"nl": {
+		Code:            "nl-NL",
+		Months:          [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		ShortMonths:     [12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+		Weekdays:        [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		ShortWeekdays:   [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+		ShortDateFormat: "02 Jan",
+		LongDateFormat:  "2 January 2006",
+	},
+	"pt": {
+		Code:            "pt-BR",
+		Months:          [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		ShortMonths:     [12]string{"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"},
+		Weekdays:        [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+		ShortWeekdays:   [7]string{"dom", "seg", "ter", "qua", "qui", "sex", "sáb"},
+		ShortDateFormat: "02 Jan",
+		LongDateFormat:  "2 de January de 2006",
+	},
+}
+
+// normalizeLocale reduces a code such as "de-DE", "pt_BR" or " FR " to the
+// language of a built-in locale, reporting false and English when the
+// language is not supported
+func normalizeLocale(code string) (string, bool) {
+	lang := strings.ToLower(strings.TrimSpace(code))
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	if _, ok := locales[lang]; ok {
+		return lang, true
+	}
+	return "en", false
+}
+
+// LookupLocale returns the locale for a code such as "de-DE" or "fr".
+// Falls back to English when the language is not supported.
+func LookupLocale(code string) Locale {
+	lang, _ := normalizeLocale(code)
+	return locales[lang]
+}
+
+// IsSupportedLocale reports whether a locale code maps to a built-in locale
+func IsSupportedLocale(code string) bool {
+	_, ok := normalizeLocale(code)
+	return ok
+}
+
+// MonthName returns the full localized month name
+func (l Locale) MonthName(m time.Month) string {
+	return l.Months[m-1]
+}
+
+// ShortMonthName returns the abbreviated localized month name
+func (l Locale) ShortMonthName(m time.Month) string {
+	return l.ShortMonths[m-1]
+}
+
+// WeekdayName returns the full localized weekday name
+func (l Locale) WeekdayName(d time.Weekday) string {
+	return l.Weekdays[d]
+}
+
+// ShortWeekdayName returns the abbreviated localized weekday name
+func (l Locale) ShortWeekdayName(d time.Weekday) string {
+	return l.ShortWeekdays[d]
+}
+
+// FormatDate formats t using a Go reference layout, substituting localized
+// names for the January/Jan/Monday/Mon tokens
+func (l Locale) FormatDate(t time.Time, layout string) string {
+	var sb strings.Builder
+	chunkStart := 0
+
+	flush := func(end int) {
+		if end > chunkStart {
+			sb.WriteString(t.Format(layout[chunkStart:end]))
+		}
+	}
+
+	for i := 0; i < len(layout); {
+		var name string
+		var n int
+		switch {
+		case strings.HasPrefix(layout[i:], "January"):
+			name, n = l.MonthName(t.Month()), len("January")
+		case strings.HasPrefix(layout[i:], "Jan"):
+			name, n = l.ShortMonthName(t.Month()), len("Jan")
+		case strings.HasPrefix(layout[i:], "Monday"):
+			name, n = l.WeekdayName(t.Weekday()), len("Monday")
+		case strings.HasPrefix(layout[i:], "Mon"):
+			name, n = l.ShortWeekdayName(t.Weekday()), len("Mon")
+		default:
+			i++
+			continue
+		}
+
+		flush(i)
+		sb.WriteString(name)
+		i += n
+		chunkStart = i
+	}
+	flush(len(layout))
+
+	return sb.String()
+}
+
+// ShortDate formats t with the locale's short day/month layout (used in task tables)
+func (l Locale) ShortDate(t time.Time) string {
+	return l.FormatDate(t, l.ShortDateFormat)
+}
+
+// LongDate formats t with the locale's long date layout
+func (l Locale) LongDate(t time.Time) string {
+	return l.FormatDate(t, l.LongDateFormat)
+}
@@ -0,0 +1,29 @@
+package core
+
+import "testing"
+
+func TestNormalizeLocale(t *testing.T) {
+	tests := []struct {
+		code      string
+		want      string
+		supported bool
+	}{
+		{"de", "de", true},
+		{"de-DE", "de", true},
+		{" PT_br ", "pt", true},
+		{"", "en", false},
+		{"xx-YY", "en", false},
+	}
+	for _, tt := range tests {
+		got, ok := normalizeLocale(tt.code)
+		if got != tt.want || ok != tt.supported {
+			t.Errorf("normalizeLocale(%q) = %q, %v, want %q, %v", tt.code, got, ok, tt.want, tt.supported)
+		}
+		if IsSupportedLocale(tt.code) != tt.supported {
+			t.Errorf("IsSupportedLocale(%q) = %v, want %v", tt.code, !tt.supported, tt.supported)
+		}
+		if loc := LookupLocale(tt.code); loc.Code != locales[tt.want].Code {
+			t.Errorf("LookupLocale(%q) = %s, want %s", tt.code, loc.Code, locales[tt.want].Code)
+		}
+	}
+}
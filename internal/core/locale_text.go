@@ -0,0 +1,240 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// textEN is the English text of the generated pages by key. The other
+// languages translate it in localeText, and the strings config key overrides
+// any entry; a key missing from both falls back to English.
+var textEN = map[string]string{
+	// Task bars and day cells
+	"continued": "continued",
+	"continues": "continues",
+	"more":      "more", // "+3 more"
+	"week":      "Week",
+	"milestone": "Milestone",
+	"completed": "Completed",
+
+	// Legends
+	"legend":             "Legend",
+	"legend_intro":       "The categories of the plan, as colored on the task bars. Days add up the length of each task.",
+	"category":           "Category",
+	"tasks":              "Tasks",
+	"days":               "Days",
+	"task_categories":    "Task Categories",
+	"default_categories": "Default Categories",
+	"phases":             "Phases",
+	"at_a_glance":        "%d at a Glance",
+
+	// Task index
+	"task_index":    "Task Index",
+	"data_sources":  "Data Sources",
+	"files":         "Files",
+	"csv_merged":    "%d CSV file(s) merged",
+	"total_tasks":   "Total Tasks",
+	"task_count":    "%d tasks",
+	"milestones":    "%d milestones",
+	"completed_n":   "%d completed",
+	"critical_path": "Critical Path",
+	"no_float":      "%d tasks without float",
+	"zero_slack":    "zero slack",
+	"complete_pct":  "%d%% complete",
+	"plan_finishes": "plan finishes %s",
+	"start":         "Start",
+	"end":           "End",
+	"late_start":    "Late start",
+	"float":         "Float",
+
+	// Daily agenda
+	"starts_today": "Starts today",
+	"due_today":    "Due today",
+	"no_tasks":     "No scheduled tasks.",
+	"notes":        "Notes",
+
+	// Other pages
+	"more_tasks":       "More Tasks by Day",
+	"project_timeline": "Project Timeline",
+	"roadmap":          "Roadmap",
+
+	// Reports
+	"schedule_risk":       "Schedule Risk",
+	"risk_intro":          "Monte Carlo simulation over %d trials. Each date is reached by the given share of trials.",
+	"planned":             "Planned",
+	"on_time":             "On time",
+	"finish_distribution": "%s finish distribution",
+	"earned_value":        "Earned Value",
+	"earned_value_intro":  "As of %s, in task-days. PV is the work planned by this date, EV the work done according to progress, and AC the days spent from the Actual Days column, left blank without it. SPI = EV/PV and CPI = EV/AC; values below 1 are behind plan.",
+	"overall":             "Overall",
+	"done":                "Done",
+}
+
+// localeText translates textEN, by language
+var localeText = map[string]map[string]string{
+	"de": {
+		"continued": "fortgesetzt", "continues": "weiter", "more": "weitere", "week": "Woche",
+		"milestone": "Meilenstein", "completed": "Erledigt",
+		"legend": "Legende", "legend_intro": "Die Kategorien des Plans in den Farben der Aufgabenbalken. Die Tage summieren die Dauer jeder Aufgabe.",
+		"category": "Kategorie", "tasks": "Aufgaben", "days": "Tage",
+		"task_categories": "Aufgabenkategorien", "default_categories": "Standardkategorien", "phases": "Phasen",
+		"at_a_glance": "%d im Überblick",
+		"task_index":  "Aufgabenverzeichnis", "data_sources": "Datenquellen", "files": "Dateien",
+		"csv_merged": "%d CSV-Datei(en) zusammengeführt", "total_tasks": "Aufgaben gesamt", "task_count": "%d Aufgaben",
+		"milestones": "%d Meilensteine", "completed_n": "%d erledigt", "critical_path": "Kritischer Pfad",
+		"zero_slack": "keine Reserve", "complete_pct": "%d %% erledigt", "no_float": "%d Aufgaben ohne Puffer", "plan_finishes": "Planende %s",
+		"start": "Beginn", "end": "Ende", "late_start": "Spätester Beginn", "float": "Puffer",
+		"starts_today": "Beginnt heute", "due_today": "Heute fällig", "no_tasks": "Keine geplanten Aufgaben.", "notes": "Notizen",
+		"more_tasks": "Weitere Aufgaben nach Tag", "project_timeline": "Projektzeitplan", "roadmap": "Roadmap",
+		"schedule_risk": "Terminrisiko", "risk_intro": "Monte-Carlo-Simulation mit %d Durchläufen. Jedes Datum wird im angegebenen Anteil der Durchläufe erreicht.",
+		"planned": "Geplant", "on_time": "Pünktlich", "finish_distribution": "Verteilung des Endtermins von %s",
+		"earned_value": "Fertigstellungswert", "earned_value_intro": "Stand %s, in Aufgabentagen. PV ist die bis zu diesem Datum geplante Arbeit, EV die laut Fortschritt erledigte Arbeit und AC die aufgewendeten Tage aus der Spalte Actual Days, ohne sie leer. SPI = EV/PV und CPI = EV/AC; Werte unter 1 liegen hinter dem Plan.",
+		"overall": "Gesamt", "done": "Erledigt",
+	},
+	"fr": {
+		"continued": "suite", "continues": "à suivre", "more": "de plus", "week": "Semaine",
+		"milestone": "Jalon", "completed": "Terminé",
+		"legend": "Légende", "legend_intro": "Les catégories du plan, dans les couleurs des barres de tâches. Les jours additionnent la durée de chaque tâche.",
+		"category": "Catégorie", "tasks": "Tâches", "days": "Jours",
+		"task_categories": "Catégories de tâches", "default_categories": "Catégories par défaut", "phases": "Phases",
+		"at_a_glance": "%d en un coup d'œil",
+		"task_index":  "Index des tâches", "data_sources": "Sources de données", "files": "Fichiers",
+		"csv_merged": "%d fichier(s) CSV fusionné(s)", "total_tasks": "Total des tâches", "task_count": "%d tâches",
+		"milestones": "%d jalons", "completed_n": "%d terminées", "critical_path": "Chemin critique",
+		"zero_slack": "aucune marge", "complete_pct": "%d %% terminé", "no_float": "%d tâches sans marge", "plan_finishes": "fin du plan le %s",
+		"start": "Début", "end": "Fin", "late_start": "Début au plus tard", "float": "Marge",
+		"starts_today": "Commence aujourd'hui", "due_today": "Échéance aujourd'hui", "no_tasks": "Aucune tâche prévue.", "notes": "Notes",
+		"more_tasks": "Autres tâches par jour", "project_timeline": "Calendrier du projet", "roadmap": "Feuille de route",
+		"schedule_risk": "Risque de planning", "risk_intro": "Simulation de Monte-Carlo sur %d tirages. Chaque date est atteinte dans la part indiquée des tirages.",
+		"planned": "Prévu", "on_time": "À temps", "finish_distribution": "Distribution de la fin de %s",
+		"earned_value": "Valeur acquise", "earned_value_intro": "Au %s, en jours-tâches. PV est le travail prévu à cette date, EV le travail fait selon l'avancement et AC les jours passés selon la colonne Actual Days, vide sans elle. SPI = EV/PV et CPI = EV/AC ; en dessous de 1, le plan est en retard.",
+		"overall": "Total", "done": "Fait",
+	},
+	"es": {
+		"continued": "continuación", "continues": "continúa", "more": "más", "week": "Semana",
+		"milestone": "Hito", "completed": "Completada",
+		"legend": "Leyenda", "legend_intro": "Las categorías del plan, con los colores de las barras de tareas. Los días suman la duración de cada tarea.",
+		"category": "Categoría", "tasks": "Tareas", "days": "Días",
+		"task_categories": "Categorías de tareas", "default_categories": "Categorías predeterminadas", "phases": "Fases",
+		"at_a_glance": "%d de un vistazo",
+		"task_index":  "Índice de tareas", "data_sources": "Fuentes de datos", "files": "Archivos",
+		"csv_merged": "%d archivo(s) CSV combinado(s)", "total_tasks": "Total de tareas", "task_count": "%d tareas",
+		"milestones": "%d hitos", "completed_n": "%d completadas", "critical_path": "Ruta crítica",
+		"zero_slack": "sin holgura", "complete_pct": "%d %% completado", "no_float": "%d tareas sin holgura", "plan_finishes": "el plan termina el %s",
+		"start": "Inicio", "end": "Fin", "late_start": "Inicio tardío", "float": "Holgura",
+		"starts_today": "Empieza hoy", "due_today": "Vence hoy", "no_tasks": "No hay tareas programadas.", "notes": "Notas",
+		"more_tasks": "Más tareas por día", "project_timeline": "Cronograma del proyecto", "roadmap": "Hoja de ruta",
+		"schedule_risk": "Riesgo del cronograma", "risk_intro": "Simulación de Monte Carlo con %d iteraciones. Cada fecha se alcanza en la proporción indicada de iteraciones.",
+		"planned": "Planificado", "on_time": "A tiempo", "finish_distribution": "Distribución del final de %s",
+		"earned_value": "Valor ganado", "earned_value_intro": "A %s, en días-tarea. PV es el trabajo planificado hasta esta fecha, EV el trabajo hecho según el avance y AC los días dedicados según la columna Actual Days, vacío sin ella. SPI = EV/PV y CPI = EV/AC; los valores por debajo de 1 van por detrás del plan.",
+		"overall": "Total", "done": "Hecho",
+	},
+	"it": {
+		"continued": "continua da prima", "continues": "continua", "more": "altre", "week": "Settimana",
+		"milestone": "Traguardo", "completed": "Completata",
+		"legend": "Legenda", "legend_intro": "Le categorie del piano, nei colori delle barre delle attività. I giorni sommano la durata di ogni attività.",
+		"category": "Categoria", "tasks": "Attività", "days": "Giorni",
+		"task_categories": "Categorie di attività", "default_categories": "Categorie predefinite", "phases": "Fasi",
+		"at_a_glance": "%d in sintesi",
+		"task_index":  "Indice delle attività", "data_sources": "Fonti dei dati", "files": "File",
+		"csv_merged": "%d file CSV uniti", "total_tasks": "Attività totali", "task_count": "%d attività",
+		"milestones": "%d traguardi", "completed_n": "%d completate", "critical_path": "Percorso critico",
+		"zero_slack": "nessun margine", "complete_pct": "%d%% completato", "no_float": "%d attività senza margine", "plan_finishes": "il piano termina il %s",
+		"start": "Inizio", "end": "Fine", "late_start": "Inizio al più tardi", "float": "Margine",
+		"starts_today": "Inizia oggi", "due_today": "Scade oggi", "no_tasks": "Nessuna attività pianificata.", "notes": "Note",
+		"more_tasks": "Altre attività per giorno", "project_timeline": "Cronoprogramma del progetto", "roadmap": "Roadmap",
+		"schedule_risk": "Rischio di pianificazione", "risk_intro": "Simulazione Monte Carlo su %d prove. Ogni data è raggiunta nella quota indicata di prove.",
+		"planned": "Pianificato", "on_time": "In tempo", "finish_distribution": "Distribuzione della fine di %s",
+		"earned_value": "Valore guadagnato", "earned_value_intro": "Al %s, in giorni-attività. PV è il lavoro pianificato entro questa data, EV il lavoro svolto secondo l'avanzamento e AC i giorni spesi secondo la colonna Actual Days, vuoto senza di essa. SPI = EV/PV e CPI = EV/AC; valori sotto 1 sono in ritardo sul piano.",
+		"overall": "Totale", "done": "Fatto",
+	},
+	"nl": {
+		"continued": "vervolg", "continues": "loopt door", "more": "meer", "week": "Week",
+		"milestone": "Mijlpaal", "completed": "Voltooid",
+		"legend": "Legenda", "legend_intro": "De categorieën van het plan, in de kleuren van de taakbalken. De dagen tellen de duur van elke taak op.",
+		"category": "Categorie", "tasks": "Taken", "days": "Dagen",
+		"task_categories": "Taakcategorieën", "default_categories": "Standaardcategorieën", "phases": "Fasen",
+		"at_a_glance": "%d in één oogopslag",
+		"task_index":  "Takenoverzicht", "data_sources": "Gegevensbronnen", "files": "Bestanden",
+		"csv_merged": "%d CSV-bestand(en) samengevoegd", "total_tasks": "Totaal aantal taken", "task_count": "%d taken",
+		"milestones": "%d mijlpalen", "completed_n": "%d voltooid", "critical_path": "Kritiek pad",
+		"zero_slack": "geen speling", "complete_pct": "%d%% voltooid", "no_float": "%d taken zonder speling", "plan_finishes": "plan eindigt op %s",
+		"start": "Begin", "end": "Einde", "late_start": "Laatste begin", "float": "Speling",
+		"starts_today": "Begint vandaag", "due_today": "Vandaag klaar", "no_tasks": "Geen geplande taken.", "notes": "Notities",
+		"more_tasks": "Meer taken per dag", "project_timeline": "Projectplanning", "roadmap": "Roadmap",
+		"schedule_risk": "Planningsrisico", "risk_intro": "Monte-Carlosimulatie over %d runs. Elke datum wordt gehaald in het aangegeven deel van de runs.",
+		"planned": "Gepland", "on_time": "Op tijd", "finish_distribution": "Verdeling van het einde van %s",
+		"earned_value": "Verdiende waarde", "earned_value_intro": "Per %s, in taakdagen. PV is het werk gepland tot deze datum, EV het werk gedaan volgens de voortgang en AC de bestede dagen uit de kolom Actual Days, leeg zonder die kolom. SPI = EV/PV en CPI = EV/AC; waarden onder 1 lopen achter op het plan.",
+		"overall": "Totaal", "done": "Klaar",
+	},
+	"pt": {
+		"continued": "continuação", "continues": "continua", "more": "mais", "week": "Semana",
+		"milestone": "Marco", "completed": "Concluída",
+		"legend": "Legenda", "legend_intro": "As categorias do plano, nas cores das barras de tarefas. Os dias somam a duração de cada tarefa.",
+		"category": "Categoria", "tasks": "Tarefas", "days": "Dias",
+		"task_categories": "Categorias de tarefas", "default_categories": "Categorias padrão", "phases": "Fases",
+		"at_a_glance": "%d em resumo",
+		"task_index":  "Índice de tarefas", "data_sources": "Fontes de dados", "files": "Arquivos",
+		"csv_merged": "%d arquivo(s) CSV combinado(s)", "total_tasks": "Total de tarefas", "task_count": "%d tarefas",
+		"milestones": "%d marcos", "completed_n": "%d concluídas", "critical_path": "Caminho crítico",
+		"zero_slack": "sem folga", "complete_pct": "%d%% concluído", "no_float": "%d tarefas sem folga", "plan_finishes": "o plano termina em %s",
+		"start": "Início", "end": "Fim", "late_start": "Início mais tarde", "float": "Folga",
+		"starts_today": "Começa hoje", "due_today": "Vence hoje", "no_tasks": "Nenhuma tarefa agendada.", "notes": "Notas",
+		"more_tasks": "Mais tarefas por dia", "project_timeline": "Cronograma do projeto", "roadmap": "Roteiro",
+		"schedule_risk": "Risco do cronograma", "risk_intro": "Simulação de Monte Carlo com %d execuções. Cada data é atingida na parcela indicada de execuções.",
+		"planned": "Planejado", "on_time": "No prazo", "finish_distribution": "Distribuição do término de %s",
+		"earned_value": "Valor agregado", "earned_value_intro": "Em %s, em dias-tarefa. PV é o trabalho planejado até esta data, EV o trabalho feito segundo o progresso e AC os dias gastos segundo a coluna Actual Days, vazio sem ela. SPI = EV/PV e CPI = EV/AC; valores abaixo de 1 estão atrasados.",
+		"overall": "Total", "done": "Feito",
+	},
+}
+
+// Text returns the text of the generated pages for key in the locale: the
+// config's strings first, then the language's translation, then English.
+// Args fill the %d and %s verbs of the text.
+func (l Locale) Text(key string, args ...any) string {
+	text, ok := l.Strings[key]
+	if !ok {
+		text, ok = localeText[l.language()][key]
+	}
+	if !ok {
+		text, ok = textEN[key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(text, args...)
+	}
+	return text
+}
+
+// language returns the language of the locale code, e.g. "de" for "de-DE"
+func (l Locale) language() string {
+	lang := strings.ToLower(l.Code)
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	return lang
+}
+
+// TextKeys returns the keys of the text of the generated pages, sorted
+func TextKeys() []string {
+	keys := make([]string, 0, len(textEN))
+	for key := range textEN {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// validateStrings checks that the strings config key only overrides known text
+func (cfg *Config) validateStrings() error {
+	for key := range cfg.Strings {
+		if _, ok := textEN[key]; !ok {
+			return NewConfigError("", "strings."+key,
+				fmt.Sprintf("unknown text key %q, expected one of %s", key, strings.Join(TextKeys(), ", ")), nil)
+		}
+	}
+	return nil
+}
@@ -14,6 +14,10 @@
 //   - PLANNER_LOG_LEVEL=trace|debug|info|warn|error|fatal|silent: Explicit level control
 //   - PLANNER_LOG_FORMAT=text|json: Output format (default: text)
 //   - PLANNER_LOG_FILE=/path/to/logfile: Write logs to file instead of stderr
+//   - PLANNER_QUIET=true: Hide progress output, keeping warnings and errors
+//
+// The --verbose, --quiet and --log-format flags set the same options through
+// ConfigureLogging.
 //
 // Structured logging with key-value pairs:
 //
@@ -79,6 +83,7 @@
 	envPlannerLogLevel  = "PLANNER_LOG_LEVEL"
 	envPlannerLogFormat = "PLANNER_LOG_FORMAT"
 	envPlannerLogFile   = "PLANNER_LOG_FILE"
+	envPlannerQuiet     = "PLANNER_QUIET"
 )
 
 // LogFormat represents the output format for logs
@@ -196,9 +201,65 @@ func parseLogFormat(format string) LogFormat {
 	}
 }
 
-// IsSilent returns true if logging is suppressed
+// IsSilent returns true if progress output is suppressed, because logging
+// is silent or quiet mode is on
 func IsSilent() bool {
-	return parseLogLevel(getLogLevelString()) == LogLevelSilent
+	quiet := os.Getenv(envPlannerQuiet)
+	return quiet == "1" || quiet == "true" || parseLogLevel(getLogLevelString()) == LogLevelSilent
+}
+
+// ConfigureLogging applies command-line logging options to the default
+// logger and, through the environment, to loggers created later. An empty
+// level or format keeps the current one; quiet hides progress output and
+// logs warnings and errors only.
+func ConfigureLogging(level, format string, quiet bool) error {
+	if quiet {
+		if level != "" && level != LogLevelWarnString {
+			return fmt.Errorf("quiet mode cannot be combined with log level %q", level)
+		}
+		level = LogLevelWarnString
+		if err := os.Setenv(envPlannerQuiet, "true"); err != nil {
+			return err
+		}
+	}
+
+	logger := NewDefaultLogger()
+	if level != "" {
+		switch level {
+		case LogLevelTraceString, LogLevelDebugString, LogLevelInfoString, LogLevelWarnString,
+			LogLevelErrorString, LogLevelFatalString, LogLevelSilentString:
+		default:
+			return fmt.Errorf("unknown log level %q", level)
+		}
+		if err := os.Setenv(envPlannerLogLevel, level); err != nil {
+			return err
+		}
+		logger.SetLevel(parseLogLevel(level))
+	}
+	if format != "" {
+		if format = strings.ToLower(format); format != "text" && format != "json" {
+			return fmt.Errorf("unknown log format %q, expected text or json", format)
+		}
+		if err := os.Setenv(envPlannerLogFormat, format); err != nil {
+			return err
+		}
+		logger.SetFormat(parseLogFormat(format))
+	}
+	return nil
+}
+
+// SetLevel changes the lowest level the logger writes
+func (l *Logger) SetLevel(level int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.Level = level
+}
+
+// SetFormat changes the output format of the logger
+func (l *Logger) SetFormat(format LogFormat) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.format = format
 }
 
 // WithField creates a new logger with an additional field
@@ -0,0 +1,106 @@
+package core
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DefaultMilestoneColumn is the CSV column that marks milestones
+const DefaultMilestoneColumn = "Milestone"
+
+// defaultMilestonePattern matches the names and objectives of tasks that are
+// milestones when no pattern is configured
+const defaultMilestonePattern = `(?i)milestone|deadline|due|complete|finish|submit|deliver`
+
+// MilestoneRules decide which tasks are milestones. A task is a milestone
+// when any rule matches.
+type MilestoneRules struct {
+	// Column is the CSV column marking milestones, "Milestone" by default
+	Column string `yaml:"column"`
+	// Values are the column values that mark a milestone, ignoring case;
+	// when empty any value except false/no/0 does
+	Values []string `yaml:"values"`
+	// Categories are categories whose tasks are all milestones
+	Categories []string `yaml:"categories"`
+	// Pattern is a regular expression matched against the task name and
+	// objective; empty uses built-in keywords and "none" turns matching off
+	Pattern string `yaml:"pattern"`
+}
+
+// milestoneMatcher is a compiled MilestoneRules
+type milestoneMatcher struct {
+	column     string
+	values     []string
+	categories []string
+	pattern    *regexp.Regexp
+}
+
+// compile checks the pattern and prepares the rules for matching
+func (m MilestoneRules) compile() (*milestoneMatcher, error) {
+	matcher := &milestoneMatcher{
+		column:     strings.ToLower(strings.TrimSpace(m.Column)),
+		values:     m.Values,
+		categories: m.Categories,
+	}
+	if matcher.column == "" {
+		matcher.column = strings.ToLower(DefaultMilestoneColumn)
+	}
+
+	pattern := strings.TrimSpace(m.Pattern)
+	switch {
+	case pattern == "":
+		pattern = defaultMilestonePattern
+	case strings.EqualFold(pattern, "none"):
+		return matcher, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, NewConfigError("", "milestones.pattern", "invalid regular expression "+pattern, err)
+	}
+	matcher.pattern = re
+	return matcher, nil
+}
+
+// defaultMilestones applies the built-in rules while reading, before any
+// configuration is known
+var defaultMilestones, _ = MilestoneRules{}.compile()
+
+// match reports whether a task read from the CSV is a milestone
+func (m *milestoneMatcher) match(task Task) bool {
+	if value := strings.TrimSpace(task.columns[m.column]); value != "" {
+		if len(m.values) == 0 {
+			switch strings.ToLower(value) {
+			case "false", "no", "0":
+			default:
+				return true
+			}
+		}
+		for _, v := range m.values {
+			if strings.EqualFold(strings.TrimSpace(v), value) {
+				return true
+			}
+		}
+	}
+	for _, category := range m.categories {
+		if strings.EqualFold(strings.TrimSpace(category), strings.TrimSpace(task.Category)) {
+			return true
+		}
+	}
+	return m.pattern != nil && m.pattern.MatchString(task.Name+" "+task.Description)
+}
+
+// DetectMilestones decides once per task whether it is a milestone using the
+// configured rules. Tasks not read from a CSV, such as recurring milestones,
+// keep their flag.
+func (c *Config) DetectMilestones(tasks []Task) error {
+	matcher, err := c.Milestones.compile()
+	if err != nil {
+		return err
+	}
+	for i := range tasks {
+		if tasks[i].columns != nil {
+			tasks[i].IsMilestone = matcher.match(tasks[i])
+		}
+	}
+	return nil
+}
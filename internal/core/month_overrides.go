@@ -0,0 +1,127 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+// MonthOverride sets layout and daily keys for some months only, merged over
+// the global settings on their month and daily pages
+type MonthOverride struct {
+	// Months lists the months it applies to: "2027-05", a range such as
+	// "2026-06:2026-08", or a month name such as "july" for every year
+	Months []string `yaml:"months"`
+
+	// Layout and Daily hold keys as in the layout: and daily: sections
+	Layout map[string]any `yaml:"layout"`
+	Daily  map[string]any `yaml:"daily"`
+}
+
+// matches reports whether the override applies to a month
+func (o MonthOverride) matches(m MonthYear) bool {
+	for _, spec := range o.Months {
+		if month, ok := monthName(spec); ok {
+			if month == m.Month {
+				return true
+			}
+			continue
+		}
+		if r, err := ParseMonthRange(spec); err == nil && r.Contains(m) {
+			return true
+		}
+	}
+	return false
+}
+
+// monthName reads an English month name, full or of three letters
+func monthName(s string) (time.Month, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for m := time.January; m <= time.December; m++ {
+		name := strings.ToLower(m.String())
+		if s == name || s == name[:3] {
+			return m, true
+		}
+	}
+	return 0, false
+}
+
+// apply merges the override's keys over cfg
+func (o MonthOverride) apply(cfg *Config) error {
+	sections := make(map[string]any, 2)
+	if o.Layout != nil {
+		sections["layout"] = o.Layout
+	}
+	if o.Daily != nil {
+		sections["daily"] = o.Daily
+	}
+	if len(sections) == 0 {
+		return nil
+	}
+	bts, err := yaml.Marshal(sections)
+	if err != nil {
+		return err
+	}
+	if err := yaml.UnmarshalWithOptions(bts, cfg, yaml.Strict()); err != nil {
+		// Keep the message without the position in and listing of the
+		// generated YAML
+		msg, _, _ := strings.Cut(err.Error(), "\n")
+		if strings.HasPrefix(msg, "[") {
+			_, msg, _ = strings.Cut(msg, "] ")
+		}
+		return errors.New(msg)
+	}
+	return cfg.Layout.TaskStyling.compileAbbreviations()
+}
+
+// validateMonthOverrides checks the months of each override and that its
+// keys apply over the global settings
+func (cfg *Config) validateMonthOverrides() error {
+	for i, o := range cfg.MonthOverrides {
+		field := fmt.Sprintf("month_overrides[%d]", i)
+		if len(o.Months) == 0 {
+			return NewConfigError("", field+".months", "list the months the override applies to", nil)
+		}
+		for _, spec := range o.Months {
+			if _, ok := monthName(spec); ok {
+				continue
+			}
+			if _, err := ParseMonthRange(spec); err != nil {
+				return NewConfigError("", field+".months", "expected YYYY-MM, YYYY-MM:YYYY-MM or a month name", err)
+			}
+		}
+
+		trial := *cfg
+		if err := o.apply(&trial); err != nil {
+			return NewConfigError("", field, "unable to apply the override", err)
+		}
+		if err := trial.validateTheme(); err != nil {
+			return NewConfigError("", field, "invalid override", err)
+		}
+	}
+	return nil
+}
+
+// ForMonth returns the config of a month's pages, with the overrides for
+// that month merged over the global settings in order
+func (cfg Config) ForMonth(year int, month time.Month) Config {
+	m := MonthYear{Year: year, Month: month}
+	for _, o := range cfg.MonthOverrides {
+		if !o.matches(m) {
+			continue
+		}
+		// Checked when the config was loaded
+		_ = o.apply(&cfg)
+		cfg.monthOverridden = true
+	}
+	return cfg
+}
+
+// HasMonthOverride reports whether month overrides were merged into the
+// config, so pages set the lengths the shared preamble fixed again
+func (cfg *Config) HasMonthOverride() bool {
+	return cfg.monthOverridden
+}
@@ -0,0 +1,38 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestForMonth(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Daily.NotesLines = 12
+	cfg.MonthOverrides = []MonthOverride{
+		{Months: []string{"2027-05"}, Layout: map[string]any{"latex": map[string]any{"monthlycellheight": "6em"}}},
+		{Months: []string{"june", "2027-01:2027-02"}, Daily: map[string]any{"notes_lines": 3}},
+	}
+	if err := cfg.validateMonthOverrides(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := cfg.ForMonth(2027, time.May).Layout.LaTeX.MonthlyCellHeight; got != "6em" {
+		t.Errorf("May 2027 cell height = %q, want 6em", got)
+	}
+	for _, m := range []MonthYear{{2026, time.June}, {2027, time.June}, {2027, time.February}} {
+		if got := cfg.ForMonth(m.Year, m.Month).Daily.NotesLines; got != 3 {
+			t.Errorf("%v notes lines = %d, want 3", m, got)
+		}
+	}
+	if got := cfg.ForMonth(2026, time.May); got.HasMonthOverride() || got.Daily.NotesLines != 12 {
+		t.Errorf("May 2026 got overrides")
+	}
+	if cfg.Layout.LaTeX.MonthlyCellHeight == "6em" {
+		t.Errorf("override leaked into the global config")
+	}
+
+	cfg.MonthOverrides = []MonthOverride{{Months: []string{"2027-05"}, Layout: map[string]any{"nope": 1}}}
+	if err := cfg.validateMonthOverrides(); err == nil {
+		t.Errorf("unknown key accepted")
+	}
+}
@@ -0,0 +1,128 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// monthFormat is the format of months in ranges: 2025-03
+const monthFormat = "2006-01"
+
+// MonthRange is an inclusive range of months, such as the months rebuilt
+// with --range 2025-01:2025-06
+type MonthRange struct {
+	From, To MonthYear
+}
+
+// ParseMonthRange reads a month ("2025-03") or a range of months
+// ("2025-01:2025-06")
+func ParseMonthRange(s string) (MonthRange, error) {
+	from, to, isRange := strings.Cut(strings.TrimSpace(s), ":")
+	if !isRange {
+		to = from
+	}
+	start, err := time.Parse(monthFormat, strings.TrimSpace(from))
+	if err != nil {
+		return MonthRange{}, fmt.Errorf("invalid month %q: use YYYY-MM or YYYY-MM:YYYY-MM", from)
+	}
+	end, err := time.Parse(monthFormat, strings.TrimSpace(to))
+	if err != nil {
+		return MonthRange{}, fmt.Errorf("invalid month %q: use YYYY-MM or YYYY-MM:YYYY-MM", to)
+	}
+	if end.Before(start) {
+		return MonthRange{}, fmt.Errorf("range %q ends before it starts", s)
+	}
+	return MonthRange{
+		From: MonthYear{Year: start.Year(), Month: start.Month()},
+		To:   MonthYear{Year: end.Year(), Month: end.Month()},
+	}, nil
+}
+
+// Start returns the first day of the range
+func (r MonthRange) Start() time.Time {
+	return time.Date(r.From.Year, r.From.Month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// End returns the last day of the range
+func (r MonthRange) End() time.Time {
+	return time.Date(r.To.Year, r.To.Month+1, 0, 0, 0, 0, 0, time.UTC)
+}
+
+// Contains reports whether a month lies in the range
+func (r MonthRange) Contains(m MonthYear) bool {
+	first := time.Date(m.Year, m.Month, 1, 0, 0, 0, 0, time.UTC)
+	return !first.Before(r.Start()) && !first.After(r.End())
+}
+
+// Filter returns the months that lie in the range
+func (r MonthRange) Filter(months []MonthYear) []MonthYear {
+	var kept []MonthYear
+	for _, m := range months {
+		if r.Contains(m) {
+			kept = append(kept, m)
+		}
+	}
+	return kept
+}
+
+// Months lists every month of the range in order
+func (r MonthRange) Months() []MonthYear {
+	var months []MonthYear
+	for m := r.Start(); !m.After(r.End()); m = m.AddDate(0, 1, 0) {
+		months = append(months, MonthYear{Year: m.Year(), Month: m.Month()})
+	}
+	return months
+}
+
+// parseCalendarMonth reads the month of a calendar bound given as YYYY-MM
+// or YYYY-MM-DD
+func parseCalendarMonth(s string) (MonthYear, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{monthFormat, DateFormatISO} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return MonthYear{Year: t.Year(), Month: t.Month()}, nil
+		}
+	}
+	return MonthYear{}, fmt.Errorf("invalid date %q: use YYYY-MM or YYYY-MM-DD", s)
+}
+
+// ApplyCalendarRange clips or pads MonthsWithTasks to calendar_start and
+// calendar_end, so that months without tasks are generated too. A missing
+// bound keeps the first or last month with tasks.
+func (cfg *Config) ApplyCalendarRange() error {
+	if strings.TrimSpace(cfg.CalendarStart) == "" && strings.TrimSpace(cfg.CalendarEnd) == "" {
+		return nil
+	}
+
+	var r MonthRange
+	if len(cfg.MonthsWithTasks) > 0 {
+		r = MonthRange{From: cfg.MonthsWithTasks[0], To: cfg.MonthsWithTasks[len(cfg.MonthsWithTasks)-1]}
+	}
+	if strings.TrimSpace(cfg.CalendarStart) != "" {
+		from, err := parseCalendarMonth(cfg.CalendarStart)
+		if err != nil {
+			return NewConfigError("", "calendar_start", "invalid calendar start", err)
+		}
+		r.From = from
+	}
+	if strings.TrimSpace(cfg.CalendarEnd) != "" {
+		to, err := parseCalendarMonth(cfg.CalendarEnd)
+		if err != nil {
+			return NewConfigError("", "calendar_end", "invalid calendar end", err)
+		}
+		r.To = to
+	}
+	if r.From.Year == 0 || r.To.Year == 0 {
+		return NewConfigError("", "calendar_start", "calendar range needs both bounds when there are no tasks", nil)
+	}
+	if r.End().Before(r.Start()) {
+		return NewConfigError("", "calendar_end", "calendar range ends before it starts",
+			fmt.Errorf("%s is before %s", r.End().Format(monthFormat), r.Start().Format(monthFormat)))
+	}
+
+	cfg.CalendarMonths = &r
+	cfg.MonthsWithTasks = r.Months()
+	cfg.StartYear, cfg.EndYear = r.From.Year, r.To.Year
+	return nil
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Built-in output profiles
+const (
+	ProfilePrint   = "print"
+	ProfileDigital = "digital"
+	ProfileTablet  = "tablet"
+)
+
+// profiles are the built-in output profiles by name, as key.path=value
+// overrides set over the config files
+var profiles = map[string][]string{
+	// Paper: no link decoration or page color, hatching for gray printers
+	// and a bleed for trimming
+	ProfilePrint: {
+		"layout.links.style=" + LinkStyleHidden,
+		`layout.page_colors.background=""`,
+		`layout.page_colors.text=""`,
+		"layout.palette.patterns=true",
+		"layout.paper.bleed=3mm",
+	},
+	// Screens: colored links to follow and no bleed
+	ProfileDigital: {
+		"layout.links.style=" + LinkStyleColor,
+		`layout.paper.bleed=""`,
+	},
+	// 4:3 tablets: a page of the screen's shape with the month grid filling it
+	ProfileTablet: {
+		"layout.paper.width=15.6cm",
+		"layout.paper.height=20.8cm",
+		"layout.grid.fill=true",
+		"layout.links.style=" + LinkStyleColor,
+		`layout.paper.bleed=""`,
+	},
+}
+
+// ProfileNames returns the names of the built-in output profiles, sorted
+func ProfileNames() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// profileOverrides returns the overrides of the profile the config selects,
+// from its own profiles first; no profile sets nothing
+func (cfg *Config) profileOverrides() ([]string, error) {
+	name := strings.ToLower(strings.TrimSpace(cfg.Profile))
+	if name == "" {
+		return nil, nil
+	}
+	for own, overrides := range cfg.Profiles {
+		if strings.ToLower(strings.TrimSpace(own)) == name {
+			return overrides, nil
+		}
+	}
+	if overrides, ok := profiles[name]; ok {
+		return overrides, nil
+	}
+
+	names := ProfileNames()
+	for own := range cfg.Profiles {
+		names = append(names, own)
+	}
+	sort.Strings(names)
+	return nil, NewConfigError("", "profile",
+		fmt.Sprintf("unknown profile %q, expected one of %s", name, strings.Join(names, ", ")), nil)
+}
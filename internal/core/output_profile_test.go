@@ -0,0 +1,22 @@
+package core
+
+import "testing"
+
+func TestProfileOverrides(t *testing.T) {
+	cfg := Config{Profile: " Print "}
+	if got, err := cfg.profileOverrides(); err != nil || len(got) == 0 {
+		t.Errorf("print = %v, %v", got, err)
+	}
+
+	cfg = Config{Profile: "print", Profiles: map[string][]string{"print": {"layout.paper.bleed=5mm"}}}
+	if got, _ := cfg.profileOverrides(); len(got) != 1 || got[0] != "layout.paper.bleed=5mm" {
+		t.Errorf("own print = %v, want the config's profile", got)
+	}
+
+	if got, err := (&Config{}).profileOverrides(); err != nil || got != nil {
+		t.Errorf("no profile = %v, %v", got, err)
+	}
+	if _, err := (&Config{Profile: "poster"}).profileOverrides(); err == nil {
+		t.Errorf("unknown profile accepted")
+	}
+}
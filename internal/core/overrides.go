@@ -0,0 +1,71 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// OverrideEnvPrefix starts the environment variables that set any config
+// key: PLANNERGEN_LAYOUT__STACKING__SPACING=0.5mm sets layout.stacking.spacing.
+// A double underscore separates the levels of the key path.
+const OverrideEnvPrefix = "PLANNERGEN_"
+
+// EnvOverrides returns the PLANNERGEN_* variables of environ as key=value
+// overrides, sorted by key
+func EnvOverrides(environ []string) []string {
+	var overrides []string
+	for _, kv := range environ {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(name, OverrideEnvPrefix) {
+			continue
+		}
+		path := strings.ToLower(strings.TrimPrefix(name, OverrideEnvPrefix))
+		overrides = append(overrides, strings.ReplaceAll(path, "__", ".")+"="+value)
+	}
+	sort.Strings(overrides)
+	return overrides
+}
+
+// ApplyOverrides sets config keys from key.path=value strings, in order. The
+// value is read as YAML, so numbers, booleans and [a, b] lists work; keys
+// that do not exist in the config and numbers out of range are an error.
+func (c *Config) ApplyOverrides(overrides []string) error {
+	for _, override := range overrides {
+		path, raw, ok := strings.Cut(override, "=")
+		path = strings.TrimSpace(path)
+		if !ok || path == "" {
+			return NewConfigError("", override, "override must be key.path=value", nil)
+		}
+
+		var value any
+		if err := yaml.Unmarshal([]byte(raw), &value); err != nil {
+			value = raw
+		}
+		if err := checkOverrideRange(path, value); err != nil {
+			return err
+		}
+		keys := strings.Split(path, ".")
+		for i := len(keys) - 1; i >= 0; i-- {
+			key := strings.TrimSpace(keys[i])
+			if key == "" {
+				return NewConfigError("", path, "override key has an empty part", nil)
+			}
+			value = map[string]any{key: value}
+		}
+
+		bts, err := yaml.Marshal(value)
+		if err != nil {
+			return NewConfigError("", path, "invalid override value", err)
+		}
+		if err := yaml.UnmarshalWithOptions(bts, c, yaml.Strict()); err != nil {
+			// Keep the message without the listing of the generated YAML
+			msg, _, _ := strings.Cut(err.Error(), "\n")
+			return NewConfigError("", path, fmt.Sprintf("unable to set %q", strings.TrimSpace(raw)), errors.New(msg))
+		}
+	}
+	return nil
+}
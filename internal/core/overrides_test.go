@@ -0,0 +1,31 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplyOverrides(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Layout.Stacking.MaxHeight = 40
+	overrides := append(EnvOverrides([]string{"HOME=/root", "PLANNERGEN_LAYOUT__STACKING__MIN_HEIGHT=12"}),
+		"layout.stacking.min_height=18", "filter.include_tags=[core, draft]", "calendar_start=2025-09")
+	if err := cfg.ApplyOverrides(overrides); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Layout.Stacking.MinHeight != 18 || cfg.Layout.Stacking.MaxHeight != 40 {
+		t.Errorf("stacking = %+v, want min 18 and max 40 kept", cfg.Layout.Stacking)
+	}
+	if got := strings.Join(cfg.Filter.IncludeTags, ","); got != "core,draft" {
+		t.Errorf("include_tags = %s, want core,draft", got)
+	}
+	if cfg.CalendarStart != "2025-09" {
+		t.Errorf("calendar_start = %q, want 2025-09", cfg.CalendarStart)
+	}
+
+	for _, bad := range []string{"layout.stacking.nope=1", "layout.stacking.min_height=abc", "calendar_start"} {
+		if err := cfg.ApplyOverrides([]string{bad}); err == nil {
+			t.Errorf("%s: expected an error", bad)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package core
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// PhaseRollup is the envelope of a phase's tasks
+type PhaseRollup struct {
+	Phase string
+	Start time.Time // Earliest task start
+	End   time.Time // Latest task end
+	Tasks int
+	// Progress is the phase completion, 0-100: task progress weighted by
+	// task length in days, milestones counting as one day
+	Progress float64
+	// Lane is the band row of the phase; overlapping phases get different lanes
+	Lane int
+}
+
+// PhaseRollups returns the envelope and weighted progress of every named
+// phase in order of start date, with lanes assigned so that overlapping
+// phases never share one
+func PhaseRollups(tasks []Task) []PhaseRollup {
+	byPhase := make(map[string]*PhaseRollup)
+	weights := make(map[string]float64)
+	var order []string
+	for _, t := range tasks {
+		phase := strings.TrimSpace(t.Phase)
+		if phase == "" || t.StartDate.IsZero() || t.EndDate.IsZero() {
+			continue
+		}
+		start, end := dayOf(t.StartDate), dayOf(t.EndDate)
+		r, ok := byPhase[phase]
+		if !ok {
+			r = &PhaseRollup{Phase: phase, Start: start, End: end}
+			byPhase[phase] = r
+			order = append(order, phase)
+		}
+		if start.Before(r.Start) {
+			r.Start = start
+		}
+		if end.After(r.End) {
+			r.End = end
+		}
+		r.Tasks++
+
+		weight := float64(daysBetween(start, end) + 1)
+		weights[phase] += weight
+		r.Progress += weight * t.Completion()
+	}
+
+	rollups := make([]PhaseRollup, 0, len(order))
+	for _, phase := range order {
+		r := *byPhase[phase]
+		if weights[phase] > 0 {
+			r.Progress = r.Progress / weights[phase] * 100
+		}
+		rollups = append(rollups, r)
+	}
+	sort.SliceStable(rollups, func(i, j int) bool { return rollups[i].Start.Before(rollups[j].Start) })
+
+	// Greedy lane assignment: reuse the first lane that is free again
+	var laneEnds []time.Time
+	for i := range rollups {
+		lane := 0
+		for lane < len(laneEnds) && !rollups[i].Start.After(laneEnds[lane]) {
+			lane++
+		}
+		if lane == len(laneEnds) {
+			laneEnds = append(laneEnds, rollups[i].End)
+		} else {
+			laneEnds[lane] = rollups[i].End
+		}
+		rollups[i].Lane = lane
+	}
+	return rollups
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Progress reports the steps of a long-running task, such as the months laid
+// out for a page. On a terminal it redraws one line with the latest step; in
+// logs it prints a line at most every heartbeat so long runs do not look hung.
+// A nil Progress ignores every call.
+type Progress struct {
+	message     string
+	unit        string // Plural name of a step, e.g. "months"
+	whole       string // Name of the work when it has no steps, e.g. "cover page"
+	interactive bool
+	heartbeat   time.Duration
+
+	mu      sync.Mutex
+	steps   int
+	start   time.Time
+	printed time.Time
+}
+
+// progressRedraw limits terminal redraws
+const progressRedraw = 50 * time.Millisecond
+
+// NewProgress starts reporting progress under message, counting steps as
+// unit, or naming the work whole when it takes none. It returns nil in
+// silent mode.
+func NewProgress(message, unit, whole string) *Progress {
+	if IsSilent() {
+		return nil
+	}
+	p := &Progress{
+		message:     message,
+		unit:        unit,
+		whole:       whole,
+		interactive: IsInteractive(),
+		heartbeat:   2 * time.Second,
+		start:       time.Now(),
+	}
+	p.printed = p.start
+	if p.interactive {
+		fmt.Print(ClearLine() + message)
+	} else {
+		fmt.Println(message)
+	}
+	return p
+}
+
+// Step records one step, described by detail
+func (p *Progress) Step(detail string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.steps++
+	now := time.Now()
+	switch {
+	case p.interactive && now.Sub(p.printed) >= progressRedraw:
+		fmt.Print(ClearLine() + p.message + " " + DimText(fmt.Sprintf("· %s (%d %s)", detail, p.steps, p.unit)))
+		p.printed = now
+	case !p.interactive && now.Sub(p.printed) >= p.heartbeat:
+		fmt.Println(DimText(fmt.Sprintf("   … %s (%d %s)", detail, p.steps, p.unit)))
+		p.printed = now
+	}
+}
+
+// Done ends the report with the number of steps and the elapsed time. On a
+// terminal the line is left open for the caller's status mark.
+func (p *Progress) Done() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	done := fmt.Sprintf("%d %s", p.steps, p.unit)
+	if p.steps == 0 && p.whole != "" {
+		done = p.whole
+	}
+	summary := fmt.Sprintf("%s in %s", done, time.Since(p.start).Round(10*time.Millisecond))
+	if p.interactive {
+		fmt.Print(ClearLine() + p.message + " " + DimText("· "+summary))
+	} else {
+		fmt.Println(DimText("   done: " + summary))
+	}
+}
@@ -2,37 +2,51 @@
 
 import (
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 )
 
 const (
 	// DateFormats supported date formats in CSV files (in order of preference)
-	DateFormatISO   = "2006-01-02"          // ISO format: 2024-01-15
-	DateFormatUS    = "01/02/2006"          // US format: 01/15/2024
-	DateFormatEU    = "02/01/2006"          // EU format: 15/01/2024
-	DateFormatSlash = "2006/01/02"          // Slash format: 2024/01/15
-	DateFormatDot   = "02.01.2006"          // Dot format: 15.01.2024
-	DateFormatSpace = "2006-01-02 15:04:05" // With time: 2024-01-15 10:30:00
+	DateFormatISO    = "2006-01-02"          // ISO format: 2024-01-15
+	DateFormatUS     = "01/02/2006"          // US format: 01/15/2024
+	DateFormatEU     = "02/01/2006"          // EU format: 15/01/2024
+	DateFormatSlash  = "2006/01/02"          // Slash format: 2024/01/15
+	DateFormatDot    = "02.01.2006"          // Dot format: 15.01.2024
+	DateFormatSpace  = "2006-01-02 15:04:05" // With time: 2024-01-15 10:30:00
+	DateFormatMinute = "2006-01-02 15:04"    // With hours and minutes: 2024-01-15 10:30
+	DateFormatT      = "2006-01-02T15:04"    // ISO 8601 local time: 2024-01-15T10:30
+
+	DateFormatRFC3339 = time.RFC3339             // With UTC offset: 2024-01-15T10:30:00-05:00
+	DateFormatTZone   = "2006-01-02T15:04Z07:00" // With UTC offset, no seconds: 2024-01-15T15:30Z
 )
 
 // Error types for detailed error reporting
 type ParseError struct {
-	Row     int
+	Row     int // Line of the CSV file
 	Column  string
 	Value   string
 	Message string
 	Err     error
 }
 
+// Error reads like "row 37, column End Date: cannot parse '31/02/2025': ...",
+// where the row is the line of the CSV file
 func (e *ParseError) Error() string {
+	problem := "invalid value"
+	if e.Err != nil {
+		problem = "cannot parse"
+	}
+	msg := fmt.Sprintf("column %s: %s '%s': %s", e.Column, problem, e.Value, e.Message)
 	if e.Row > 0 {
-		return fmt.Sprintf("row %d, column '%s', value '%s': %s", e.Row, e.Column, e.Value, e.Message)
+		msg = fmt.Sprintf("row %d, %s", e.Row, msg)
 	}
-	return fmt.Sprintf("column '%s', value '%s': %s", e.Column, e.Value, e.Message)
+	return msg
 }
 
 func (e *ParseError) Unwrap() error {
@@ -79,6 +93,10 @@ func NewValidationError(taskID, field, value, message string) *ValidationError {
 	DateFormatSlash,
 	DateFormatDot,
 	DateFormatSpace,
+	DateFormatMinute,
+	DateFormatT,
+	DateFormatRFC3339,
+	DateFormatTZone,
 }
 
 // Reader handles reading and parsing CSV task data
@@ -174,20 +192,6 @@ func (r *Reader) parseDate(dateStr string) (time.Time, error) {
 		fmt.Sprintf("unable to parse with any supported format (tried: %v)", supportedDateFormats), nil)
 }
 
-// isMilestoneTask determines if a task is a milestone based on its name or description
-func (r *Reader) isMilestoneTask(name, description string) bool {
-	text := strings.ToLower(name + " " + description)
-	milestoneKeywords := []string{"milestone", "deadline", "due", "complete", "finish", "submit", "deliver"}
-
-	for _, keyword := range milestoneKeywords {
-		if strings.Contains(text, keyword) {
-			return true
-		}
-	}
-
-	return false
-}
-
 // addError adds an error to the aggregator
 func (r *Reader) addError(err error) {
 	r.aggregator.AddError(err)
@@ -213,6 +217,11 @@ func (r *Reader) getErrorSummary() string {
 	return r.aggregator.Summary()
 }
 
+// ParseErrors returns the errors of rows skipped by the last ReadTasks
+func (r *Reader) ParseErrors() []error {
+	return r.aggregator.Errors
+}
+
 // ReadTasks reads all tasks from the CSV file with improved error handling and memory management
 func (r *Reader) ReadTasks() ([]Task, error) {
 	// Clear any previous errors
@@ -316,7 +325,6 @@ func (r *Reader) createFieldIndexMap(header []string) map[string]int {
 func (r *Reader) parseAllRecords(reader *csv.Reader, fieldIndex map[string]int) ([]Task, []error) {
 	var tasks []Task
 	var parseErrors []error
-	rowNum := 1 // Start from 1 (header is row 0)
 
 	for {
 		record, err := reader.Read()
@@ -324,46 +332,68 @@ func (r *Reader) parseAllRecords(reader *csv.Reader, fieldIndex map[string]int)
 			break
 		}
 		if err != nil {
-			parseErrors = append(parseErrors, fmt.Errorf("row %d: %w", rowNum, err))
-			r.addError(fmt.Errorf("row %d: %w", rowNum, err))
+			// csv.ParseError gives the line and column of the bad quoting
+			parseErrors = append(parseErrors, err)
+			r.addError(err)
 			break
 		}
 
-		rowNum++
-
 		// Skip empty rows
 		if len(record) == 0 || record[0] == "" {
 			continue
 		}
 
-		task, err := r.parseTask(record, fieldIndex, rowNum)
+		// Rows are numbered by their line in the file, which quoted fields
+		// spanning lines and blank lines keep apart from the record count
+		line, _ := reader.FieldPos(0)
+		task, err := r.parseTask(record, fieldIndex, line)
 		if err != nil {
-			parseErr := fmt.Errorf("row %d: %w", rowNum, err)
+			parseErr := locateParseError(err, reader, fieldIndex, line)
 			parseErrors = append(parseErrors, parseErr)
 			r.addError(parseErr)
 
 			if r.strictMode {
 				// Return error immediately in strict mode
-				return tasks, []error{fmt.Errorf("strict mode: failed to parse task at row %d: %w", rowNum, err)}
+				return tasks, []error{fmt.Errorf("strict mode: %w", parseErr)}
 			}
 
 			if !r.skipInvalid {
 				// Return error if not skipping invalid rows
-				return tasks, []error{fmt.Errorf("failed to parse task at row %d: %w", rowNum, err)}
+				return tasks, []error{parseErr}
 			}
 
 			// Log warning but continue processing other tasks
-			r.logger.Warn("Skipping invalid task at row %d: %v", rowNum, err)
-			r.addWarning(fmt.Errorf("skipped invalid task at row %d: %w", rowNum, err))
+			r.logger.Warn("Skipping invalid task in %s: %v", r.FilePath, parseErr)
+			r.addWarning(fmt.Errorf("skipped invalid task: %w", parseErr))
 			continue
 		}
 
+		task.line = line
 		tasks = append(tasks, task)
 	}
 
 	return tasks, parseErrors
 }
 
+// locateParseError returns the error of a row as a ParseError on the line of
+// its column's field, which differs from the row's first line after a
+// quoted field spanning lines
+func locateParseError(err error, reader *csv.Reader, fieldIndex map[string]int, line int) error {
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			return fmt.Errorf("row %d: %w", line, err)
+		}
+		parseErr = NewParseError(line, validationErr.Field, validationErr.Value, validationErr.Message, nil)
+	}
+	parseErr.Row = line
+	if i, ok := fieldIndex[strings.ToLower(parseErr.Column)]; ok {
+		parseErr.Row, _ = reader.FieldPos(i)
+	}
+	return parseErr
+}
+
 // logParsingSummary logs a summary of the parsing results
 func (r *Reader) logParsingSummary(tasks []Task, _ []error) {
 	errorCount := r.aggregator.ErrorCount()
@@ -410,6 +440,17 @@ func (fe *fieldExtractor) get(fieldName string) string {
 	return ""
 }
 
+// columns returns the row's values by lower-case column name
+func (fe *fieldExtractor) columns() map[string]string {
+	values := make(map[string]string, len(fe.fieldIndex))
+	for name, index := range fe.fieldIndex {
+		if index < len(fe.record) {
+			values[name] = strings.TrimSpace(fe.record[index])
+		}
+	}
+	return values
+}
+
 // getWithDefault retrieves a field value with a default fallback
 func (fe *fieldExtractor) getWithDefault(fieldName, defaultValue string) string {
 	value := fe.get(fieldName)
@@ -437,6 +478,18 @@ func (fe *fieldExtractor) getList(fieldName string) []string {
 	return result
 }
 
+// getTags retrieves a list field separated by commas or semicolons, so that
+// tags need no quoting in the CSV
+func (fe *fieldExtractor) getTags(fieldName string) []string {
+	var tags []string
+	for _, tag := range strings.FieldsFunc(fe.get(fieldName), func(r rune) bool { return r == ',' || r == ';' }) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
 // parseTask parses a single CSV record into a Task struct with improved field mapping
 func (r *Reader) parseTask(record []string, fieldIndex map[string]int, rowNum int) (Task, error) {
 	extractor := newFieldExtractor(record, fieldIndex)
@@ -451,8 +504,43 @@ func (r *Reader) parseTask(record []string, fieldIndex map[string]int, rowNum in
 	// Extract status and assignment
 	r.extractStatusFields(&task, extractor)
 
+	// Keep the row for milestone rules and apply the built-in ones until the
+	// configuration is loaded
+	task.columns = extractor.columns()
+	task.IsMilestone = defaultMilestones.match(task)
+
 	// Extract dependencies
-	task.Dependencies = extractor.getList("Dependencies")
+	for _, spec := range extractor.getList("Dependencies") {
+		kind, spec := ParseDependencyType(spec)
+		id, lag := ParseDependency(spec)
+		task.Dependencies = append(task.Dependencies, id)
+		if lag != 0 {
+			if task.Lags == nil {
+				task.Lags = make(map[string]int)
+			}
+			task.Lags[id] = lag
+		}
+		if kind != FinishToStart {
+			if task.DepTypes == nil {
+				task.DepTypes = make(map[string]DependencyType)
+			}
+			task.DepTypes[id] = kind
+		}
+	}
+
+	// Parse optional completion percentage and effort
+	if err := r.extractProgressField(&task, extractor, rowNum); err != nil {
+		return task, err
+	}
+	if err := r.extractDurationField(&task, extractor, rowNum); err != nil {
+		return task, err
+	}
+	if err := r.extractEffortField(&task, extractor, rowNum); err != nil {
+		return task, err
+	}
+	if err := r.extractActualDaysField(&task, extractor, rowNum); err != nil {
+		return task, err
+	}
 
 	// Parse dates
 	if err := r.extractDateFields(&task, extractor, rowNum); err != nil {
@@ -467,7 +555,7 @@ func (r *Reader) parseTask(record []string, fieldIndex map[string]int, rowNum in
 	return task, nil
 }
 
-// extractBasicFields extracts ID, name, description, and milestone status
+// extractBasicFields extracts ID, name and description
 func (r *Reader) extractBasicFields(task *Task, extractor *fieldExtractor) {
 	task.ID = extractor.get("Task ID")
 	if task.ID == "" {
@@ -475,15 +563,8 @@ func (r *Reader) extractBasicFields(task *Task, extractor *fieldExtractor) {
 	}
 	task.Name = extractor.get("Task")
 	task.Description = extractor.get("Objective")
-
-	// Extract milestone status from CSV column or detect from content
-	milestoneValue := extractor.get("Milestone")
-	if milestoneValue != "" && strings.ToLower(milestoneValue) != "false" {
-		task.IsMilestone = true
-	} else {
-		// Fallback to keyword detection
-		task.IsMilestone = r.isMilestoneTask(task.Name, task.Description)
-	}
+	task.Template = extractor.get("Template")
+	task.Tags = extractor.getTags("Tags")
 }
 
 // extractPhaseFields extracts phase and category information
@@ -499,38 +580,210 @@ func (r *Reader) extractPhaseFields(task *Task, extractor *fieldExtractor) {
 func (r *Reader) extractStatusFields(task *Task, extractor *fieldExtractor) {
 	task.Status = extractor.getWithDefault("Status", "Planned")
 	task.Assignee = extractor.get("Assignee")
+	task.Priority = extractor.get("Priority")
 	task.ParentID = extractor.get("Parent Task ID")
 }
 
+// extractProgressField parses the Progress column as a 0-100 percentage,
+// accepting an optional trailing percent sign
+func (r *Reader) extractProgressField(task *Task, extractor *fieldExtractor, rowNum int) error {
+	value := extractor.get("Progress")
+	if value == "" {
+		return nil
+	}
+
+	progress, err := strconv.Atoi(strings.TrimSpace(strings.TrimSuffix(value, "%")))
+	if err != nil {
+		return NewParseError(rowNum, "Progress", value, "progress must be a whole percentage", err)
+	}
+	if progress < 0 || progress > 100 {
+		return NewParseError(rowNum, "Progress", value, "progress must be between 0 and 100", nil)
+	}
+	task.Progress = progress
+	return nil
+}
+
+// extractDurationField parses the Duration column and the optional
+// Optimistic/Likely/Pessimistic estimate columns
+func (r *Reader) extractDurationField(task *Task, extractor *fieldExtractor, rowNum int) error {
+	var err error
+	if task.Duration, err = parseDaysField(extractor, "Duration", rowNum); err != nil {
+		return err
+	}
+
+	est := &task.Estimate
+	if est.Optimistic, err = parseDaysField(extractor, "Optimistic", rowNum); err != nil {
+		return err
+	}
+	if est.Likely, err = parseDaysField(extractor, "Likely", rowNum); err != nil {
+		return err
+	}
+	if est.Pessimistic, err = parseDaysField(extractor, "Pessimistic", rowNum); err != nil {
+		return err
+	}
+	if !est.IsSet() {
+		return nil
+	}
+
+	// Missing points collapse onto the ones given
+	if est.Likely == 0 {
+		est.Likely = est.Pessimistic
+	}
+	if est.Optimistic == 0 {
+		est.Optimistic = est.Likely
+	}
+	if est.Optimistic > est.Likely || est.Likely > est.Pessimistic {
+		return NewParseError(rowNum, "Pessimistic", strconv.Itoa(est.Pessimistic),
+			"estimates must satisfy optimistic <= likely <= pessimistic", nil)
+	}
+	return nil
+}
+
+// extractEffortField parses the Effort column as hours, accepting an optional
+// trailing "h"
+func (r *Reader) extractEffortField(task *Task, extractor *fieldExtractor, rowNum int) error {
+	value := extractor.get("Effort")
+	if value == "" {
+		return nil
+	}
+
+	hours, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(strings.ToLower(value), "h")), 64)
+	if err != nil {
+		return NewParseError(rowNum, "Effort", value, "effort must be a number of hours", err)
+	}
+	if hours < 0 {
+		return NewParseError(rowNum, "Effort", value, "effort cannot be negative", nil)
+	}
+	task.EffortHours = hours
+	return nil
+}
+
+// extractActualDaysField parses the Actual Days column as the days spent on
+// the task, accepting an optional trailing "d"
+func (r *Reader) extractActualDaysField(task *Task, extractor *fieldExtractor, rowNum int) error {
+	value := extractor.get("Actual Days")
+	if value == "" {
+		return nil
+	}
+
+	days, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(strings.ToLower(value), "d")), 64)
+	if err != nil {
+		return NewParseError(rowNum, "Actual Days", value, "actual days must be a number of days", err)
+	}
+	if days < 0 {
+		return NewParseError(rowNum, "Actual Days", value, "actual days cannot be negative", nil)
+	}
+	task.ActualDays = days
+	return nil
+}
+
+// parseDaysField parses a column as a whole number of days, accepting an
+// optional trailing "d". Empty columns yield zero.
+func parseDaysField(extractor *fieldExtractor, column string, rowNum int) (int, error) {
+	value := extractor.get(column)
+	if value == "" {
+		return 0, nil
+	}
+
+	days, err := strconv.Atoi(strings.TrimSpace(strings.TrimSuffix(strings.ToLower(value), "d")))
+	if err != nil {
+		return 0, NewParseError(rowNum, column, value, "must be a whole number of days", err)
+	}
+	if days < 1 {
+		return 0, NewParseError(rowNum, column, value, "must be at least one day", nil)
+	}
+	return days, nil
+}
+
+// dateFormatHint tells how to write a date the reader cannot parse
+const dateFormatHint = "expected a date such as 2025-03-14, 03/14/2025 or 2025-03-14 09:30"
+
 // extractDateFields parses date fields from the extractor
 func (r *Reader) extractDateFields(task *Task, extractor *fieldExtractor, rowNum int) error {
-	startDateStr := extractor.get("Start Date")
-	if startDateStr != "" {
-		startDate, err := r.parseDate(startDateStr)
-		if err != nil {
-			return NewParseError(rowNum, "Start Date", startDateStr, "invalid date format", err)
-		}
-		task.StartDate = startDate
+	startDate, startAt, err := r.parseDateColumn(extractor, "Start Date", rowNum)
+	if err != nil {
+		return err
 	}
+	task.startAt = startAt
+	task.StartDate, task.StartTime = splitTimeOfDay(startDate)
 
-	endDateStr := extractor.get("End Date")
-	if endDateStr != "" {
-		endDate, err := r.parseDate(endDateStr)
-		if err != nil {
-			return NewParseError(rowNum, "End Date", endDateStr, "invalid date format", err)
+	endDate, endAt, err := r.parseDateColumn(extractor, "End Date", rowNum)
+	if err != nil {
+		return err
+	}
+	task.endAt = endAt
+	task.EndDate, task.EndTime = splitTimeOfDay(endDate)
+
+	deadline, deadlineAt, err := r.parseDateColumn(extractor, "Deadline", rowNum)
+	if err != nil {
+		return err
+	}
+	task.Deadline, task.deadlineAt = deadline, deadlineAt
+
+	// Baseline dates accept either "Baseline Start" or "baseline_start" headers
+	for _, field := range []struct {
+		names []string
+		date  *time.Time
+		at    *time.Time
+	}{
+		{[]string{"Baseline Start", "baseline_start"}, &task.BaselineStart, &task.baselineStartAt},
+		{[]string{"Baseline End", "baseline_end"}, &task.BaselineEnd, &task.baselineEndAt},
+	} {
+		for _, name := range field.names {
+			if extractor.get(name) == "" {
+				continue
+			}
+			if *field.date, *field.at, err = r.parseDateColumn(extractor, name, rowNum); err != nil {
+				return err
+			}
+			break
 		}
-		task.EndDate = endDate
 	}
 
 	return nil
 }
 
-// validateDates validates that end date is not before start date
+// parseDateColumn parses a date column, zero when empty. A date written with a
+// UTC offset is put on its UTC wall clock and also returned as the instant,
+// for Config.ApplyTimezone.
+func (r *Reader) parseDateColumn(extractor *fieldExtractor, column string, rowNum int) (time.Time, time.Time, error) {
+	value := extractor.get(column)
+	if value == "" {
+		return time.Time{}, time.Time{}, nil
+	}
+	date, err := r.parseDate(value)
+	if err != nil {
+		return time.Time{}, time.Time{}, NewParseError(rowNum, column, value, dateFormatHint, err)
+	}
+	if hasUTCOffset(value) {
+		return wallClock(date, time.UTC), date, nil
+	}
+	return date, time.Time{}, nil
+}
+
+// splitTimeOfDay separates a timestamp into its day and the time into that day
+func splitTimeOfDay(t time.Time) (time.Time, time.Duration) {
+	day := dayOf(t)
+	return day, t.Sub(day)
+}
+
+// validateDates validates that end date is not before start date, nor the
+// end time before the start time of a task within one day
 func (r *Reader) validateDates(task Task) error {
+	if task.IsIntraDay() && task.EndTime <= task.StartTime {
+		return NewValidationError(
+			task.ID,
+			"End Date",
+			task.EndDate.Add(task.EndTime).Format(DateFormatMinute),
+			fmt.Sprintf("end time %s is not after start time %s",
+				task.EndDate.Add(task.EndTime).Format("15:04"),
+				task.StartDate.Add(task.StartTime).Format("15:04")),
+		)
+	}
 	if !task.StartDate.IsZero() && !task.EndDate.IsZero() && task.EndDate.Before(task.StartDate) {
 		return NewValidationError(
 			task.ID,
-			"Due Date",
+			"End Date",
 			task.EndDate.Format("2006-01-02"),
 			fmt.Sprintf("end date %s is before start date %s",
 				task.EndDate.Format("2006-01-02"),
@@ -0,0 +1,74 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReaderErrorLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.csv")
+	csv := "Task ID,Task,Start Date,End Date,Objective\n" +
+		"A,First,2025-03-03,2025-03-07,\"spans\ntwo lines\"\n" +
+		"\n" +
+		"B,Second,2025-03-10,31/02/2025,\n"
+	if err := os.WriteFile(path, []byte(csv), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewReader(path)
+	tasks, err := r.ReadTasks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 1 {
+		t.Fatalf("tasks = %d, want 1", len(tasks))
+	}
+	if tasks[0].line != 2 {
+		t.Fatalf("first task on line %d, want line 2", tasks[0].line)
+	}
+
+	var parseErr *ParseError
+	if errs := r.ParseErrors(); len(errs) != 1 || !errors.As(errs[0], &parseErr) {
+		t.Fatalf("parse errors = %v, want one ParseError", errs)
+	}
+	want := "row 5, column End Date: cannot parse '31/02/2025': " + dateFormatHint
+	if got := parseErr.Error(); got != want {
+		t.Errorf("error = %q, want %q", got, want)
+	}
+}
+
+func TestReaderPlacesZonedDatesAlike(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.csv")
+	// 22:00 at UTC-5 is 03:00 the next day in UTC
+	csv := "Task ID,Task,Start Date,End Date,Deadline,Baseline End\n" +
+		"A,First,2025-03-03T22:00:00-05:00,2025-03-07T22:00:00-05:00,2025-03-07T22:00:00-05:00,2025-03-07T22:00:00-05:00\n"
+	if err := os.WriteFile(path, []byte(csv), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tasks, err := NewReader(path).ReadTasks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 1 {
+		t.Fatalf("tasks = %d, want 1", len(tasks))
+	}
+	task := tasks[0]
+	for name, got := range map[string]time.Time{"Deadline": task.Deadline, "Baseline End": task.BaselineEnd} {
+		if !dayOf(got).Equal(task.EndDate) {
+			t.Errorf("%s on %s, want the End Date's day %s", name, got.Format(DateFormatISO), task.EndDate.Format(DateFormatISO))
+		}
+	}
+
+	cfg := &Config{Timezone: "America/New_York"}
+	if err := cfg.ApplyTimezone(tasks); err != nil {
+		t.Fatal(err)
+	}
+	if want := date(t, "2025-03-07"); !tasks[0].EndDate.Equal(want) || !dayOf(tasks[0].Deadline).Equal(want) {
+		t.Errorf("in New York: End Date %s, Deadline %s, want both on %s", tasks[0].EndDate.Format(DateFormatISO),
+			tasks[0].Deadline.Format(DateFormatISO), want.Format(DateFormatISO))
+	}
+}
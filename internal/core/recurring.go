@@ -0,0 +1,152 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecurringMilestone describes a milestone repeated on a calendar rule, such
+// as a committee meeting on the third Friday of every month
+type RecurringMilestone struct {
+	// Name is the title of every generated milestone
+	Name string `yaml:"name"`
+	// ID prefixes the generated task IDs; defaults to the name in lower case
+	ID string `yaml:"id"`
+	// Every is the rule: "friday" (weekly), "3rd friday" or "last friday"
+	// (monthly), or "15th" (monthly on a day of the month)
+	Every string `yaml:"every"`
+	// Start and End (YYYY-MM-DD) bound the repetition; they default to the
+	// first and last day of the plan
+	Start string `yaml:"start"`
+	End   string `yaml:"end"`
+	// Phase and Category are copied onto the generated milestones
+	Phase    string `yaml:"phase"`
+	Category string `yaml:"category"`
+	// SkipHolidays leaves out occurrences falling on a holiday
+	SkipHolidays bool `yaml:"skip_holidays"`
+}
+
+var (
+	weekdayRule  = regexp.MustCompile(`^(?:every\s+)?(?:(1st|2nd|3rd|4th|5th|first|second|third|fourth|fifth|last)\s+)?([a-z]+?)s?(?:\s+of\s+(?:the|each|every)\s+month)?$`)
+	monthDayRule = regexp.MustCompile(`^(?:every\s+)?(?:day\s+)?(\d{1,2})(?:st|nd|rd|th)?(?:\s+of\s+(?:the|each|every)\s+month)?$`)
+)
+
+// ordinals maps rule ordinals to the week of the month; -1 is the last week
+var ordinals = map[string]int{
+	"1st": 1, "first": 1, "2nd": 2, "second": 2, "3rd": 3, "third": 3,
+	"4th": 4, "fourth": 4, "5th": 5, "fifth": 5, "last": -1,
+}
+
+// parseRecurrence turns a rule into a predicate over days
+func parseRecurrence(rule string) (func(time.Time) bool, error) {
+	rule = strings.Join(strings.Fields(strings.ToLower(rule)), " ")
+
+	if m := monthDayRule.FindStringSubmatch(rule); m != nil {
+		day, _ := strconv.Atoi(m[1])
+		if day < 1 || day > 31 {
+			return nil, fmt.Errorf("day of month %d out of range", day)
+		}
+		return func(t time.Time) bool { return t.Day() == day }, nil
+	}
+
+	if m := weekdayRule.FindStringSubmatch(rule); m != nil {
+		weekday, ok := parseWeekday(m[2])
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %q", m[2])
+		}
+		if m[1] == "" {
+			return func(t time.Time) bool { return t.Weekday() == weekday }, nil
+		}
+		n := ordinals[m[1]]
+		return func(t time.Time) bool {
+			if t.Weekday() != weekday {
+				return false
+			}
+			if n < 0 {
+				return t.AddDate(0, 0, 7).Month() != t.Month()
+			}
+			return (t.Day()-1)/7+1 == n
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unrecognized rule %q", rule)
+}
+
+// parseWeekday accepts full or three-letter English weekday names
+func parseWeekday(s string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if s == name || s == name[:3] {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// AddRecurringMilestones returns the tasks followed by a milestone for every
+// occurrence of each configured recurring milestone. Generated tasks are
+// marked Recurring so they can be styled apart from planned milestones.
+func (c *Config) AddRecurringMilestones(tasks []Task) ([]Task, error) {
+	if len(c.RecurringMilestones) == 0 {
+		return tasks, nil
+	}
+
+	var planStart, planEnd time.Time
+	if len(tasks) > 0 {
+		r := CalculateDateRange(tasks)
+		planStart, planEnd = dayOf(r.Earliest), dayOf(r.Latest)
+	}
+
+	for i, rm := range c.RecurringMilestones {
+		field := fmt.Sprintf("recurring_milestones[%d]", i)
+		if strings.TrimSpace(rm.Name) == "" {
+			return nil, NewConfigError("", field+".name", "recurring milestone needs a name", nil)
+		}
+		matches, err := parseRecurrence(rm.Every)
+		if err != nil {
+			return nil, NewConfigError("", field+".every", err.Error(), err)
+		}
+
+		start, end := planStart, planEnd
+		if s := strings.TrimSpace(rm.Start); s != "" {
+			if start, err = time.Parse(DateFormatISO, s); err != nil {
+				return nil, NewConfigError("", field+".start", "invalid date "+s, err)
+			}
+		}
+		if s := strings.TrimSpace(rm.End); s != "" {
+			if end, err = time.Parse(DateFormatISO, s); err != nil {
+				return nil, NewConfigError("", field+".end", "invalid date "+s, err)
+			}
+		}
+		if start.IsZero() || end.IsZero() {
+			continue
+		}
+
+		prefix := strings.TrimSpace(rm.ID)
+		if prefix == "" {
+			prefix = strings.Join(strings.Fields(strings.ToLower(rm.Name)), "-")
+		}
+		for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+			if !matches(d) {
+				continue
+			}
+			if _, holiday := c.HolidayOn(d); holiday && rm.SkipHolidays {
+				continue
+			}
+			tasks = append(tasks, Task{
+				ID:          prefix + "-" + d.Format(DateFormatISO),
+				Name:        strings.TrimSpace(rm.Name),
+				StartDate:   d,
+				EndDate:     d,
+				Phase:       rm.Phase,
+				Category:    rm.Category,
+				IsMilestone: true,
+				Recurring:   true,
+			})
+		}
+	}
+	return tasks, nil
+}
@@ -0,0 +1,218 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ScenarioConfig configures the what-if comparison against a proposed plan
+type ScenarioConfig struct {
+	// Proposed lists the CSV file(s) of the proposed plan, comma-separated
+	Proposed string `yaml:"proposed"`
+}
+
+// ProposedFiles returns the proposed plan's CSV paths, or nil when no
+// comparison is configured
+func (s ScenarioConfig) ProposedFiles() []string {
+	var files []string
+	for _, f := range strings.Split(s.Proposed, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// TaskShift compares one task between the current and the proposed plan.
+// Added tasks only exist in the proposed plan, removed tasks only in the
+// current one.
+type TaskShift struct {
+	ID            string
+	Name          string
+	IsMilestone   bool
+	CurrentStart  time.Time
+	CurrentEnd    time.Time
+	ProposedStart time.Time
+	ProposedEnd   time.Time
+	Added         bool
+	Removed       bool
+}
+
+// StartShift returns how many days later the task starts in the proposed plan
+func (s TaskShift) StartShift() int {
+	return daysBetween(s.CurrentStart, s.ProposedStart)
+}
+
+// EndShift returns how many days later the task ends in the proposed plan
+func (s TaskShift) EndShift() int {
+	return daysBetween(s.CurrentEnd, s.ProposedEnd)
+}
+
+// Changed reports whether the task was added, removed or moved
+func (s TaskShift) Changed() bool {
+	return s.Added || s.Removed || s.StartShift() != 0 || s.EndShift() != 0
+}
+
+// Label describes the change in a few characters, e.g. "+5d", "-3d", "new"
+func (s TaskShift) Label() string {
+	switch {
+	case s.Added:
+		return "new"
+	case s.Removed:
+		return "removed"
+	}
+	days := s.EndShift()
+	if days == 0 {
+		days = s.StartShift()
+	}
+	if days > 0 {
+		return fmt.Sprintf("+%dd", days)
+	}
+	return fmt.Sprintf("%dd", days)
+}
+
+// String describes the change for console reports
+func (s TaskShift) String() string {
+	switch {
+	case s.Added:
+		return fmt.Sprintf("%s %s: new, %s -> %s", s.ID, s.Name,
+			s.ProposedStart.Format("2006-01-02"), s.ProposedEnd.Format("2006-01-02"))
+	case s.Removed:
+		return fmt.Sprintf("%s %s: removed (was %s -> %s)", s.ID, s.Name,
+			s.CurrentStart.Format("2006-01-02"), s.CurrentEnd.Format("2006-01-02"))
+	}
+	return fmt.Sprintf("%s %s: ends %s -> %s (%s)", s.ID, s.Name,
+		s.CurrentEnd.Format("2006-01-02"), s.ProposedEnd.Format("2006-01-02"), s.Label())
+}
+
+// CompareSchedules matches tasks of the current and proposed plans by ID (or
+// by name when a task has no ID) and returns the ones that changed, in
+// current-plan order followed by added tasks
+func CompareSchedules(current, proposed []Task) []TaskShift {
+	byKey := make(map[string]Task, len(proposed))
+	for _, t := range proposed {
+		byKey[taskKey(t)] = t
+	}
+
+	var shifts []TaskShift
+	seen := make(map[string]bool, len(current))
+	for _, t := range current {
+		k := taskKey(t)
+		seen[k] = true
+		shift := TaskShift{
+			ID: t.ID, Name: t.Name, IsMilestone: t.IsMilestone,
+			CurrentStart: dayOf(t.StartDate), CurrentEnd: dayOf(t.EndDate),
+		}
+		if p, ok := byKey[k]; ok {
+			shift.ProposedStart, shift.ProposedEnd = dayOf(p.StartDate), dayOf(p.EndDate)
+		} else {
+			shift.Removed = true
+		}
+		if shift.Changed() {
+			shifts = append(shifts, shift)
+		}
+	}
+	for _, t := range proposed {
+		if seen[taskKey(t)] {
+			continue
+		}
+		shifts = append(shifts, TaskShift{
+			ID: t.ID, Name: t.Name, IsMilestone: t.IsMilestone,
+			ProposedStart: dayOf(t.StartDate), ProposedEnd: dayOf(t.EndDate), Added: true,
+		})
+	}
+
+	return shifts
+}
+
+// taskKey identifies a task across two versions of a plan: by ID, or by name
+// when it has no ID
+func taskKey(t Task) string {
+	if id := strings.TrimSpace(t.ID); id != "" {
+		return "id:" + id
+	}
+	return "name:" + strings.ToLower(strings.TrimSpace(t.Name))
+}
+
+// ShiftedMonths returns the first day of every month whose tasks change
+// between the plans, in order: the months a moved task leaves and enters
+func ShiftedMonths(shifts []TaskShift) []time.Time {
+	months := make(map[time.Time]bool)
+	mark := func(start, end time.Time) {
+		if start.IsZero() || end.IsZero() {
+			return
+		}
+		for m := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC); !m.After(end); m = m.AddDate(0, 1, 0) {
+			months[m] = true
+		}
+	}
+	for _, s := range shifts {
+		if s.Added || s.Removed {
+			mark(s.CurrentStart, s.CurrentEnd)
+			mark(s.ProposedStart, s.ProposedEnd)
+			continue
+		}
+		// Only the days gained or lost change a month's content
+		if s.StartShift() != 0 {
+			mark(minTime(s.CurrentStart, s.ProposedStart), maxTime(s.CurrentStart, s.ProposedStart))
+		}
+		if s.EndShift() != 0 {
+			mark(minTime(s.CurrentEnd, s.ProposedEnd), maxTime(s.CurrentEnd, s.ProposedEnd))
+		}
+	}
+
+	list := make([]time.Time, 0, len(months))
+	for m := range months {
+		list = append(list, m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Before(list[j]) })
+	return list
+}
+
+// SetScenarioShifts stores the comparison against the proposed plan
+func (c *Config) SetScenarioShifts(shifts []TaskShift) {
+	c.scenarioShifts = shifts
+}
+
+// ScenarioShifts returns the tasks that change in the proposed plan
+func (c *Config) ScenarioShifts() []TaskShift {
+	return c.scenarioShifts
+}
+
+// ScenarioShift returns the proposed change of the task with the given ID
+func (c *Config) ScenarioShift(id string) (TaskShift, bool) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return TaskShift{}, false
+	}
+	for _, s := range c.scenarioShifts {
+		if strings.TrimSpace(s.ID) == id {
+			return s, true
+		}
+	}
+	return TaskShift{}, false
+}
+
+// daysBetween returns the whole days from a to b, or 0 when either is unset
+func daysBetween(a, b time.Time) int {
+	if a.IsZero() || b.IsZero() {
+		return 0
+	}
+	return int(dayOf(b).Sub(dayOf(a)).Hours() / 24)
+}
+
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,46 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompareSchedules(t *testing.T) {
+	current := []Task{
+		{ID: "A", StartDate: date(t, "2026-01-05"), EndDate: date(t, "2026-01-20")},
+		{ID: "B", StartDate: date(t, "2026-01-21"), EndDate: date(t, "2026-01-30")},
+		{ID: "M", IsMilestone: true, StartDate: date(t, "2026-01-31"), EndDate: date(t, "2026-01-31")},
+	}
+	proposed := []Task{
+		{ID: "A", StartDate: date(t, "2026-01-05"), EndDate: date(t, "2026-01-20")},
+		{ID: "M", IsMilestone: true, StartDate: date(t, "2026-02-10"), EndDate: date(t, "2026-02-10")},
+		{ID: "C", StartDate: date(t, "2026-02-01"), EndDate: date(t, "2026-02-09")},
+	}
+
+	shifts := CompareSchedules(current, proposed)
+	labels := make(map[string]string)
+	for _, s := range shifts {
+		labels[s.ID] = s.Label()
+	}
+	want := map[string]string{"B": "removed", "M": "+10d", "C": "new"}
+	if len(labels) != len(want) {
+		t.Fatalf("expected %d changes, got %v", len(want), labels)
+	}
+	for id, label := range want {
+		if labels[id] != label {
+			t.Errorf("%s: expected %q, got %q", id, label, labels[id])
+		}
+	}
+
+	months := ShiftedMonths(shifts)
+	if len(months) != 2 || months[0].Month() != time.January || months[1].Month() != time.February {
+		t.Errorf("expected January and February to change, got %v", months)
+	}
+
+	// A status change alone is a modification in a diff but not a shift
+	proposed[0].Status = "completed"
+	diffs := DiffTasks(current, proposed)
+	if len(diffs) != 4 || !diffs[0].Modified() || len(diffs[0].Changes) != 1 || diffs[0].Changes[0].Field != "Status" {
+		t.Errorf("expected A's status change first among 4 diffs, got %+v", diffs)
+	}
+}
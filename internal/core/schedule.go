@@ -0,0 +1,229 @@
+package core
+
+import (
+	"strings"
+	"time"
+)
+
+// SchedulingConfig configures dependency-driven scheduling
+type SchedulingConfig struct {
+	// StartDate anchors tasks without dated predecessors (YYYY-MM-DD)
+	StartDate string `yaml:"start_date"`
+	// ShowCPM adds late start and float columns to the task index
+	ShowCPM bool `yaml:"show_cpm"`
+}
+
+// ScheduleTasks computes the dates of tasks that have a Duration but no
+// explicit dates. Tasks are visited in dependency order, so each one starts
+// the day after its latest predecessor finishes, shifted by any lag or lead
+// on the dependency; tasks without predecessors
+// start on the project start date. Durations count working days when
+// working_days.durations or holidays.exclude_from_duration is set. Calling it
+// again recomputes every auto-scheduled task, e.g. once holidays are loaded.
+func (c *Config) ScheduleTasks(tasks []Task) error {
+	pending := 0
+	for i := range tasks {
+		if tasks[i].computedStart {
+			tasks[i].StartDate = time.Time{}
+		}
+		if tasks[i].computedEnd {
+			tasks[i].EndDate = time.Time{}
+		}
+		if tasks[i].needsScheduling() {
+			pending++
+		}
+	}
+	if pending == 0 {
+		return nil
+	}
+
+	projectStart, err := c.projectStart(tasks)
+	if err != nil {
+		return err
+	}
+
+	order, err := topologicalOrder(tasks)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[string]*Task, len(tasks))
+	for i := range tasks {
+		if id := strings.TrimSpace(tasks[i].ID); id != "" {
+			byID[id] = &tasks[i]
+		}
+	}
+
+	for _, idx := range order {
+		task := &tasks[idx]
+		if !task.needsScheduling() {
+			continue
+		}
+
+		switch {
+		case task.StartDate.IsZero() && !task.EndDate.IsZero():
+			// Deadline given: work back from the end date
+			task.StartDate = c.addWorkingDays(task.EndDate, -(task.Duration - 1))
+			task.computedStart = true
+		default:
+			if task.StartDate.IsZero() {
+				task.computedStart = true
+				start := projectStart
+				for _, dep := range task.Dependencies {
+					pred, ok := byID[strings.TrimSpace(dep)]
+					if !ok || pred.StartDate.IsZero() || pred.EndDate.IsZero() {
+						continue
+					}
+					kind := task.DepType(dep)
+					next := pred.EndDate.AddDate(0, 0, 1)
+					if kind.FromStart() {
+						next = pred.StartDate
+					}
+					if lag := task.Lag(dep); lag != 0 {
+						if kind.FromStart() {
+							next = c.addWorkingDays(next, lag)
+						} else {
+							// Count from the finish, so a predecessor ending
+							// before a weekend still waits lag working days
+							next = c.addWorkingDays(pred.EndDate, lag+1)
+						}
+					}
+					if kind.ToFinish() {
+						// next bounds the day after the finish; step back over the effort
+						next = c.addWorkingDays(next, -task.Duration)
+					}
+					if next.After(start) {
+						start = next
+					}
+				}
+				task.StartDate = c.nextWorkingDay(start)
+			}
+			task.EndDate = c.addWorkingDays(task.StartDate, task.Duration-1)
+			task.computedEnd = true
+		}
+		task.AutoScheduled = true
+	}
+
+	return nil
+}
+
+// needsScheduling reports whether the task has an effort but lacks a date
+func (t Task) needsScheduling() bool {
+	return t.Duration > 0 && (t.StartDate.IsZero() || t.EndDate.IsZero())
+}
+
+// projectStart returns the configured scheduling start, the earliest explicit
+// start date, or the as-of date, in that order
+func (c *Config) projectStart(tasks []Task) (time.Time, error) {
+	if value := strings.TrimSpace(c.Scheduling.StartDate); value != "" {
+		start, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return time.Time{}, NewConfigError("", "scheduling.start_date", "expected YYYY-MM-DD", err)
+		}
+		return start, nil
+	}
+
+	var earliest time.Time
+	for _, t := range tasks {
+		if !t.StartDate.IsZero() && !t.computedStart && (earliest.IsZero() || t.StartDate.Before(earliest)) {
+			earliest = t.StartDate
+		}
+	}
+	if !earliest.IsZero() {
+		return earliest, nil
+	}
+	return c.GetAsOfDate(), nil
+}
+
+// topologicalOrder returns task indexes so every task follows the tasks it
+// depends on. Unknown dependency IDs are ignored; a cycle is an error.
+func topologicalOrder(tasks []Task) ([]int, error) {
+	index := make(map[string]int, len(tasks))
+	for i := range tasks {
+		if id := strings.TrimSpace(tasks[i].ID); id != "" {
+			index[id] = i
+		}
+	}
+
+	indegree := make([]int, len(tasks))
+	dependents := make([][]int, len(tasks))
+	for i := range tasks {
+		for _, dep := range tasks[i].Dependencies {
+			if j, ok := index[strings.TrimSpace(dep)]; ok && j != i {
+				indegree[i]++
+				dependents[j] = append(dependents[j], i)
+			}
+		}
+	}
+
+	// Kahn's algorithm, seeded in CSV order for stable results
+	queue := make([]int, 0, len(tasks))
+	for i, n := range indegree {
+		if n == 0 {
+			queue = append(queue, i)
+		}
+	}
+
+	order := make([]int, 0, len(tasks))
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		order = append(order, i)
+		for _, j := range dependents[i] {
+			if indegree[j]--; indegree[j] == 0 {
+				queue = append(queue, j)
+			}
+		}
+	}
+
+	if len(order) < len(tasks) {
+		var stuck []string
+		for i, n := range indegree {
+			if n > 0 {
+				stuck = append(stuck, tasks[i].ID)
+			}
+		}
+		return nil, NewValidationError(strings.Join(stuck, ", "), "Dependencies", "",
+			"circular dependency prevents scheduling")
+	}
+	return order, nil
+}
+
+// isWorkingDay reports whether effort is spent on the day
+func (c *Config) isWorkingDay(t time.Time) bool {
+	if c.WorkingDays.Durations && IsWeekend(t) {
+		return false
+	}
+	if c.Holidays.ExcludeFromDuration {
+		if _, ok := c.HolidayOn(t); ok {
+			return false
+		}
+	}
+	if _, ok := c.BlackoutOn(t); ok {
+		return false
+	}
+	return true
+}
+
+// nextWorkingDay returns t, or the first working day after it
+func (c *Config) nextWorkingDay(t time.Time) time.Time {
+	for i := 0; i < 366 && !c.isWorkingDay(t); i++ {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t
+}
+
+// addWorkingDays moves n working days from t (backwards when n is negative)
+func (c *Config) addWorkingDays(t time.Time, n int) time.Time {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		t = t.AddDate(0, 0, step)
+		if c.isWorkingDay(t) {
+			n--
+		}
+	}
+	return t
+}
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleTasks(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.WorkingDays.Durations = true
+	tasks := []Task{
+		{ID: "B", Duration: 3, Dependencies: []string{"A"}},
+		{ID: "A", StartDate: date(t, "2026-07-13"), EndDate: date(t, "2026-07-15")},
+		{ID: "C", Duration: 5, Dependencies: []string{"A", "B"}},
+	}
+
+	if err := cfg.ScheduleTasks(tasks); err != nil {
+		t.Fatalf("ScheduleTasks: %v", err)
+	}
+
+	// B follows A (Thu 16 - Mon 20), C follows B over the weekend
+	if !tasks[0].StartDate.Equal(date(t, "2026-07-16")) || !tasks[0].EndDate.Equal(date(t, "2026-07-20")) {
+		t.Errorf("B scheduled %s..%s", tasks[0].StartDate.Format("2006-01-02"), tasks[0].EndDate.Format("2006-01-02"))
+	}
+	if !tasks[2].StartDate.Equal(date(t, "2026-07-21")) || !tasks[2].EndDate.Equal(date(t, "2026-07-27")) {
+		t.Errorf("C scheduled %s..%s", tasks[2].StartDate.Format("2006-01-02"), tasks[2].EndDate.Format("2006-01-02"))
+	}
+	if tasks[1].AutoScheduled || !tasks[2].AutoScheduled {
+		t.Error("only undated tasks should be marked auto-scheduled")
+	}
+
+	// A three-day lag leaves Thu, Fri and Mon free after A
+	id, lag := ParseDependency("A+3d")
+	lagged := []Task{
+		{ID: "A", StartDate: date(t, "2026-07-13"), EndDate: date(t, "2026-07-15")},
+		{ID: "D", Duration: 1, Dependencies: []string{id}, Lags: map[string]int{id: lag}},
+	}
+	if err := cfg.ScheduleTasks(lagged); err != nil {
+		t.Fatalf("ScheduleTasks: %v", err)
+	}
+	if !lagged[1].StartDate.Equal(date(t, "2026-07-21")) {
+		t.Errorf("D with lag scheduled %s", lagged[1].StartDate.Format("2006-01-02"))
+	}
+	if id, lag := ParseDependency("T-1"); id != "T-1" || lag != 0 {
+		t.Errorf("ID with a dash parsed as %q %+d", id, lag)
+	}
+
+	// Start-to-start lets F begin with A; finish-to-finish ends G with A
+	kind, spec := ParseDependencyType("ss:A")
+	if kind != StartToStart || spec != "A" {
+		t.Errorf("ParseDependencyType(ss:A) = %s %q", kind, spec)
+	}
+	typed := []Task{
+		{ID: "A", StartDate: date(t, "2026-07-13"), EndDate: date(t, "2026-07-15")},
+		{ID: "F", Duration: 2, Dependencies: []string{"A"}, DepTypes: map[string]DependencyType{"A": StartToStart}},
+		{ID: "G", Duration: 2, Dependencies: []string{"A"}, DepTypes: map[string]DependencyType{"A": FinishToFinish}},
+	}
+	if err := cfg.ScheduleTasks(typed); err != nil {
+		t.Fatalf("ScheduleTasks: %v", err)
+	}
+	if !typed[1].StartDate.Equal(date(t, "2026-07-13")) {
+		t.Errorf("SS task F scheduled from %s", typed[1].StartDate.Format("2006-01-02"))
+	}
+	if !typed[2].EndDate.Equal(date(t, "2026-07-15")) {
+		t.Errorf("FF task G ends %s", typed[2].EndDate.Format("2006-01-02"))
+	}
+
+	// A blackout on Wed 15 - Fri 17 pushes the three days of E to Mon 20 - Wed 22,
+	// and A, dated across it in the CSV, is flagged
+	blackout := DefaultConfig()
+	blackout.WorkingDays.Durations = true
+	blackout.Blackouts = []Blackout{{Name: "Conference", Start: "2026-07-15", End: "2026-07-17"}}
+	if err := blackout.LoadBlackouts(); err != nil {
+		t.Fatalf("LoadBlackouts: %v", err)
+	}
+	away := []Task{
+		{ID: "A", StartDate: date(t, "2026-07-13"), EndDate: date(t, "2026-07-15")},
+		{ID: "E", Duration: 3, Dependencies: []string{"A"}},
+	}
+	if err := blackout.ScheduleTasks(away); err != nil {
+		t.Fatalf("ScheduleTasks: %v", err)
+	}
+	if !away[1].StartDate.Equal(date(t, "2026-07-20")) || !away[1].EndDate.Equal(date(t, "2026-07-22")) {
+		t.Errorf("E scheduled %s..%s", away[1].StartDate.Format("2006-01-02"), away[1].EndDate.Format("2006-01-02"))
+	}
+	if issues := blackout.BlackoutConflicts(away); len(issues) != 1 || issues[0].Type != "blackout_conflict" {
+		t.Errorf("BlackoutConflicts = %v, want one conflict for A", issues)
+	}
+
+	cyclic := []Task{
+		{ID: "X", Duration: 1, Dependencies: []string{"Y"}},
+		{ID: "Y", Duration: 1, Dependencies: []string{"X"}},
+	}
+	if err := cfg.ScheduleTasks(cyclic); err == nil {
+		t.Error("expected an error for circular dependencies")
+	}
+}
+
+// date parses a YYYY-MM-DD test date
+func date(t *testing.T, s string) time.Time {
+	t.Helper()
+	d, err := time.Parse(DateFormatISO, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
@@ -0,0 +1,201 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+)
+
+// configRange is the allowed span of a numeric config key
+type configRange struct {
+	min, max float64
+}
+
+// atLeastZero bounds the counts and sizes that only need to be non-negative
+var atLeastZero = configRange{0, math.Inf(1)}
+
+// configRanges bounds the numeric config keys, by key path; each value of a
+// list key is checked
+var configRanges = map[string]configRange{
+	"weekly.start_hour":                                  {0, 23},
+	"weekly.end_hour":                                    {1, 24},
+	"daily.notes_lines":                                  atLeastZero,
+	"gantt.rows_per_page":                                atLeastZero,
+	"leveling.max_tasks_per_week":                        atLeastZero,
+	"simulation.trials":                                  atLeastZero,
+	"simulation.percentiles":                             {1, 99},
+	"capacity.hours_per_week":                            atLeastZero,
+	"splitting.max_days":                                 atLeastZero,
+	"baseline.tolerance_days":                            atLeastZero,
+	"layout.palette.saturation":                          {0, 1},
+	"layout.palette.lightness":                           {0, 1},
+	"layout.shading.past":                                {0, 1},
+	"layout.shading.blackout":                            {0, 1},
+	"layout.task_styling.background_opacity":             {0, 100},
+	"layout.task_styling.border_opacity":                 {0, 100},
+	"layout.task_styling.milestone.background_opacity":   {0, 100},
+	"layout.task_styling.milestone.border_opacity":       {0, 100},
+	"layout.calendar.max_tasks_per_day":                  atLeastZero,
+	"layout.stacking.base_height":                        atLeastZero,
+	"layout.stacking.min_height":                         atLeastZero,
+	"layout.stacking.max_height":                         atLeastZero,
+	"layout.layout_engine.initial_y_position_multiplier": {0, 10},
+	"layout.layout_engine.task_height_multiplier":        {0, 10},
+	"layout.layout_engine.max_task_width_days":           {0, 10},
+	"layout.layout_engine.duration_long_multiplier":      {0, 10},
+	"layout.layout_engine.duration_short_multiplier":     {0, 10},
+	"layout.layout_engine.milestone_weight_multiplier":   {0, 10},
+	"layout.layout_engine.category_weight_multiplier":    {0, 10},
+}
+
+// decodeConfigFile overlays the YAML content of a config file on cfg, with
+// its ${VAR} references expanded. Keys that are not config fields, values of
+// the wrong type and numbers out of range are errors giving their line,
+// instead of falling back to defaults.
+func (cfg *Config) decodeConfigFile(file string, bts []byte) error {
+	bts, err := expandEnv(file, bts, os.LookupEnv)
+	if err != nil {
+		return err
+	}
+	if err := yaml.UnmarshalWithOptions(bts, cfg, yaml.Strict()); err != nil {
+		return schemaError(file, err)
+	}
+	return checkConfigRanges(file, bts)
+}
+
+// schemaError describes a decoding error of a config file at its line
+func schemaError(file string, err error) error {
+	var yerr yaml.Error
+	if !errors.As(err, &yerr) {
+		return NewConfigError(file, "", "invalid YAML", err)
+	}
+
+	msg := yerr.GetMessage()
+	var unknown *yaml.UnknownFieldError
+	var typeErr *yaml.TypeError
+	switch {
+	case errors.As(err, &unknown) && yerr.GetToken() != nil:
+		msg = fmt.Sprintf("unknown key %q", yerr.GetToken().Value)
+	case errors.As(err, &typeErr) && typeErr.Token != nil:
+		got := strconv.Quote(typeErr.Token.Value)
+		if k := typeErr.SrcType; k != nil && (k.Kind() == reflect.Slice || k.Kind() == reflect.Map) {
+			got = describeType(k)
+		}
+		msg = fmt.Sprintf("expected %s, got %s", describeType(typeErr.DstType), got)
+	}
+
+	configErr := NewConfigError(file, "", msg, nil)
+	if tk := yerr.GetToken(); tk != nil && tk.Position != nil {
+		configErr.Line = tk.Position.Line
+	}
+	return configErr
+}
+
+// describeType names the YAML values that decode into t
+func describeType(t reflect.Type) string {
+	if t == nil {
+		return "another value"
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "true or false"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "a whole number"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "text"
+	case reflect.Slice, reflect.Array:
+		return "a list"
+	case reflect.Map, reflect.Struct:
+		return "a mapping of keys"
+	case reflect.Pointer:
+		return describeType(t.Elem())
+	}
+	return t.String()
+}
+
+// checkConfigRanges returns the numbers of a config file outside the range
+// of their key, one error each in line order
+func checkConfigRanges(file string, bts []byte) error {
+	f, err := parser.ParseBytes(bts, 0)
+	if err != nil {
+		return schemaError(file, err)
+	}
+
+	keys := make([]string, 0, len(configRanges))
+	for key := range configRanges {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []*ConfigError
+	for _, key := range keys {
+		path, err := yaml.PathString("$." + key)
+		if err != nil {
+			return err
+		}
+		node, err := path.FilterFile(f)
+		if err != nil || node == nil {
+			continue
+		}
+		values := []ast.Node{node}
+		if seq, ok := node.(*ast.SequenceNode); ok {
+			values = seq.Values
+		}
+		r := configRanges[key]
+		for _, v := range values {
+			tk := v.GetToken()
+			n, err := strconv.ParseFloat(tk.Value, 64)
+			if err != nil || (n >= r.min && n <= r.max) {
+				continue
+			}
+			configErr := NewConfigError(file, key, fmt.Sprintf("%s is out of range, %s", tk.Value, r), nil)
+			configErr.Line = tk.Position.Line
+			errs = append(errs, configErr)
+		}
+	}
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Line < errs[j].Line })
+	joined := make([]error, len(errs))
+	for i, err := range errs {
+		joined[i] = err
+	}
+	return errors.Join(joined...)
+}
+
+// checkOverrideRange returns an error when a numeric override value, or one
+// of a list, is outside the range of its key
+func checkOverrideRange(key string, value any) error {
+	r, ok := configRanges[key]
+	if !ok {
+		return nil
+	}
+	values, ok := value.([]any)
+	if !ok {
+		values = []any{value}
+	}
+	for _, v := range values {
+		text := fmt.Sprint(v)
+		if n, err := strconv.ParseFloat(text, 64); err == nil && (n < r.min || n > r.max) {
+			return NewConfigError("", key, fmt.Sprintf("%s is out of range, %s", text, r), nil)
+		}
+	}
+	return nil
+}
+
+// String describes the range, e.g. "must be between 0 and 23"
+func (r configRange) String() string {
+	if math.IsInf(r.max, 1) {
+		return fmt.Sprintf("must be at least %g", r.min)
+	}
+	return fmt.Sprintf("must be between %g and %g", r.min, r.max)
+}
@@ -0,0 +1,45 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigSchema(t *testing.T) {
+	if _, err := NewConfig(filepath.Join("..", "..", "input_data", "config.yaml")); err != nil {
+		t.Fatalf("example config: %v", err)
+	}
+
+	cases := []struct {
+		yml  string
+		line int
+		want string
+	}{
+		{"weekly:\n  start_hour: 8\n  strat_hour: 9\n", 3, `unknown key "strat_hour"`},
+		{"layout:\n  stacking:\n    min_height: tall\n", 3, `expected a number, got "tall"`},
+		{"daily:\n  skip_empty: true\nweekly:\n  end_hour: 30\n", 4, "must be between 1 and 24"},
+		{"simulation:\n  percentiles:\n    - 50\n    - 0\n", 4, "must be between 1 and 99"},
+	}
+	for _, tc := range cases {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(path, []byte(tc.yml), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := NewConfig(path)
+		var configErr *ConfigError
+		if !errors.As(err, &configErr) {
+			t.Errorf("%q: got %v, want a config error", tc.yml, err)
+			continue
+		}
+		if configErr.Line != tc.line || !strings.Contains(configErr.Message, tc.want) {
+			t.Errorf("%q: line %d %q, want line %d %q", tc.yml, configErr.Line, configErr.Message, tc.line, tc.want)
+		}
+	}
+
+	if _, err := NewConfigWithOverrides([]string{"layout.palette.saturation=1.5"}); err == nil {
+		t.Error("saturation 1.5: expected an out of range error")
+	}
+}
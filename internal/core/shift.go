@@ -0,0 +1,166 @@
+package core
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var shiftPattern = regexp.MustCompile(`^([+-]?\d+)\s*([dDwW]?)$`)
+
+// ParseShift reads a shift such as "14d", "-7d" or "2w" as a number of days;
+// a bare number counts days
+func ParseShift(s string) (int, error) {
+	m := shiftPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, fmt.Errorf("invalid shift %q: use days or weeks such as 14d, -7d or 2w", s)
+	}
+	n, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid shift %q: %w", s, err)
+	}
+	if strings.EqualFold(m[2], "w") {
+		n *= 7
+	}
+	return n, nil
+}
+
+// matchesPhase reports whether a phase is selected by a filter: an empty
+// filter selects every phase, otherwise the phase must equal or start with it,
+// ignoring case
+func matchesPhase(phase, filter string) bool {
+	filter = strings.ToLower(strings.TrimSpace(filter))
+	return filter == "" || strings.HasPrefix(strings.ToLower(strings.TrimSpace(phase)), filter)
+}
+
+// ShiftCSV copies a task CSV from src to dst with the Start and End Date of
+// every row in the selected phase moved by the given number of days, and
+// returns how many rows changed. Dates keep the format they were written in;
+// deadlines, baselines and other columns are copied unchanged. src and dst may
+// be the same file.
+func ShiftCSV(src, dst string, days int, phase string) (int, error) {
+	records, lines, err := readCSVRecords(src)
+	if err != nil || len(records) == 0 {
+		return 0, err
+	}
+
+	header := records[0]
+	phaseCol := csvColumn(header, "Phase")
+	startCol, endCol := csvColumn(header, "Start Date"), csvColumn(header, "End Date")
+
+	shifted := 0
+	for i := 1; i < len(records); i++ {
+		record := records[i]
+		if strings.TrimSpace(phase) != "" && (phaseCol < 0 || phaseCol >= len(record) || !matchesPhase(record[phaseCol], phase)) {
+			continue
+		}
+		changed := false
+		for _, col := range []int{startCol, endCol} {
+			if col < 0 || col >= len(record) || strings.TrimSpace(record[col]) == "" {
+				continue
+			}
+			moved, err := shiftDate(record[col], days)
+			if err != nil {
+				return 0, NewParseError(lines[i][col], header[col], record[col], "cannot shift date", err)
+			}
+			record[col] = moved
+			changed = true
+		}
+		if changed {
+			shifted++
+		}
+	}
+
+	return shifted, writeCSVRecords(dst, records)
+}
+
+// shiftDate moves a date written in any supported format by days, keeping its format
+func shiftDate(value string, days int) (string, error) {
+	value = strings.TrimSpace(value)
+	for _, format := range supportedDateFormats {
+		if t, err := time.Parse(format, value); err == nil {
+			return t.AddDate(0, 0, days).Format(format), nil
+		}
+	}
+	return "", fmt.Errorf("unsupported date format")
+}
+
+// readCSVRecords reads every record of a CSV file, allowing ragged rows,
+// with the file line of each field, as the reader reports errors
+func readCSVRecords(path string) ([][]string, [][]int, error) {
+	in, err := os.Open(path)
+	if err != nil {
+		return nil, nil, NewFileError(path, "open", err)
+	}
+	defer in.Close()
+
+	reader := csv.NewReader(in)
+	reader.FieldsPerRecord = -1
+	var records [][]string
+	var lines [][]int
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, NewFileError(path, "read CSV", err)
+		}
+		fieldLines := make([]int, len(record))
+		for i := range record {
+			fieldLines[i], _ = reader.FieldPos(i)
+		}
+		records = append(records, record)
+		lines = append(lines, fieldLines)
+	}
+	return records, lines, nil
+}
+
+// writeCSVRecords writes records to a CSV file, replacing it only once they
+// are all written so that a failed write leaves the file as it was
+func writeCSVRecords(path string, records [][]string) error {
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	out, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return NewFileError(path, "create", err)
+	}
+	tmp := out.Name()
+	defer os.Remove(tmp)
+
+	writer := csv.NewWriter(out)
+	if err := writer.WriteAll(records); err != nil {
+		out.Close()
+		return NewFileError(path, "write CSV", err)
+	}
+	if err := out.Chmod(mode); err != nil {
+		out.Close()
+		return NewFileError(path, "write CSV", err)
+	}
+	if err := out.Close(); err != nil {
+		return NewFileError(path, "write CSV", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return NewFileError(path, "replace", err)
+	}
+	return nil
+}
+
+// csvColumn returns the index of the named header column, ignoring case, or -1
+func csvColumn(header []string, name string) int {
+	for i, field := range header {
+		if strings.EqualFold(strings.TrimSpace(field), name) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,45 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestShiftCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.csv")
+	csv := "Task ID,Task,Start Date,End Date\n" +
+		"A,\"Two\nlines\",2025-03-01,2025-03-05\n" +
+		"\n" +
+		"B,Bad,2025-03-02,31/02/2025\n"
+	if err := os.WriteFile(path, []byte(csv), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := ShiftCSV(path, path, 7, "")
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Row != 5 {
+		t.Fatalf("error = %v, want one on line 5", err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != csv {
+		t.Errorf("failed shift changed the file:\n%s", got)
+	}
+
+	if err := os.WriteFile(path, []byte("Task ID,Start Date,End Date\nA,2025-03-01,2025-03-05\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := ShiftCSV(path, path, 7, ""); err != nil || n != 1 {
+		t.Fatalf("shifted %d rows, %v; want 1", n, err)
+	}
+	got, _ := os.ReadFile(path)
+	if string(got) != "Task ID,Start Date,End Date\nA,2025-03-08,2025-03-12\n" {
+		t.Errorf("shifted file:\n%s", got)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+		t.Errorf("mode = %v, want the file's 0600", info.Mode().Perm())
+	}
+}
@@ -0,0 +1,196 @@
+package core
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SimulationConfig configures the Monte Carlo schedule risk simulation
+type SimulationConfig struct {
+	// Trials is the number of random schedules to draw
+	Trials int `yaml:"trials"`
+	// Seed makes runs reproducible; the same seed gives the same dates
+	Seed int64 `yaml:"seed"`
+	// Percentiles are the confidence levels reported for each milestone
+	Percentiles []int `yaml:"percentiles"`
+}
+
+// Simulation defaults
+const (
+	defaultSimulationTrials = 1000
+	defaultSimulationSeed   = 1
+)
+
+// GetSimulationTrials returns the number of trials to run
+func (c *Config) GetSimulationTrials() int {
+	if c.Simulation.Trials > 0 {
+		return c.Simulation.Trials
+	}
+	return defaultSimulationTrials
+}
+
+// GetSimulationPercentiles returns the reported confidence levels, ascending
+func (c *Config) GetSimulationPercentiles() []int {
+	var levels []int
+	for _, p := range c.Simulation.Percentiles {
+		if p > 0 && p < 100 {
+			levels = append(levels, p)
+		}
+	}
+	if len(levels) == 0 {
+		return []int{50, 80, 95}
+	}
+	sort.Ints(levels)
+	return levels
+}
+
+// SimulatedDate is the distribution of one milestone's finish over all trials
+type SimulatedDate struct {
+	ID       string
+	Name     string
+	Planned  time.Time
+	Finishes []time.Time // Sorted ascending
+}
+
+// Percentile returns the date the milestone is reached by in p percent of trials
+func (s SimulatedDate) Percentile(p int) time.Time {
+	if len(s.Finishes) == 0 {
+		return s.Planned
+	}
+	i := int(math.Ceil(float64(p)/100*float64(len(s.Finishes)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(s.Finishes) {
+		i = len(s.Finishes) - 1
+	}
+	return s.Finishes[i]
+}
+
+// OnTimeProbability returns the share of trials finishing by the planned date
+func (s SimulatedDate) OnTimeProbability() float64 {
+	if len(s.Finishes) == 0 {
+		return 1
+	}
+	n := sort.Search(len(s.Finishes), func(i int) bool {
+		return s.Finishes[i].After(s.Planned)
+	})
+	return float64(n) / float64(len(s.Finishes))
+}
+
+// SimulationResult holds the simulated finish dates of the plan's milestones
+type SimulationResult struct {
+	Trials      int
+	Percentiles []int
+	Milestones  []SimulatedDate
+}
+
+// SimulateSchedule runs Monte Carlo trials over the plan. Each trial draws a
+// duration for every task with a three-point estimate from a triangular
+// distribution (tasks without one keep their planned length), then starts each
+// task at its planned start or after its latest predecessor. The finish dates
+// of every milestone are collected across trials.
+func (c *Config) SimulateSchedule(tasks []Task) (SimulationResult, error) {
+	result := SimulationResult{Trials: c.GetSimulationTrials(), Percentiles: c.GetSimulationPercentiles()}
+
+	order, err := topologicalOrder(tasks)
+	if err != nil {
+		return result, err
+	}
+
+	index := make(map[string]int, len(tasks))
+	var milestones []int
+	for i, t := range tasks {
+		if t.StartDate.IsZero() || t.EndDate.IsZero() {
+			continue
+		}
+		if id := strings.TrimSpace(t.ID); id != "" {
+			index[id] = i
+		}
+		if t.IsMilestone {
+			milestones = append(milestones, i)
+		}
+	}
+	if len(milestones) == 0 {
+		return result, nil
+	}
+
+	seed := c.Simulation.Seed
+	if seed == 0 {
+		seed = defaultSimulationSeed
+	}
+	rng := rand.New(rand.NewSource(seed))
+
+	finishes := make([][]time.Time, len(tasks))
+	start := make([]time.Time, len(tasks))
+	finish := make([]time.Time, len(tasks))
+	for trial := 0; trial < result.Trials; trial++ {
+		for _, i := range order {
+			t := tasks[i]
+			if t.StartDate.IsZero() || t.EndDate.IsZero() {
+				continue
+			}
+
+			days := int(dayOf(t.EndDate).Sub(dayOf(t.StartDate)).Hours()/24) + 1
+			if t.Estimate.IsSet() {
+				days = sampleTriangular(rng, t.Estimate)
+			}
+
+			start[i] = dayOf(t.StartDate)
+			for _, dep := range t.Dependencies {
+				j, ok := index[strings.TrimSpace(dep)]
+				if !ok || j == i {
+					continue
+				}
+				if next := successorStart(t.DepType(dep), start[j], finish[j], t.Lag(dep), days); next.After(start[i]) {
+					start[i] = next
+				}
+			}
+			finish[i] = start[i].AddDate(0, 0, days-1)
+		}
+
+		for _, i := range milestones {
+			finishes[i] = append(finishes[i], finish[i])
+		}
+	}
+
+	for _, i := range milestones {
+		sort.Slice(finishes[i], func(a, b int) bool { return finishes[i][a].Before(finishes[i][b]) })
+		result.Milestones = append(result.Milestones, SimulatedDate{
+			ID:       tasks[i].ID,
+			Name:     tasks[i].Name,
+			Planned:  dayOf(tasks[i].EndDate),
+			Finishes: finishes[i],
+		})
+	}
+	sort.SliceStable(result.Milestones, func(a, b int) bool {
+		return result.Milestones[a].Planned.Before(result.Milestones[b].Planned)
+	})
+
+	return result, nil
+}
+
+// sampleTriangular draws a whole number of days from the triangular
+// distribution spanned by the estimate
+func sampleTriangular(rng *rand.Rand, e Estimate) int {
+	lo, mode, hi := float64(e.Optimistic), float64(e.Likely), float64(e.Pessimistic)
+	if hi <= lo {
+		return e.Likely
+	}
+
+	u := rng.Float64()
+	var days float64
+	if u < (mode-lo)/(hi-lo) {
+		days = lo + math.Sqrt(u*(hi-lo)*(mode-lo))
+	} else {
+		days = hi - math.Sqrt((1-u)*(hi-lo)*(hi-mode))
+	}
+
+	if n := int(math.Round(days)); n > 0 {
+		return n
+	}
+	return 1
+}
@@ -0,0 +1,29 @@
+package core
+
+import "testing"
+
+func TestSimulateSchedule(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Simulation = SimulationConfig{Trials: 200, Seed: 7}
+	tasks := []Task{
+		{ID: "W", StartDate: date(t, "2026-03-02"), EndDate: date(t, "2026-03-11"), Estimate: Estimate{Optimistic: 8, Likely: 10, Pessimistic: 20}},
+		{ID: "M", IsMilestone: true, StartDate: date(t, "2026-03-12"), EndDate: date(t, "2026-03-12"), Dependencies: []string{"W"}},
+	}
+
+	result, err := cfg.SimulateSchedule(tasks)
+	if err != nil {
+		t.Fatalf("SimulateSchedule: %v", err)
+	}
+	if len(result.Milestones) != 1 || len(result.Milestones[0].Finishes) != 200 {
+		t.Fatalf("expected 200 finishes for one milestone, got %+v", result.Milestones)
+	}
+
+	m := result.Milestones[0]
+	earliest, latest := date(t, "2026-03-10"), date(t, "2026-03-22")
+	if m.Percentile(1).Before(earliest) || m.Percentile(99).After(latest) {
+		t.Errorf("finishes outside the estimate range: %s..%s", m.Percentile(1).Format("2006-01-02"), m.Percentile(99).Format("2006-01-02"))
+	}
+	if p := m.OnTimeProbability(); p <= 0 || p >= 1 {
+		t.Errorf("expected a partial on-time probability, got %.2f", p)
+	}
+}
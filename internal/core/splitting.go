@@ -0,0 +1,226 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SplittingConfig configures splitting very long tasks into segments
+type SplittingConfig struct {
+	// Enabled splits tasks longer than MaxDays before layout
+	Enabled bool `yaml:"enabled"`
+	// MaxDays is the length in calendar days above which a task is split
+	MaxDays int `yaml:"max_days"`
+	// Align chooses the segment boundaries: "month" cuts at the first of each
+	// month, "phase" cuts where another phase of the plan begins
+	Align string `yaml:"align"`
+}
+
+// Segment boundary modes for SplittingConfig.Align
+const (
+	SplitAlignMonth = "month"
+	SplitAlignPhase = "phase"
+)
+
+// defaultSplitMaxDays is used when splitting is enabled without a threshold
+const defaultSplitMaxDays = 60
+
+// GetSplitMaxDays returns the length above which tasks are split
+func (c *Config) GetSplitMaxDays() int {
+	if c.Splitting.MaxDays > 0 {
+		return c.Splitting.MaxDays
+	}
+	return defaultSplitMaxDays
+}
+
+// SplitLongTasks returns the tasks with every task longer than the threshold
+// replaced by consecutive segments. Segment IDs are the original ID with a
+// "/n" suffix; each segment depends on the one before it, the first keeps
+// the original dependencies, and tasks depending on the original depend on
+// the last segment instead. Milestones are never split.
+func (c *Config) SplitLongTasks(tasks []Task) []Task {
+	if !c.Splitting.Enabled {
+		return tasks
+	}
+
+	var phaseStarts []time.Time
+	if strings.EqualFold(strings.TrimSpace(c.Splitting.Align), SplitAlignPhase) {
+		phaseStarts = phaseBoundaries(tasks)
+	}
+
+	maxDays := c.GetSplitMaxDays()
+	renamed := make(map[string]string)      // Split ID to its last segment
+	renamedFirst := make(map[string]string) // Split ID to its first segment
+	result := make([]Task, 0, len(tasks))
+	for _, t := range tasks {
+		if t.IsMilestone || t.StartDate.IsZero() || t.EndDate.IsZero() ||
+			daysBetween(t.StartDate, t.EndDate)+1 <= maxDays {
+			result = append(result, t)
+			continue
+		}
+
+		var cuts []time.Time
+		if phaseStarts != nil {
+			cuts = cutsWithin(t, phaseStarts)
+		} else {
+			cuts = monthCuts(t)
+		}
+		if len(cuts) == 0 {
+			result = append(result, t)
+			continue
+		}
+
+		segments := splitTask(t, cuts)
+		if id := strings.TrimSpace(t.ID); id != "" {
+			renamed[id] = segments[len(segments)-1].ID
+			renamedFirst[id] = segments[0].ID
+		}
+		result = append(result, segments...)
+	}
+
+	// Successors of a split task follow its last segment, or its first for
+	// start-to-start and start-to-finish dependencies. Dependencies, lags and
+	// types are copied so the input tasks are left untouched.
+	for i := range result {
+		deps := make([]string, len(result[i].Dependencies))
+		lags := make(map[string]int, len(result[i].Lags))
+		kinds := make(map[string]DependencyType, len(result[i].DepTypes))
+		changed := false
+		for j, dep := range result[i].Dependencies {
+			dep = strings.TrimSpace(dep)
+			deps[j] = dep
+			kind := result[i].DepType(dep)
+			target := renamed
+			if kind.FromStart() {
+				target = renamedFirst
+			}
+			if seg, ok := target[dep]; ok {
+				deps[j] = seg
+				changed = true
+			}
+			if lag := result[i].Lags[dep]; lag != 0 {
+				lags[deps[j]] = lag
+			}
+			if kind != FinishToStart {
+				kinds[deps[j]] = kind
+			}
+		}
+		if changed {
+			result[i].Dependencies = deps
+			result[i].Lags = lags
+			result[i].DepTypes = kinds
+		}
+	}
+
+	return result
+}
+
+// splitTask cuts a task into segments starting at each cut date
+func splitTask(t Task, cuts []time.Time) []Task {
+	starts := append([]time.Time{dayOf(t.StartDate)}, cuts...)
+	id := strings.TrimSpace(t.ID)
+	segments := make([]Task, 0, len(starts))
+	for n, start := range starts {
+		seg := t
+		seg.SplitFrom = id
+		seg.ID = fmt.Sprintf("%s/%d", id, n+1)
+		seg.Name = fmt.Sprintf("%s (%d/%d)", t.Name, n+1, len(starts))
+		seg.StartDate = start
+		seg.EndDate = dayOf(t.EndDate)
+		if n+1 < len(starts) {
+			seg.EndDate = starts[n+1].AddDate(0, 0, -1)
+		}
+
+		// Copy slices and maps so segments never share them
+		seg.Dependencies = nil
+		seg.Lags = nil
+		seg.DepTypes = nil
+		if n > 0 {
+			seg.Dependencies = []string{segments[n-1].ID}
+		}
+		// The first segment keeps the dependencies on the task's start, the
+		// last those on its finish (finish-to-finish and start-to-finish)
+		for _, dep := range t.Dependencies {
+			dep = strings.TrimSpace(dep)
+			kind := t.DepType(dep)
+			first, last := n == 0, n+1 == len(starts)
+			if kind.ToFinish() && !last || !kind.ToFinish() && !first {
+				continue
+			}
+			seg.Dependencies = append(seg.Dependencies, dep)
+			if lag := t.Lag(dep); lag != 0 {
+				if seg.Lags == nil {
+					seg.Lags = make(map[string]int)
+				}
+				seg.Lags[dep] = lag
+			}
+			if kind != FinishToStart {
+				if seg.DepTypes == nil {
+					seg.DepTypes = make(map[string]DependencyType)
+				}
+				seg.DepTypes[dep] = kind
+			}
+		}
+
+		// Deadlines and baselines belong to the ends of the original task,
+		// the days spent on it to the first segment
+		if n > 0 {
+			seg.BaselineStart = time.Time{}
+			seg.ActualDays = 0
+		}
+		if n+1 < len(starts) {
+			seg.Deadline = time.Time{}
+			seg.BaselineEnd = time.Time{}
+		}
+		segments = append(segments, seg)
+	}
+	return segments
+}
+
+// monthCuts returns the first of every month after the task's start month
+// up to its end
+func monthCuts(t Task) []time.Time {
+	start, end := dayOf(t.StartDate), dayOf(t.EndDate)
+	var cuts []time.Time
+	for m := time.Date(start.Year(), start.Month()+1, 1, 0, 0, 0, 0, time.UTC); !m.After(end); m = m.AddDate(0, 1, 0) {
+		cuts = append(cuts, m)
+	}
+	return cuts
+}
+
+// phaseBoundaries returns the start date of every phase, in order
+func phaseBoundaries(tasks []Task) []time.Time {
+	first := make(map[string]time.Time)
+	for _, t := range tasks {
+		if t.StartDate.IsZero() {
+			continue
+		}
+		if s, ok := first[t.Phase]; !ok || t.StartDate.Before(s) {
+			first[t.Phase] = dayOf(t.StartDate)
+		}
+	}
+	seen := make(map[time.Time]bool)
+	boundaries := make([]time.Time, 0, len(first))
+	for _, s := range first {
+		if !seen[s] {
+			seen[s] = true
+			boundaries = append(boundaries, s)
+		}
+	}
+	sort.Slice(boundaries, func(i, j int) bool { return boundaries[i].Before(boundaries[j]) })
+	return boundaries
+}
+
+// cutsWithin returns the boundaries strictly inside the task
+func cutsWithin(t Task, boundaries []time.Time) []time.Time {
+	start, end := dayOf(t.StartDate), dayOf(t.EndDate)
+	var cuts []time.Time
+	for _, b := range boundaries {
+		if b.After(start) && !b.After(end) {
+			cuts = append(cuts, b)
+		}
+	}
+	return cuts
+}
@@ -0,0 +1,26 @@
+package core
+
+import "testing"
+
+func TestSplitLongTasks(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Splitting = SplittingConfig{Enabled: true, MaxDays: 40, Align: SplitAlignMonth}
+	tasks := []Task{
+		{ID: "A", Name: "Long", StartDate: date(t, "2026-01-15"), EndDate: date(t, "2026-03-10")},
+		{ID: "B", Name: "Next", StartDate: date(t, "2026-03-11"), EndDate: date(t, "2026-03-20"), Dependencies: []string{"A"}},
+	}
+
+	split := cfg.SplitLongTasks(tasks)
+	if len(split) != 4 {
+		t.Fatalf("expected 3 segments and B, got %d tasks", len(split))
+	}
+	if split[1].ID != "A/2" || !split[1].StartDate.Equal(date(t, "2026-02-01")) || !split[1].EndDate.Equal(date(t, "2026-02-28")) {
+		t.Errorf("second segment %s %s..%s", split[1].ID, split[1].StartDate.Format("2006-01-02"), split[1].EndDate.Format("2006-01-02"))
+	}
+	if len(split[2].Dependencies) != 1 || split[2].Dependencies[0] != "A/2" {
+		t.Errorf("segments should chain, got %v", split[2].Dependencies)
+	}
+	if split[3].Dependencies[0] != "A/3" || tasks[1].Dependencies[0] != "A" {
+		t.Errorf("successor should follow the last segment without changing the input, got %v", split[3].Dependencies)
+	}
+}
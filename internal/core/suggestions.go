@@ -0,0 +1,174 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxSuggestionRounds bounds how often dependency and overlap fixes are
+// re-applied while they keep pushing each other
+const maxSuggestionRounds = 5
+
+// Suggestion is a concrete date shift that resolves a scheduling conflict
+type Suggestion struct {
+	TaskID   string
+	Name     string
+	From     time.Time // Current start
+	To       time.Time // Suggested start
+	NewEnd   time.Time // End after the move; the task keeps its length
+	Reason   string
+	Critical bool // The task has Critical priority
+}
+
+// String phrases the suggestion as an instruction
+func (s Suggestion) String() string {
+	return fmt.Sprintf("move %s start to %s (ends %s): %s",
+		s.TaskID, s.To.Format("2006-01-02"), s.NewEnd.Format("2006-01-02"), s.Reason)
+}
+
+// SuggestReschedule proposes start dates that resolve two kinds of critical
+// overlap: tasks starting before a predecessor (plus lag) has finished, and
+// Critical-priority tasks of the same assignee running at the same time. Moves
+// keep each task's length and are propagated to successors. The tasks are
+// not modified.
+func SuggestReschedule(tasks []Task) ([]Suggestion, error) {
+	order, err := topologicalOrder(tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	work := make([]Task, len(tasks))
+	copy(work, tasks)
+	index := make(map[string]int, len(work))
+	for i := range work {
+		if id := strings.TrimSpace(work[i].ID); id != "" {
+			index[id] = i
+		}
+	}
+
+	reasons := make(map[int]string)
+	shift := func(i int, start time.Time, reason string) {
+		length := work[i].EndDate.Sub(work[i].StartDate)
+		work[i].StartDate = start
+		work[i].EndDate = start.Add(length)
+		if _, ok := reasons[i]; !ok {
+			reasons[i] = reason
+		}
+	}
+	dated := func(t Task) bool {
+		return !t.StartDate.IsZero() && !t.EndDate.IsZero()
+	}
+
+	// Dependencies: start after every predecessor finishes
+	followDependencies := func() bool {
+		changed := false
+		for _, i := range order {
+			if !dated(work[i]) {
+				continue
+			}
+			for _, dep := range work[i].Dependencies {
+				j, ok := index[strings.TrimSpace(dep)]
+				if !ok || j == i || !dated(work[j]) {
+					continue
+				}
+				days := daysBetween(work[i].StartDate, work[i].EndDate) + 1
+				earliest := successorStart(work[i].DepType(dep), work[j].StartDate, work[j].EndDate, work[i].Lag(dep), days)
+				if dayOf(work[i].StartDate).Before(earliest) {
+					shift(i, earliest, fmt.Sprintf("starts before %s finishes", work[j].ID))
+					changed = true
+				}
+			}
+		}
+		return changed
+	}
+
+	// Critical work of one person runs one task at a time
+	separateCritical := func() bool {
+		byAssignee := make(map[string][]int)
+		for i, t := range work {
+			if dated(t) && !t.IsMilestone && priorityRank(t.Priority) == 0 && strings.TrimSpace(t.Assignee) != "" {
+				person := strings.ToLower(strings.TrimSpace(t.Assignee))
+				byAssignee[person] = append(byAssignee[person], i)
+			}
+		}
+		changed := false
+		for _, group := range byAssignee {
+			sort.SliceStable(group, func(a, b int) bool {
+				return work[group[a]].StartDate.Before(work[group[b]].StartDate)
+			})
+			for k := 1; k < len(group); k++ {
+				prev, cur := work[group[k-1]], work[group[k]]
+				if !dayOf(cur.StartDate).After(dayOf(prev.EndDate)) {
+					shift(group[k], dayOf(prev.EndDate).AddDate(0, 0, 1),
+						fmt.Sprintf("overlaps critical task %s of %s", prev.ID, strings.TrimSpace(cur.Assignee)))
+					changed = true
+				}
+			}
+		}
+		return changed
+	}
+
+	for round := 0; round < maxSuggestionRounds; round++ {
+		deps := followDependencies()
+		critical := separateCritical()
+		if !deps && !critical {
+			break
+		}
+	}
+
+	var suggestions []Suggestion
+	for i := range work {
+		if !dayOf(work[i].StartDate).Equal(dayOf(tasks[i].StartDate)) {
+			suggestions = append(suggestions, Suggestion{
+				TaskID:   work[i].ID,
+				Name:     work[i].Name,
+				From:     dayOf(tasks[i].StartDate),
+				To:       dayOf(work[i].StartDate),
+				NewEnd:   dayOf(work[i].EndDate),
+				Reason:   reasons[i],
+				Critical: priorityRank(work[i].Priority) == 0,
+			})
+		}
+	}
+	sort.SliceStable(suggestions, func(a, b int) bool {
+		return suggestions[a].To.Before(suggestions[b].To)
+	})
+	return suggestions, nil
+}
+
+// PatchCSV copies a task CSV from src to dst with the suggested start and end
+// dates applied to matching Task IDs, and returns how many rows changed.
+// Other columns and rows are copied unchanged.
+func PatchCSV(src, dst string, suggestions []Suggestion) (int, error) {
+	records, _, err := readCSVRecords(src)
+	if err != nil || len(records) == 0 {
+		return 0, err
+	}
+
+	idCol := csvColumn(records[0], "Task ID")
+	startCol, endCol := csvColumn(records[0], "Start Date"), csvColumn(records[0], "End Date")
+	if idCol < 0 || startCol < 0 || endCol < 0 {
+		return 0, nil
+	}
+
+	byID := make(map[string]Suggestion, len(suggestions))
+	for _, s := range suggestions {
+		byID[strings.TrimSpace(s.TaskID)] = s
+	}
+
+	patched := 0
+	for _, record := range records[1:] {
+		if idCol >= len(record) || startCol >= len(record) || endCol >= len(record) {
+			continue
+		}
+		if s, ok := byID[strings.TrimSpace(record[idCol])]; ok {
+			record[startCol] = s.To.Format("2006-01-02")
+			record[endCol] = s.NewEnd.Format("2006-01-02")
+			patched++
+		}
+	}
+
+	return patched, writeCSVRecords(dst, records)
+}
@@ -17,9 +17,87 @@ type Task struct {
 	Description  string
 	Status       string   // * Added: Task status (Planned, In Progress, Completed, etc.)
 	Assignee     string   // * Added: Task assignee
+	Priority     string   // Priority (Critical, High, Medium, Low), used when leveling workload
 	ParentID     string   // * Added: Parent task ID for hierarchical relationships
 	Dependencies []string // * Added: List of task IDs this task depends on
 	IsMilestone  bool     // * Added: Whether this is a milestone task
+	Progress     int      // Completion percentage (0-100) from the optional Progress column
+	Duration     int      // Effort in days from the optional Duration column, used to schedule undated tasks
+	Estimate     Estimate // Optional three-point duration estimate for risk simulation
+	EffortHours  float64  // Hours of work from the optional Effort column, checked against weekly capacity
+	ActualDays   float64  // Days spent so far from the optional Actual Days column, the actual cost of earned value
+
+	// StartTime and EndTime are the times of day given in the Start and End
+	// Date columns (e.g. "2026-05-04 09:30"); StartDate and EndDate keep the
+	// days. Both are zero for tasks given in whole days.
+	StartTime time.Duration
+	EndTime   time.Duration
+	// startAt, endAt, deadlineAt and the baseline instants keep dates written
+	// with a UTC offset as instants, for Config.ApplyTimezone
+	startAt, endAt, deadlineAt     time.Time
+	baselineStartAt, baselineEndAt time.Time
+
+	// Lags holds the finish-to-start lag in days per predecessor ID, from
+	// dependency entries such as "T12+3d"; negative values are leads
+	Lags map[string]int
+	// DepTypes holds the relationship per predecessor ID when it is not
+	// finish-to-start, from dependency entries such as "SS:T4"
+	DepTypes map[string]DependencyType
+
+	// Deadline is the optional hard due date from the Deadline column
+	Deadline time.Time
+
+	// BaselineStart and BaselineEnd are the originally planned dates, from the
+	// Baseline Start/End columns or a baseline file
+	BaselineStart time.Time
+	BaselineEnd   time.Time
+
+	// SplitFrom is the ID of the task this segment was split from, if any
+	SplitFrom string
+
+	// Template names the task template the row expands to, from the optional
+	// Template column
+	Template string
+
+	// Tags are free-form labels from the optional Tags column, separated by
+	// commas or semicolons
+	Tags []string
+
+	// Recurring marks milestones generated from recurring_milestones
+	Recurring bool
+
+	// AutoScheduled marks tasks whose dates were computed from Duration and
+	// dependencies rather than read from the CSV
+	AutoScheduled bool
+	// columns holds the CSV row by lower-case column name, for milestone rules
+	columns map[string]string
+	// line is the line of the CSV file the task's row starts on
+	line int
+	// computedStart and computedEnd record which dates the scheduler filled in
+	computedStart, computedEnd bool
+}
+
+// HasTimes reports whether the task was given with times of day
+func (t Task) HasTimes() bool {
+	return t.StartTime > 0 || t.EndTime > 0
+}
+
+// IsIntraDay reports whether the task starts and ends at given times on the same day
+func (t Task) IsIntraDay() bool {
+	return t.HasTimes() && !t.StartDate.IsZero() && t.StartDate.Equal(t.EndDate)
+}
+
+// Estimate is a three-point duration estimate in days, from the Optimistic,
+// Likely and Pessimistic columns
+type Estimate struct {
+	Optimistic  int
+	Likely      int
+	Pessimistic int
+}
+
+// IsSet reports whether the task carries a usable estimate
+func (e Estimate) IsSet() bool {
+	return e.Pessimistic > 0
 }
 
 // DateRange represents the earliest and latest dates from the task data
@@ -34,68 +112,20 @@ type MonthYear struct {
 	Month time.Month
 }
 
-// TaskCategory represents a task category with visual and organizational properties
-type TaskCategory struct {
-	Name        string
-	DisplayName string
-	Color       string
-	Description string
+// GenerateCategoryColor returns the color declared for the category in the
+// config or assigned to it by AssignCategoryColors, or creates a consistent
+// color based on the category name
+func (cfg *Config) GenerateCategoryColor(category string) string {
+	return cfg.styles().generateColor(category)
 }
 
-// Predefined task categories with their properties
-var (
-	CategoryPROPOSAL = TaskCategory{
-		Name:        "PROPOSAL",
-		DisplayName: "Proposal",
-		Color:       GenerateCategoryColor("PROPOSAL"),
-		Description: "PhD proposal related tasks",
-	}
-
-	CategoryLASER = TaskCategory{
-		Name:        "LASER",
-		DisplayName: "Laser System",
-		Color:       GenerateCategoryColor("LASER"),
-		Description: "Laser system setup and maintenance",
-	}
-
-	CategoryIMAGING = TaskCategory{
-		Name:        "IMAGING",
-		DisplayName: "Imaging",
-		Color:       GenerateCategoryColor("IMAGING"),
-		Description: "Imaging experiments and data collection",
-	}
-
-	CategoryADMIN = TaskCategory{
-		Name:        "ADMIN",
-		DisplayName: "Administrative",
-		Color:       GenerateCategoryColor("ADMIN"),
-		Description: "Administrative tasks and paperwork",
+// generateColor returns the declared or assigned color of a category, or
+// one from the palette based on its name
+func (s *categoryStyles) generateColor(category string) string {
+	if color, ok := s.color(category); ok {
+		return color
 	}
 
-	CategoryDISSERTATION = TaskCategory{
-		Name:        "DISSERTATION",
-		DisplayName: "Dissertation",
-		Color:       GenerateCategoryColor("DISSERTATION"),
-		Description: "Dissertation writing and defense",
-	}
-
-	CategoryRESEARCH = TaskCategory{
-		Name:        "RESEARCH",
-		DisplayName: "Research",
-		Color:       GenerateCategoryColor("RESEARCH"),
-		Description: "General research activities",
-	}
-
-	CategoryPUBLICATION = TaskCategory{
-		Name:        "PUBLICATION",
-		DisplayName: "Publication",
-		Color:       GenerateCategoryColor("PUBLICATION"),
-		Description: "Publication and manuscript writing",
-	}
-)
-
-// GenerateCategoryColor creates a consistent, visually distinct color based on the category name
-func GenerateCategoryColor(category string) string {
 	// Dynamic color assignment using golden angle for maximum visual distinction
 	// This ensures each unique category gets a unique, well-distributed color
 
@@ -116,12 +146,18 @@ func GenerateCategoryColor(category string) string {
 	hue := float64(hash%360) * 137.5
 	hue = hue - float64(int(hue/360.0)*360) // Keep hue in 0-360 range
 
-	// Optimized saturation and lightness for accessibility and visual appeal
-	saturation := 0.75 // High saturation for vibrancy
-	lightness := 0.65  // Balanced lightness for good contrast
+	// Saturation and lightness come from the theme's palette, unless it is
+	// a fixed color-blind safe one
+	palette := s.palette
+	switch palette.Scheme {
+	case PaletteOkabeIto:
+		return palette.color(hash%len(okabeIto), len(okabeIto))
+	case PaletteViridis:
+		return palette.color(hash%360, 360)
+	}
 
 	// Convert HSL to RGB
-	r, g, b := hslToRgb(hue, saturation, lightness)
+	r, g, b := hslToRgb(hue, palette.Saturation, palette.Lightness)
 
 	// Convert to hex
 	return fmt.Sprintf("#%02X%02X%02X", r, g, b)
@@ -174,8 +210,6 @@ func hueToRgb(p, q, t float64) float64 {
 	return p
 }
 
-// GetCategory returns the TaskCategory for a given category name
-// GetAllCategories returns all predefined categories
 // TaskRenderer represents visual rendering properties for tasks
 type TaskRenderer struct {
 	TaskID      string
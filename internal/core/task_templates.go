@@ -0,0 +1,137 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TemplateStep is one task generated from a task template
+type TemplateStep struct {
+	// ID is appended to the row's Task ID to form the step's ID
+	ID string `yaml:"id"`
+	// Name is appended to the row's task name
+	Name string `yaml:"name"`
+	// Offset is the number of days after the row's start at which the step
+	// starts; gaps and overlaps between steps become lags
+	Offset int `yaml:"offset"`
+	// Days is the length of the step; milestones take one day
+	Days int `yaml:"days"`
+	// Milestone marks the step as a milestone
+	Milestone bool `yaml:"milestone"`
+	// Category overrides the row's category
+	Category string `yaml:"category"`
+}
+
+// length returns the step's length in days, at least one
+func (s TemplateStep) length() int {
+	if s.Days < 1 {
+		return 1
+	}
+	return s.Days
+}
+
+// TaskTemplate looks up a configured template by name, ignoring case
+func (c *Config) TaskTemplate(name string) ([]TemplateStep, bool) {
+	name = strings.TrimSpace(name)
+	for key, steps := range c.TaskTemplates {
+		if strings.EqualFold(strings.TrimSpace(key), name) {
+			return steps, true
+		}
+	}
+	return nil, false
+}
+
+// ExpandTemplates replaces every task naming a template in its Template
+// column with the template's steps. The steps copy the row's phase, status
+// and assignment and are scheduled by duration one after another. The first
+// starts Offset days after the row's Start Date, or after the row's
+// dependencies when it has none; tasks depending on the row depend on its
+// last step, which also takes the row's deadline.
+func (c *Config) ExpandTemplates(tasks []Task) ([]Task, error) {
+	expanded := make([]Task, 0, len(tasks))
+	lastStep := make(map[string]string)
+
+	for _, row := range tasks {
+		name := strings.TrimSpace(row.Template)
+		if name == "" {
+			expanded = append(expanded, row)
+			continue
+		}
+		steps, ok := c.TaskTemplate(name)
+		if !ok {
+			return nil, NewValidationError(row.ID, "Template", name, "unknown task template")
+		}
+		if len(steps) == 0 {
+			return nil, NewValidationError(row.ID, "Template", name, "task template has no steps")
+		}
+
+		var prevID string
+		for i, step := range steps {
+			stepID := strings.TrimSpace(step.ID)
+			if stepID == "" {
+				return nil, NewConfigError("", fmt.Sprintf("task_templates.%s[%d].id", name, i), "step needs an id", nil)
+			}
+
+			task := row
+			task.ID = row.ID + "-" + stepID
+			task.Name = strings.TrimSpace(row.Name + " " + step.Name)
+			task.Template, task.columns = "", nil
+			task.IsMilestone = step.Milestone
+			task.Duration = step.length()
+			task.EndDate, task.StartTime, task.EndTime = time.Time{}, 0, 0
+			task.EffortHours, task.Estimate = 0, Estimate{}
+			if step.Category != "" {
+				task.Category = step.Category
+			}
+			if i < len(steps)-1 {
+				task.Deadline = time.Time{}
+			}
+
+			if i == 0 {
+				if !row.StartDate.IsZero() {
+					task.StartDate = row.StartDate.AddDate(0, 0, step.Offset)
+				} else if step.Offset != 0 {
+					task.Lags = make(map[string]int, len(row.Dependencies))
+					for _, dep := range row.Dependencies {
+						task.Lags[dep] = row.Lag(dep) + step.Offset
+					}
+				}
+			} else {
+				prev := steps[i-1]
+				task.StartDate = time.Time{}
+				task.Dependencies = []string{prevID}
+				task.DepTypes, task.Lags = nil, nil
+				if lag := step.Offset - prev.Offset - prev.length(); lag != 0 {
+					task.Lags = map[string]int{prevID: lag}
+				}
+			}
+
+			expanded = append(expanded, task)
+			prevID = task.ID
+		}
+		lastStep[row.ID] = prevID
+	}
+
+	if len(lastStep) == 0 {
+		return expanded, nil
+	}
+	for i := range expanded {
+		for j, dep := range expanded[i].Dependencies {
+			last, ok := lastStep[dep]
+			if !ok {
+				continue
+			}
+			expanded[i].Dependencies[j] = last
+			if lag, ok := expanded[i].Lags[dep]; ok {
+				delete(expanded[i].Lags, dep)
+				expanded[i].Lags[last] = lag
+			}
+			if kind, ok := expanded[i].DepTypes[dep]; ok {
+				delete(expanded[i].DepTypes, dep)
+				expanded[i].DepTypes[last] = kind
+			}
+		}
+	}
+	return expanded, nil
+}
@@ -0,0 +1,42 @@
+package core
+
+import "testing"
+
+func TestExpandTemplates(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.TaskTemplates = map[string][]TemplateStep{
+		"Paper Submission": {
+			{ID: "draft", Name: "draft", Days: 10},
+			{ID: "review", Name: "review", Offset: 12, Days: 5},
+			{ID: "submit", Name: "submit", Offset: 17, Milestone: true},
+		},
+	}
+	tasks := []Task{
+		{ID: "P1", Name: "Paper 1", Template: "paper submission", StartDate: date(t, "2026-03-02")},
+		{ID: "D", Duration: 2, Dependencies: []string{"P1"}},
+	}
+
+	tasks, err := cfg.ExpandTemplates(tasks)
+	if err != nil {
+		t.Fatalf("ExpandTemplates: %v", err)
+	}
+	if len(tasks) != 4 || tasks[2].ID != "P1-submit" || !tasks[2].IsMilestone {
+		t.Fatalf("unexpected expansion: %+v", tasks)
+	}
+	if err := cfg.ScheduleTasks(tasks); err != nil {
+		t.Fatalf("ScheduleTasks: %v", err)
+	}
+
+	// Draft Mar 2-11, two days gap, review Mar 14-18, submit Mar 19, then D
+	if !tasks[1].StartDate.Equal(date(t, "2026-03-14")) || !tasks[2].StartDate.Equal(date(t, "2026-03-19")) {
+		t.Errorf("steps scheduled review %s, submit %s",
+			tasks[1].StartDate.Format("2006-01-02"), tasks[2].StartDate.Format("2006-01-02"))
+	}
+	if tasks[3].Dependencies[0] != "P1-submit" || !tasks[3].StartDate.Equal(date(t, "2026-03-20")) {
+		t.Errorf("D should follow the last step, got %v from %s", tasks[3].Dependencies, tasks[3].StartDate.Format("2006-01-02"))
+	}
+
+	if _, err := cfg.ExpandTemplates([]Task{{ID: "X", Template: "grant"}}); err == nil {
+		t.Error("expected an error for an unknown template")
+	}
+}
@@ -0,0 +1,369 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Built-in themes
+const (
+	ThemeClassic      = "classic"
+	ThemeMinimal      = "minimal"
+	ThemeHighContrast = "high-contrast"
+	ThemePastel       = "pastel"
+	ThemeDark         = "dark"
+)
+
+// Document font families
+const (
+	FontFamilySans  = "sans"
+	FontFamilySerif = "serif"
+)
+
+// Category palette schemes
+const (
+	PaletteGenerated = "generated" // Hues spread around the color wheel
+	PaletteOkabeIto  = "okabe-ito" // The eight colors of Okabe and Ito, told apart with any color vision
+	PaletteViridis   = "viridis"   // Samples of the viridis map, dark blue to yellow
+)
+
+// Palette sets the category colors: with the generated scheme, hues are
+// spread around the color wheel at this saturation and lightness (0 to 1);
+// okabe-ito and viridis are color-blind safe. Patterns hatches the bars of
+// categories whose colors are close to one another.
+type Palette struct {
+	Scheme     string  `yaml:"scheme"`
+	Saturation float64 `yaml:"saturation"`
+	Lightness  float64 `yaml:"lightness"`
+	Patterns   bool    `yaml:"patterns"`
+}
+
+// okabeIto is the Okabe-Ito palette, most distinct colors first
+var okabeIto = []string{"#E69F00", "#56B4E9", "#009E73", "#F0E442", "#0072B2", "#D55E00", "#CC79A7", "#000000"}
+
+// viridisStops are evenly spaced colors of the viridis map, interpolated
+// between for any number of categories
+var viridisStops = [][3]float64{
+	{68, 1, 84}, {71, 45, 123}, {59, 82, 139}, {44, 114, 142}, {33, 145, 140},
+	{40, 174, 128}, {94, 201, 98}, {173, 220, 48}, {253, 231, 37},
+}
+
+// PaletteSchemes returns the names of the palette schemes
+func PaletteSchemes() []string {
+	return []string{PaletteGenerated, PaletteOkabeIto, PaletteViridis}
+}
+
+// color returns the color of slot i of n evenly spread palette slots
+func (p Palette) color(i, n int) string {
+	switch p.Scheme {
+	case PaletteOkabeIto:
+		return okabeIto[i%len(okabeIto)]
+	case PaletteViridis:
+		t := 0.5
+		if n > 1 {
+			t = float64(i) / float64(n-1)
+		}
+		return viridisColor(t)
+	}
+	lightness := p.Lightness
+	if n > maxDistinctHues && i%2 == 1 {
+		lightness = math.Max(0, lightness-alternateLightnessStep)
+	}
+	r, g, b := hslToRgb(math.Mod(firstAssignedHue+float64(i)*360/float64(n), 360), p.Saturation, lightness)
+	return fmt.Sprintf("#%02X%02X%02X", r, g, b)
+}
+
+// viridisColor returns the viridis color at t, from 0 (dark blue) to 1 (yellow)
+func viridisColor(t float64) string {
+	pos := math.Max(0, math.Min(1, t)) * float64(len(viridisStops)-1)
+	i := int(pos)
+	if i >= len(viridisStops)-1 {
+		i = len(viridisStops) - 2
+	}
+	f := pos - float64(i)
+	var rgb [3]int
+	for c := range rgb {
+		rgb[c] = int(math.Round(viridisStops[i][c] + f*(viridisStops[i+1][c]-viridisStops[i][c])))
+	}
+	return fmt.Sprintf("#%02X%02X%02X", rgb[0], rgb[1], rgb[2])
+}
+
+// Shading sets the background of marked day cells: gray levels (0 black to
+// 1 white) for past and blackout days, R,G,B triples for the others
+type Shading struct {
+	Past         float64 `yaml:"past"`
+	Blackout     float64 `yaml:"blackout"`
+	Today        string  `yaml:"today"`
+	OverCapacity string  `yaml:"over_capacity"`
+}
+
+// PageColors sets the page background and default text color as R,G,B
+// triples; empty keeps black text on white
+type PageColors struct {
+	Background string `yaml:"background"`
+	Text       string `yaml:"text"`
+}
+
+// Link styles
+const (
+	LinkStyleHidden    = "hidden"
+	LinkStyleColor     = "color"
+	LinkStyleBorder    = "border"
+	LinkStyleUnderline = "underline"
+)
+
+// LinkStyles lists the link styles
+func LinkStyles() []string {
+	return []string{LinkStyleHidden, LinkStyleColor, LinkStyleBorder, LinkStyleUnderline}
+}
+
+// Links sets how the navigation links of the PDF look: "hidden" (default)
+// for print, "color" for colored link text, or "border" and "underline" for
+// boxed and underlined links in Color, an R,G,B triple
+type Links struct {
+	Style string `yaml:"style"`
+	Color string `yaml:"color"`
+}
+
+// BorderColor returns Color as the "r g b" fractions hyperref borders take,
+// blue when no color is set
+func (l Links) BorderColor() string {
+	color := l.Color
+	if !isRGBTriple(color) {
+		color = defaultLinkColor
+	}
+	parts := strings.Split(color, ",")
+	fractions := make([]string, len(parts))
+	for i, part := range parts {
+		n, _ := strconv.Atoi(strings.TrimSpace(part))
+		fractions[i] = strconv.FormatFloat(float64(n)/255, 'f', 2, 64)
+	}
+	return strings.Join(fractions, " ")
+}
+
+// RGB returns Color, blue when no color is set
+func (l Links) RGB() string {
+	if l.Color == "" {
+		return defaultLinkColor
+	}
+	return l.Color
+}
+
+// defaultLinkColor is the color of shown links without one
+const defaultLinkColor = "31,78,160"
+
+// Theme is a named set of visual settings. A theme is applied before the
+// config files, so any key they set wins over the theme.
+type Theme struct {
+	Palette    Palette
+	FontFamily string
+	Shading    Shading
+	PageColors PageColors
+
+	BorderWidth       string
+	BackgroundOpacity int
+	BorderOpacity     int
+
+	MilestoneBorderWidth       string
+	MilestoneBackgroundOpacity int
+	MilestoneBorderOpacity     int
+}
+
+// themes are the built-in themes by name
+var themes = map[string]Theme{
+	ThemeClassic: {
+		Palette:    Palette{Saturation: 0.75, Lightness: 0.65},
+		FontFamily: FontFamilySans,
+		Shading:    Shading{Past: 0.94, Blackout: 0.86, Today: "255,243,176", OverCapacity: "253,226,222"},
+
+		BorderWidth:       "0.5pt",
+		BackgroundOpacity: 15,
+		BorderOpacity:     75,
+
+		MilestoneBorderWidth:       "1.2pt",
+		MilestoneBackgroundOpacity: 25,
+		MilestoneBorderOpacity:     90,
+	},
+	ThemeMinimal: {
+		Palette:    Palette{Saturation: 0.35, Lightness: 0.6},
+		FontFamily: FontFamilySerif,
+		Shading:    Shading{Past: 0.97, Blackout: 0.92, Today: "255,249,219", OverCapacity: "253,238,235"},
+
+		BorderWidth:       "0.3pt",
+		BackgroundOpacity: 8,
+		BorderOpacity:     50,
+
+		MilestoneBorderWidth:       "0.8pt",
+		MilestoneBackgroundOpacity: 15,
+		MilestoneBorderOpacity:     70,
+	},
+	ThemeHighContrast: {
+		Palette:    Palette{Saturation: 0.95, Lightness: 0.4},
+		FontFamily: FontFamilySans,
+		Shading:    Shading{Past: 0.85, Blackout: 0.7, Today: "255,221,0", OverCapacity: "255,170,160"},
+
+		BorderWidth:       "1pt",
+		BackgroundOpacity: 35,
+		BorderOpacity:     100,
+
+		MilestoneBorderWidth:       "1.6pt",
+		MilestoneBackgroundOpacity: 45,
+		MilestoneBorderOpacity:     100,
+	},
+	ThemePastel: {
+		Palette:    Palette{Saturation: 0.6, Lightness: 0.8},
+		FontFamily: FontFamilySans,
+		Shading:    Shading{Past: 0.96, Blackout: 0.9, Today: "255,246,204", OverCapacity: "255,232,228"},
+
+		BorderWidth:       "0.4pt",
+		BackgroundOpacity: 30,
+		BorderOpacity:     60,
+
+		MilestoneBorderWidth:       "1pt",
+		MilestoneBackgroundOpacity: 40,
+		MilestoneBorderOpacity:     75,
+	},
+	// Light text on a dark page for tablets and e-readers in dark mode:
+	// boxes and shading mix with the page color, so fills stay dark and
+	// category colors are lighter to stand out
+	ThemeDark: {
+		Palette:    Palette{Saturation: 0.7, Lightness: 0.62},
+		FontFamily: FontFamilySans,
+		Shading:    Shading{Past: 0.2, Blackout: 0.28, Today: "92,80,20", OverCapacity: "100,42,38"},
+		PageColors: PageColors{Background: "30,30,30", Text: "225,225,225"},
+
+		BorderWidth:       "0.5pt",
+		BackgroundOpacity: 30,
+		BorderOpacity:     90,
+
+		MilestoneBorderWidth:       "1.2pt",
+		MilestoneBackgroundOpacity: 40,
+		MilestoneBorderOpacity:     100,
+	},
+}
+
+// ThemeNames returns the names of the built-in themes, sorted
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupTheme returns the built-in theme called name; an empty name is the
+// classic theme
+func LookupTheme(name string) (Theme, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = ThemeClassic
+	}
+	theme, ok := themes[name]
+	if !ok {
+		return Theme{}, NewConfigError("", "theme",
+			fmt.Sprintf("unknown theme %q, expected one of %s", name, strings.Join(ThemeNames(), ", ")), nil)
+	}
+	return theme, nil
+}
+
+// apply sets the visual settings of cfg from the theme
+func (t Theme) apply(cfg *Config) {
+	cfg.Layout.Palette = t.Palette
+	cfg.Layout.Shading = t.Shading
+	cfg.Layout.PageColors = t.PageColors
+	cfg.Layout.LaTeX.Document.FontFamily = t.FontFamily
+
+	styling := &cfg.Layout.TaskStyling
+	styling.BorderWidth = t.BorderWidth
+	styling.BackgroundOpacity = t.BackgroundOpacity
+	styling.BorderOpacity = t.BorderOpacity
+	styling.Milestone.BorderWidth = t.MilestoneBorderWidth
+	styling.Milestone.BackgroundOpacity = t.MilestoneBackgroundOpacity
+	styling.Milestone.BorderOpacity = t.MilestoneBorderOpacity
+}
+
+// validateTheme checks the visual settings a theme or the config files set
+func (cfg *Config) validateTheme() error {
+	p := cfg.Layout.Palette
+	switch p.Scheme {
+	case "", PaletteGenerated, PaletteOkabeIto, PaletteViridis:
+	default:
+		return NewConfigError("", "layout.palette.scheme",
+			fmt.Sprintf("unknown palette %q, expected one of %s", p.Scheme, strings.Join(PaletteSchemes(), ", ")), nil)
+	}
+	if p.Saturation < 0 || p.Saturation > 1 || p.Lightness < 0 || p.Lightness > 1 {
+		return NewConfigError("", "layout.palette", "saturation and lightness must be between 0 and 1", nil)
+	}
+	s := cfg.Layout.Shading
+	if s.Past < 0 || s.Past > 1 || s.Blackout < 0 || s.Blackout > 1 {
+		return NewConfigError("", "layout.shading", "past and blackout must be gray levels between 0 and 1", nil)
+	}
+	colors := []struct{ field, rgb string }{
+		{"layout.shading.today", s.Today},
+		{"layout.shading.over_capacity", s.OverCapacity},
+		{"layout.page_colors.background", cfg.Layout.PageColors.Background},
+		{"layout.page_colors.text", cfg.Layout.PageColors.Text},
+		{"layout.links.color", cfg.Layout.Links.Color},
+	}
+	for _, c := range colors {
+		if c.rgb != "" && !isRGBTriple(c.rgb) {
+			return NewConfigError("", c.field, fmt.Sprintf("%q must be an R,G,B triple such as 255,243,176", c.rgb), nil)
+		}
+	}
+	if style := cfg.Layout.TaskStyling.BorderStyle; !isBorderStyle(style) {
+		return NewConfigError("", "layout.task_styling.border_style",
+			fmt.Sprintf("unknown border style %q, expected one of %s", style, strings.Join(BorderStyles(), ", ")), nil)
+	}
+	switch style := cfg.Layout.Links.Style; style {
+	case "", LinkStyleHidden, LinkStyleColor, LinkStyleBorder, LinkStyleUnderline:
+	default:
+		return NewConfigError("", "layout.links.style",
+			fmt.Sprintf("unknown link style %q, expected one of %s", style, strings.Join(LinkStyles(), ", ")), nil)
+	}
+	if err := cfg.Layout.PageNumbering.validate(); err != nil {
+		return err
+	}
+	if err := cfg.Layout.Grid.validate(cfg.Layout.Paper); err != nil {
+		return err
+	}
+	if bleed := cfg.Layout.Paper.Bleed; bleed != "" {
+		if err := validateDimension(bleed); err != nil {
+			return NewConfigError("", "layout.paper.bleed", err.Error(), nil)
+		}
+	}
+	switch cfg.Layout.LaTeX.Document.FontFamily {
+	case FontFamilySans, FontFamilySerif:
+	default:
+		return NewConfigError("", "layout.latex.document.font_family",
+			fmt.Sprintf("unknown font family %q, expected %s or %s", cfg.Layout.LaTeX.Document.FontFamily, FontFamilySans, FontFamilySerif), nil)
+	}
+	fonts := []struct{ field, name string }{
+		{"layout.latex.document.font", cfg.Layout.LaTeX.Document.Font},
+		{"layout.latex.document.header_font", cfg.Layout.LaTeX.Document.HeaderFont},
+	}
+	for _, f := range fonts {
+		if strings.ContainsAny(f.name, `\{}%#$&^_~`) {
+			return NewConfigError("", f.field, fmt.Sprintf("%q must be a font name such as TeX Gyre Heros", f.name), nil)
+		}
+	}
+	return nil
+}
+
+// isRGBTriple reports whether s is three comma-separated values from 0 to 255
+func isRGBTriple(s string) bool {
+	parts := strings.Split(s, ",")
+	if len(parts) != 3 {
+		return false
+	}
+	for _, part := range parts {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n < 0 || n > 255 {
+			return false
+		}
+	}
+	return true
+}
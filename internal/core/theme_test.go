@@ -0,0 +1,151 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestThemeUnderConfigFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	yml := "theme: pastel\nlayout:\n  task_styling:\n    border_width: 2pt\n"
+	if err := os.WriteFile(path, []byte(yml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := NewConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pastel := themes[ThemePastel]
+	if cfg.Layout.TaskStyling.BorderWidth != "2pt" {
+		t.Errorf("border_width = %s, want the config file's 2pt", cfg.Layout.TaskStyling.BorderWidth)
+	}
+	if cfg.Layout.TaskStyling.BackgroundOpacity != pastel.BackgroundOpacity || cfg.Layout.Palette != pastel.Palette {
+		t.Errorf("background_opacity %d, palette %+v: want the pastel theme's", cfg.Layout.TaskStyling.BackgroundOpacity, cfg.Layout.Palette)
+	}
+
+	if _, err := NewConfigWithOverrides([]string{"theme=neon"}, path); err == nil {
+		t.Error("unknown theme: expected an error")
+	}
+}
+
+func TestColorBlindPalettes(t *testing.T) {
+	cfg := DefaultConfig()
+
+	var tasks []Task
+	for i := 0; i < 10; i++ {
+		tasks = append(tasks, Task{ID: fmt.Sprint(i), Category: fmt.Sprintf("Aim %d", i)})
+	}
+	cfg.Layout.Palette = Palette{Scheme: PaletteOkabeIto, Patterns: true}
+	cfg.AssignCategoryColors(tasks)
+	if got := cfg.GenerateCategoryColor("Aim 0"); got != okabeIto[0] {
+		t.Errorf("first Okabe-Ito color = %s, want %s", got, okabeIto[0])
+	}
+	if a, b := cfg.GenerateCategoryColor("Aim 0"), cfg.GenerateCategoryColor("Aim 8"); a != b {
+		t.Errorf("ninth category = %s, want the first color %s again", b, a)
+	}
+	if cfg.CategoryPattern("Aim 0") != "" || cfg.CategoryPattern("Aim 8") == "" {
+		t.Errorf("patterns %q and %q: want only the repeated color hatched", cfg.CategoryPattern("Aim 0"), cfg.CategoryPattern("Aim 8"))
+	}
+
+	if got := viridisColor(0); got != "#440154" {
+		t.Errorf("viridis start = %s, want #440154", got)
+	}
+	if got := viridisColor(1); got != "#FDE725" {
+		t.Errorf("viridis end = %s, want #FDE725", got)
+	}
+}
+
+func TestDocumentFonts(t *testing.T) {
+	cfg, err := NewConfigWithOverrides([]string{"layout.latex.document.font=TeX Gyre Heros"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc := cfg.Layout.LaTeX.Document; doc.Font != "TeX Gyre Heros" || doc.HeaderSize != `\Large` {
+		t.Errorf("font %q, header size %q: want TeX Gyre Heros and the default \\Large", doc.Font, doc.HeaderSize)
+	}
+	if _, err := NewConfigWithOverrides([]string{`layout.latex.document.header_font=\bfseries`}); err == nil {
+		t.Error("header font with a LaTeX command: expected an error")
+	}
+}
+
+func TestBorderStyles(t *testing.T) {
+	cfg := DefaultConfig()
+	if got := cfg.GetBorderStyle(); got != BorderStyleSolid {
+		t.Errorf("default border style = %s, want solid", got)
+	}
+	cfg.Layout.TaskStyling.BorderStyle = "wavy"
+	if err := cfg.validateTheme(); err == nil {
+		t.Error("expected an error for an unknown border style")
+	}
+
+	cfg.Categories = []TaskCategory{{Name: "Drafts", BorderStyle: "dashed"}, {Name: "Admin"}}
+	if err := cfg.validateCategories(); err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.CategoryBorderStyle("drafts"); got != BorderStyleDashed {
+		t.Errorf("Drafts border style = %q, want dashed", got)
+	}
+	if got := cfg.CategoryBorderStyle("Admin"); got != "" {
+		t.Errorf("Admin border style = %q, want the global one", got)
+	}
+	cfg.Categories[1].BorderStyle = "double"
+	if err := cfg.validateCategories(); err == nil {
+		t.Error("expected an error for an unknown category border style")
+	}
+}
+
+func TestPageNumbering(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.Layout.PageNumbering.Marks() {
+		t.Error("month pages should not mark sections by default")
+	}
+	if err := cfg.ApplyOverrides([]string{"layout.page_numbering.position=center", "layout.page_numbering.running_head=true"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.validateTheme(); err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Layout.PageNumbering.Marks() {
+		t.Error("a running head should mark the month pages")
+	}
+	cfg.Layout.PageNumbering.Style = "greek"
+	if err := cfg.validateTheme(); err == nil {
+		t.Error("expected an error for an unknown numbering style")
+	}
+}
+
+func TestLinks(t *testing.T) {
+	if got := (Links{Color: "255,0,51"}).BorderColor(); got != "1.00 0.00 0.20" {
+		t.Errorf("border color = %q, want 1.00 0.00 0.20", got)
+	}
+	if got := (Links{}).RGB(); got != defaultLinkColor {
+		t.Errorf("default color = %q, want %s", got, defaultLinkColor)
+	}
+	cfg := DefaultConfig()
+	cfg.Layout.Links = Links{Style: LinkStyleUnderline, Color: "red"}
+	if err := cfg.validateTheme(); err == nil {
+		t.Error("expected an error for a link color that is not R,G,B")
+	}
+}
+
+func TestGridValidate(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Layout.Grid = Grid{Fill: true, Padding: "4cm"}
+	if err := cfg.validateTheme(); err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.GetGridPadding(); got != "4cm" {
+		t.Errorf("padding = %q, want 4cm", got)
+	}
+	cfg.Layout.Paper.Margin.Top = ""
+	if err := cfg.validateTheme(); err == nil {
+		t.Error("expected an error for a filled grid without a top margin")
+	}
+	cfg.Layout.Grid = Grid{Padding: "4"}
+	if err := cfg.validateTheme(); err == nil {
+		t.Error("expected an error for a padding without a unit")
+	}
+}
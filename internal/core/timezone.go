@@ -0,0 +1,82 @@
+package core
+
+import (
+	"strings"
+	"time"
+)
+
+// zonedDateFormats are the supported date formats that carry a UTC offset
+var zonedDateFormats = []string{
+	DateFormatRFC3339,
+	DateFormatTZone,
+}
+
+// hasUTCOffset reports whether a date string carries its own UTC offset
+func hasUTCOffset(value string) bool {
+	value = strings.TrimSpace(value)
+	for _, format := range zonedDateFormats {
+		if _, err := time.Parse(format, value); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// wallClock returns the date and time an instant shows on the clock in loc.
+// Planner dates are kept as UTC wall-clock values, so only the result is
+// comparable with task and calendar days.
+func wallClock(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
+
+// LoadLocation returns the configured time zone, UTC when none is set
+func (c *Config) LoadLocation() (*time.Location, error) {
+	name := strings.TrimSpace(c.Timezone)
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, NewConfigError("", "timezone", "unknown time zone "+name, err)
+	}
+	return loc, nil
+}
+
+// GetLocation returns the configured time zone, falling back to UTC
+func (c *Config) GetLocation() *time.Location {
+	loc, err := c.LoadLocation()
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// ApplyTimezone places the start, end, deadline and baseline dates that were
+// written with a UTC offset on the day and time they fall on in the
+// configured time zone. The reader has no configuration and puts them on
+// their UTC day.
+func (c *Config) ApplyTimezone(tasks []Task) error {
+	loc, err := c.LoadLocation()
+	if err != nil {
+		return err
+	}
+	for i := range tasks {
+		if !tasks[i].startAt.IsZero() {
+			tasks[i].StartDate, tasks[i].StartTime = splitTimeOfDay(wallClock(tasks[i].startAt, loc))
+		}
+		if !tasks[i].endAt.IsZero() {
+			tasks[i].EndDate, tasks[i].EndTime = splitTimeOfDay(wallClock(tasks[i].endAt, loc))
+		}
+		for _, day := range []struct{ at, date *time.Time }{
+			{&tasks[i].deadlineAt, &tasks[i].Deadline},
+			{&tasks[i].baselineStartAt, &tasks[i].BaselineStart},
+			{&tasks[i].baselineEndAt, &tasks[i].BaselineEnd},
+		} {
+			if !day.at.IsZero() {
+				*day.date = wallClock(*day.at, loc)
+			}
+		}
+	}
+	return nil
+}
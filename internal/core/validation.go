@@ -1,6 +1,7 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"regexp"
@@ -85,13 +86,25 @@ func (v *CSVValidator) ValidateCSVFile(filePath string) (*ValidationResult, erro
 
 	result.RowCount = len(tasks)
 
+	// Rows the reader skipped
+	for _, err := range reader.ParseErrors() {
+		issue := ValidationIssue{Type: "csv_parsing", Message: err.Error()}
+		var parseErr *ParseError
+		if errors.As(err, &parseErr) {
+			issue.Row, issue.Field, issue.Value = parseErr.Row, parseErr.Column, parseErr.Value
+			issue.Message = parseErr.Message
+		}
+		result.Errors = append(result.Errors, issue)
+		result.IsValid = false
+	}
+
 	// Validate each task
 	for i, task := range tasks {
-		if errs := v.validateTask(task, i+2); len(errs) > 0 { // +2 for header row + 0-indexing
+		if errs := v.validateTask(task, taskRow(task, i)); len(errs) > 0 {
 			result.Errors = append(result.Errors, errs...)
 			result.IsValid = false
 		}
-		if warns := v.validateTaskWarnings(task, i+2); len(warns) > 0 {
+		if warns := v.validateTaskWarnings(task, taskRow(task, i)); len(warns) > 0 {
 			result.Warnings = append(result.Warnings, warns...)
 		}
 	}
@@ -105,6 +118,15 @@ func (v *CSVValidator) ValidateCSVFile(filePath string) (*ValidationResult, erro
 	return result, nil
 }
 
+// taskRow returns the line of the CSV file a task was read from, or its
+// place after the header for tasks not read from one
+func taskRow(task Task, i int) int {
+	if task.line > 0 {
+		return task.line
+	}
+	return i + 2 // +2 for header + 0-indexing
+}
+
 // validateFileAccess checks if the CSV file can be accessed
 func (v *CSVValidator) validateFileAccess(filePath string) error {
 	info, err := os.Stat(filePath)
@@ -147,8 +169,8 @@ func (v *CSVValidator) validateTask(task Task, rowNum int) []ValidationIssue {
 		})
 	}
 
-	// Validate dates
-	if task.StartDate.IsZero() {
+	// Validate dates; tasks with a Duration are scheduled from their dependencies
+	if task.StartDate.IsZero() && task.Duration == 0 {
 		errors = append(errors, ValidationIssue{
 			Type:    "required_field",
 			Field:   "Start Date",
@@ -157,7 +179,7 @@ func (v *CSVValidator) validateTask(task Task, rowNum int) []ValidationIssue {
 		})
 	}
 
-	if task.EndDate.IsZero() {
+	if task.EndDate.IsZero() && task.Duration == 0 {
 		errors = append(errors, ValidationIssue{
 			Type:    "required_field",
 			Field:   "End Date",
@@ -308,6 +330,17 @@ func (v *CSVValidator) validateTaskWarnings(task Task, rowNum int) []ValidationI
 		}
 	}
 
+	// Warn about tasks planned to end after their hard deadline
+	if task.MissesDeadline() {
+		warnings = append(warnings, ValidationIssue{
+			Type:    "deadline_missed",
+			Field:   "Deadline",
+			Row:     rowNum,
+			Value:   task.Deadline.Format("2006-01-02"),
+			Message: fmt.Sprintf("End date %s is after the deadline", task.EndDate.Format("2006-01-02")),
+		})
+	}
+
 	// Note: Assignee validation removed - acceptable for single-person projects
 
 	return warnings
@@ -353,7 +386,7 @@ func (v *CSVValidator) validateDataConsistency(tasks []Task) []ValidationIssue {
 					errors = append(errors, ValidationIssue{
 						Type:    "invalid_dependency",
 						Field:   "Dependencies",
-						Row:     i + 2, // +2 for header + 0-indexing
+						Row:     taskRow(task, i),
 						Value:   dep,
 						Message: fmt.Sprintf("Dependency '%s' references non-existent task ID", dep),
 					})
@@ -375,6 +408,68 @@ func (v *CSVValidator) validateDataConsistency(tasks []Task) []ValidationIssue {
 	return errors
 }
 
+// DependencyCycles reports every circular dependency chain among tasks
+// merged from one or more CSV files. Row numbers are omitted because they are
+// not meaningful across files; the chain names the offending tasks.
+func DependencyCycles(tasks []Task) []ValidationIssue {
+	taskIndex := make(map[string]int, len(tasks))
+	for i, task := range tasks {
+		if task.ID != "" {
+			taskIndex[task.ID] = i
+		}
+	}
+
+	issues := (&CSVValidator{}).detectDependencyCycles(tasks, taskIndex)
+	for i := range issues {
+		issues[i].Row = 0
+	}
+	return issues
+}
+
+// CategoryIssues warns about tasks without a category, categories spelled
+// differently only in case or spacing (which get different colors), and
+// configured swimlanes that no task uses
+func (c *Config) CategoryIssues(tasks []Task) []ValidationIssue {
+	var issues []ValidationIssue
+	spellings := make(map[string]string)
+	used := make(map[string]bool)
+	for _, task := range tasks {
+		category := task.Category
+		key := CategoryKey(category)
+		if key == "" {
+			issues = append(issues, ValidationIssue{
+				Type:    "missing_category",
+				Field:   "Category",
+				Message: fmt.Sprintf("Task %s has no category", task.ID),
+			})
+			continue
+		}
+		used[key] = true
+		if first, ok := spellings[key]; !ok {
+			spellings[key] = category
+		} else if first != category {
+			issues = append(issues, ValidationIssue{
+				Type:    "category_spelling",
+				Field:   "Category",
+				Value:   category,
+				Message: fmt.Sprintf("Task %s uses category %q, elsewhere spelled %q", task.ID, category, first),
+			})
+		}
+	}
+
+	for _, lane := range c.Layout.Calendar.Swimlanes {
+		if key := CategoryKey(lane); key != "" && !used[key] {
+			issues = append(issues, ValidationIssue{
+				Type:    "unused_swimlane",
+				Field:   "layout.calendar.swimlanes",
+				Value:   lane,
+				Message: fmt.Sprintf("Swimlane %q matches no task category", lane),
+			})
+		}
+	}
+	return issues
+}
+
 // detectDependencyCycles detects circular dependencies in the task graph
 func (v *CSVValidator) detectDependencyCycles(tasks []Task, taskIndex map[string]int) []ValidationIssue {
 	var errors []ValidationIssue
@@ -395,7 +490,7 @@ func (v *CSVValidator) detectDependencyCycles(tasks []Task, taskIndex map[string
 			}
 			if cycleStart >= 0 {
 				cycle := append(path[cycleStart:], taskID)
-				row := taskIndex[taskID] + 2 // +2 for header + 0-indexing
+				row := taskRow(tasks[taskIndex[taskID]], taskIndex[taskID])
 				errors = append(errors, ValidationIssue{
 					Type:    "dependency_cycle",
 					Field:   "Dependencies",
@@ -466,7 +561,7 @@ func (v *CSVValidator) validateMilestoneConsistency(tasks []Task) []ValidationIs
 			errors = append(errors, ValidationIssue{
 				Type:    "milestone_inconsistency",
 				Field:   "Milestone",
-				Row:     i + 2, // +2 for header + 0-indexing
+				Row:     taskRow(task, i),
 				Message: "Task is marked as milestone but name/description doesn't indicate milestone characteristics",
 			})
 		}
@@ -685,6 +780,16 @@ func (cv *ConfigValidator) validateConfigWarnings(config Config) []ValidationIss
 		})
 	}
 
+	// Warn about locales that will silently fall back to English
+	if strings.TrimSpace(config.Locale) != "" && !IsSupportedLocale(config.Locale) {
+		warnings = append(warnings, ValidationIssue{
+			Type:    "unsupported_value",
+			Field:   "locale",
+			Value:   config.Locale,
+			Message: fmt.Sprintf("Unsupported locale '%s', falling back to English", config.Locale),
+		})
+	}
+
 	return warnings
 }
 
@@ -0,0 +1,24 @@
+package core
+
+import "testing"
+
+func TestDependencyCycles(t *testing.T) {
+	tasks := []Task{
+		{ID: "A", Dependencies: []string{"C"}},
+		{ID: "B", Dependencies: []string{"A"}},
+		{ID: "C", Dependencies: []string{"B"}},
+		{ID: "D", Dependencies: []string{"A"}},
+	}
+
+	issues := DependencyCycles(tasks)
+	if len(issues) != 1 {
+		t.Fatalf("expected one cycle, got %v", issues)
+	}
+	if issues[0].Value != "A → C → B → A" {
+		t.Errorf("unexpected chain %q", issues[0].Value)
+	}
+
+	if issues := DependencyCycles(tasks[1:]); len(issues) != 0 {
+		t.Errorf("expected no cycle once A is removed, got %v", issues)
+	}
+}
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+// Build metadata, set when building with
+//
+//	-ldflags "-X phd-dissertation-planner/internal/core.Version=v1.4.0
+//	          -X phd-dissertation-planner/internal/core.Commit=$(git rev-parse HEAD)
+//	          -X phd-dissertation-planner/internal/core.BuildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// as the Makefile does. Without them, the commit and date recorded by the Go
+// toolchain for builds from a git checkout are used.
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildDate = ""
+)
+
+// shortCommitLength is the length of the abbreviated commit hash
+const shortCommitLength = 7
+
+// BuildInfo describes the running binary
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	Modified  bool   `json:"modified,omitempty"` // Built from a checkout with uncommitted changes
+	Date      string `json:"date,omitempty"`     // RFC 3339, UTC
+	GoVersion string `json:"go_version"`
+	Platform  string `json:"platform"`
+}
+
+// Build returns the metadata of the running binary
+func Build() BuildInfo {
+	info := BuildInfo{
+		Version:   strings.TrimSpace(Version),
+		Commit:    strings.TrimSpace(Commit),
+		Date:      strings.TrimSpace(BuildDate),
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	if info.Version == "" {
+		info.Version = "dev"
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		// Tagged module versions only; pseudo-versions repeat the commit
+		if v := bi.Main.Version; info.Version == "dev" && v != "" && v != "(devel)" && !strings.HasPrefix(v, "v0.0.0-") {
+			info.Version = v
+		}
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = s.Value
+				}
+			case "vcs.time":
+				if info.Date == "" {
+					info.Date = s.Value
+				}
+			case "vcs.modified":
+				info.Modified = s.Value == "true"
+			}
+		}
+	}
+	return info
+}
+
+// ShortCommit returns the abbreviated commit hash, marked "-dirty" when the
+// checkout had uncommitted changes
+func (b BuildInfo) ShortCommit() string {
+	commit := b.Commit
+	if len(commit) > shortCommitLength {
+		commit = commit[:shortCommitLength]
+	}
+	if commit != "" && b.Modified {
+		commit += "-dirty"
+	}
+	return commit
+}
+
+// ShortDate returns the build day, YYYY-MM-DD, or the date as set when it is
+// not RFC 3339
+func (b BuildInfo) ShortDate() string {
+	if t, err := time.Parse(time.RFC3339, b.Date); err == nil {
+		return t.UTC().Format("2006-01-02")
+	}
+	return b.Date
+}
+
+// String returns the version with the commit and build day, such as
+// "v1.4.0 (3f2a9c1, built 2026-10-18)"
+func (b BuildInfo) String() string {
+	var details []string
+	if commit := b.ShortCommit(); commit != "" {
+		details = append(details, commit)
+	}
+	if date := b.ShortDate(); date != "" {
+		details = append(details, "built "+date)
+	}
+	if len(details) == 0 {
+		return b.Version
+	}
+	return fmt.Sprintf("%s (%s)", b.Version, strings.Join(details, ", "))
+}
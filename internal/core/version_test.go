@@ -0,0 +1,19 @@
+package core
+
+import "testing"
+
+func TestBuildInfoString(t *testing.T) {
+	cases := []struct {
+		info BuildInfo
+		want string
+	}{
+		{BuildInfo{Version: "v1.4.0", Commit: "3f2a9c1d8e7b", Date: "2026-10-18T09:30:00Z"}, "v1.4.0 (3f2a9c1, built 2026-10-18)"},
+		{BuildInfo{Version: "dev", Commit: "3f2a9c1d8e7b", Modified: true}, "dev (3f2a9c1-dirty)"},
+		{BuildInfo{Version: "dev"}, "dev"},
+	}
+	for _, c := range cases {
+		if got := c.info.String(); got != c.want {
+			t.Errorf("%+v = %q, want %q", c.info, got, c.want)
+		}
+	}
+}
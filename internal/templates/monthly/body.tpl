@@ -2,14 +2,26 @@
 \SetupDefaultCategoryPalette{}
 
 {{- template "calendar.tpl" dict "Cfg" .Cfg "Body" .Body -}}
+{{- with .Body.Month.DependencyArrows }}
+% Dependency arrows between task bars
+{{ . }}
+{{- end }}
 
 % Legend at bottom of page - just colors and categories
 \vfill
+{{- with .Body.Month.OverflowFootnotes }}
+% Tasks collapsed in crowded day cells
+{{ . }}
+{{- end }}
+{{- with .Body.Month.NeighbourMiniCalendars }}
+% Previous/next month reference calendars
+{\par\noindent\hfill {{ . }}\par}
+{{- end }}
 {{- $phaseGroups := .Body.Month.GetTaskColorsByPhase -}}
 {{- if $phaseGroups -}}
 {\small{{- range $idx, $phase := $phaseGroups -}}
 % Phase header with subtle background
-{\colorbox[RGB]{245,245,245}{\makebox[\linewidth][l]{\textbf{ {{- $phase.PhaseName -}} }}}\\
+{\colorbox{PageText!4!PageBackground}{\makebox[\linewidth][l]{\textbf{ {{- $phase.PhaseName -}} }}}\\
 \vspace{1pt}
 {{- range $subIdx, $subPhase := $phase.SubPhases -}}\ColorCircle{ {{- $subPhase.Color -}} }{ {{- $subPhase.Name -}} }\quad{{- end -}}\\
 
@@ -21,14 +33,14 @@
 {{- if $taskColors -}}
 \noindent{\small
 % Legend header
-{\colorbox[RGB]{250,250,250]{\makebox[\linewidth][l]{\textbf{Task Categories}}}}\\
+{\colorbox[RGB]{250,250,250]{\makebox[\linewidth][l]{\textbf{ {{- text .Cfg "task_categories" -}} }}}}\\
 \vspace{1pt}
 {{- range $color, $category := $taskColors -}}{{- if ne $color "" -}}\ColorCircle{ {{- $color -}} }{ {{- $category -}} }\quad{{- end -}}{{- end -}}}
 }
 {{- else -}}
 % Ultimate fallback to algorithmic legend
 \noindent{\small
-{\colorbox[RGB]{248,248,248]{\makebox[\linewidth][l]{\textbf{Default Categories}}}}\\
+{\colorbox[RGB]{248,248,248]{\makebox[\linewidth][l]{\textbf{ {{- text .Cfg "default_categories" -}} }}}}\\
 \vspace{1pt}
 \ColorLegend}
 {{- end -}}
@@ -1,6 +1,8 @@
 {%
 {{ if not .Body.Large }} \renewcommand{\arraystretch}{\myNumArrayStretch} {{ end }}
 \setlength{\tabcolsep}{\myLenTabColSep}
+{{- with .Body.Month.GridLengths .Body.Large }}
+{{ . }}{{ end }}
 {{ $tbl := .Body.Month.DefineTable .Body.TableType .Body.Large }}
 {{ if $tbl }}
 {{$tbl}}
@@ -0,0 +1,24 @@
+{{- hypertarget "cover" "" -}}
+\begin{center}
+\vspace*{0.18\textheight}
+{{- with .Body.Logo }}
+\includegraphics[width={{ $.Body.Cover.LogoWidth }}, height=0.2\textheight, keepaspectratio]{ {{- . -}} }\par
+\vspace{2em}
+{{- end }}
+{\HeaderFontFamily\Huge\bfseries {{ escapeLatex .Body.Cover.Title }}\par}
+{{- with .Body.DateRange }}
+\vspace{1.2em}
+{\Large {{ . }}\par}
+{{- end }}
+\vfill
+{{- with .Body.Cover.Author }}
+{\Large {{ escapeLatex . }}\par}
+{{- end }}
+{{- with .Body.Cover.Institution }}
+\vspace{0.6em}
+{\large {{ escapeLatex . }}\par}
+{{- end }}
+\vspace*{0.12\textheight}
+\end{center}
+
+\pagebreak
@@ -0,0 +1,40 @@
+{{- hypertarget .Body.DayRef "" -}}
+{\noindent\normalsize\renewcommand{\arraystretch}{1.0}
+{{- .Body.Breadcrumb -}}
+\hfill%
+{{ .Body.Extra.Table false -}}
+}
+\myLineThick
+\medskip
+
+{\noindent\PageTitleFont\textbf{ {{- .Body.Title -}} }}
+{{- with .Body.Day.Holiday }}\hfill{\large\textit{ {{- . -}} }}{{ end }}
+{{- range .Body.Day.AcademicNames }}\par{\small\textcolor{blue!50!black}{ {{- . -}} }}{{ end }}
+\medskip
+
+{{ $day := .Body.Day -}}
+{{ if $day.Tasks -}}
+% Tasks active on this day
+\begin{itemize}[leftmargin=*, itemsep=6pt, label={}]
+{{- range $task := $day.Tasks }}
+\item \ColorCircle{ {{- $task.ColorRGB -}} }{\textbf{ {{- if $task.IsMilestone }}\ensuremath{\bigstar}~{{ end }}{{ $task.EscapedName -}} }}
+{{- if $day.StartsToday $task }} \hfill{\small\textit{ {{- text $.Cfg "starts_today" -}} }}{{ else if $day.EndsToday $task }} \hfill{\small\textit{ {{- text $.Cfg "due_today" -}} }}{{ end }}\\
+{\small {{ $task.EscapedPhase }} \textperiodcentered{} {{ $.Body.Locale.ShortDate $task.StartDate }} -- {{ $.Body.Locale.ShortDate $task.EndDate }}
+{{- with $task.Meta }} \textperiodcentered{} {{ . }}{{ end }}}
+{{- if $task.EscapedDescription }}\\
+{\small {{ $task.EscapedDescription }}}
+{{- end }}
+{{- end }}
+\end{itemize}
+{{- else -}}
+{\noindent\small\textit{ {{- text .Cfg "no_tasks" -}} }}
+{{- end }}
+
+% Notes
+\vfill
+{\noindent\textbf{ {{- text .Cfg "notes" -}} }}\par
+{{- range .Body.NotesLines }}
+\noindent\rule{\linewidth}{\myLenLineThicknessDefault}\par\vspace{1.2em}
+{{- end }}
+
+\pagebreak
@@ -12,7 +12,21 @@
 \usepackage[utf8]{inputenc}
 \usepackage[T1]{fontenc}
 \usepackage{lmodern}
-\renewcommand{\familydefault}{\sfdefault}
+\renewcommand{\familydefault}{ {{- if eq .Cfg.Layout.LaTeX.Document.FontFamily "serif"}}\rmdefault{{else}}\sfdefault{{end -}} }
+{{- $doc := .Cfg.Layout.LaTeX.Document }}
+{{- if or $doc.Font $doc.HeaderFont $doc.IconFont }}
+% OpenType fonts from layout.latex.document.font, header_font and icon_font
+\usepackage{fontspec}
+{{- if $doc.Font }}
+\{{ if eq $doc.FontFamily "serif" }}setmainfont{{ else }}setsansfont{{ end }}{ {{- $doc.Font -}} }
+{{- end }}
+{{- if $doc.HeaderFont }}
+\newfontfamily\plannerheaderfont{ {{- $doc.HeaderFont -}} }
+{{- end }}
+{{- if $doc.IconFont }}
+\newfontfamily\plannericonfont{ {{- $doc.IconFont -}} }
+{{- end }}
+{{- end }}
 
 % Unicode character support
 \usepackage{textcomp}
@@ -22,6 +36,12 @@
 \usepackage[table]{xcolor}
 \usepackage{graphicx}
 \usepackage{tikz}
+{{- if .Cfg.Layout.Palette.Patterns }}
+\usetikzlibrary{patterns}
+{{- end }}
+{{- if .Cfg.Layout.TaskStyling.Shadow }}
+\usetikzlibrary{shadows}
+{{- end }}
 \usepackage{adjustbox}
 
 % Table and array packages
@@ -58,14 +78,33 @@
 \usepackage{bookmark}
 
 {{if $.Cfg.Debug.ShowFrame}}\usepackage{showframe}{{end}}
+{{- if eq .Cfg.Layout.Links.Style "color" }}
+\definecolor{LinkColor}{RGB}{ {{- .Cfg.Layout.Links.RGB -}} }
+{{- end }}
 
 \hypersetup{
     pdftitle={PhD Dissertation Planner {{.Cfg.Year}}},
     pdfauthor={PlannerGen},
     pdfsubject={PhD Dissertation Timeline},
     pdfkeywords={PhD, Dissertation, Planner, Timeline, {{.Cfg.Year}}},
-    pdfcreator={PlannerGen},
-{{- if not .Cfg.Debug.ShowLinks}}
+    pdfcreator={PlannerGen {{buildInfo}}},
+{{- $links := .Cfg.Layout.Links }}
+{{- if .Cfg.Debug.ShowLinks}}
+{{- else if eq $links.Style "color" }}
+    colorlinks=true,
+    linkcolor=LinkColor,
+    citecolor=LinkColor,
+    filecolor=LinkColor,
+    urlcolor=LinkColor
+{{- else if or (eq $links.Style "border") (eq $links.Style "underline") }}
+    colorlinks=false,
+    linkbordercolor={ {{- $links.BorderColor -}} },
+    citebordercolor={ {{- $links.BorderColor -}} },
+    filebordercolor={ {{- $links.BorderColor -}} },
+    urlbordercolor={ {{- $links.BorderColor -}} },
+    pdfborderstyle={/S/{{ if eq $links.Style "underline" }}U{{ else }}S{{ end }}/W 0.6},
+    pdfborder={0 0 0.6}
+{{- else }}
     hidelinks,
     colorlinks=false,
     linkbordercolor={1 1 1},
@@ -76,7 +115,19 @@
     pdfborder={0 0 0}
 {{- end}}
 }
-
+{{ $paper := .Cfg.Layout.Paper }}
+{{- with $paper.Bleed }}
+% The paper and margins grow by the bleed (layout.paper.bleed) on every side
+\geometry{verbose=false,paperwidth=\dimexpr {{ $paper.Width }}+2\dimexpr {{ . }}\relax\relax, paperheight=\dimexpr {{ $paper.Height }}+2\dimexpr {{ . }}\relax\relax}
+\geometry{
+  top=\dimexpr {{ $paper.Margin.Top }}+{{ . }}\relax,
+  bottom=\dimexpr {{ $paper.Margin.Bottom }}+{{ . }}\relax,
+  left=\dimexpr {{ $paper.Margin.Left }}+{{ . }}\relax,
+  right=\dimexpr {{ $paper.Margin.Right }}+{{ . }}\relax,
+  marginparwidth={{ $paper.MarginParWidth }},
+  marginparsep={{ $paper.MarginParSep }}
+}
+{{- else }}
 \geometry{verbose=false,paperwidth={{.Cfg.Layout.Paper.Width}}, paperheight={{.Cfg.Layout.Paper.Height}}}
 \geometry{
   top={{.Cfg.Layout.Paper.Margin.Top}},
@@ -86,8 +137,55 @@
   marginparwidth={{.Cfg.Layout.Paper.MarginParWidth}},
   marginparsep={{.Cfg.Layout.Paper.MarginParSep}}
 }
-
+{{- end }}
+
+{{- $labels := .Cfg.Pages.HasHeaderFooter }}
+{{- $numbering := .Cfg.Layout.PageNumbering }}
+{{- $head := or $labels $numbering.RunningHead }}
+{{- if or .Cfg.BuildFooter $labels $numbering.Position $numbering.RunningHead }}
+{{- if $labels }}
+% Header and footer text of the pages' header and footer templates, set by
+% each page with \PageLabels{header}{footer}
+\newcommand{\PageHeaderText}{}
+\newcommand{\PageFooterText}{}
+\newcommand{\PageLabels}[2]{\renewcommand{\PageHeaderText}{#1}\renewcommand{\PageFooterText}{#2}}
+{{- end }}
+% Build stamp, page numbers and running head in the margins, without moving
+% the page body
+\fancypagestyle{plannerbuild}{%
+  \fancyhf{}%
+  \renewcommand{\headrulewidth}{0pt}%
+{{- if $numbering.RunningHead }}
+  \fancyhead[L]{\smash{\small\HeaderFontFamily\leftmark}}%
+{{- end }}
+{{- if $labels }}
+  \fancyhead[C]{\smash{\small\PageHeaderText}}%
+{{- end }}
+{{- if eq $numbering.Position "left" }}
+  \fancyfoot[L]{\small\thepage}%
+{{- end }}
+{{- if or $labels (eq $numbering.Position "center") }}
+  \fancyfoot[C]{\small
+  {{- if $labels }}\PageFooterText{{ end }}
+  {{- if and $labels (eq $numbering.Position "center") }}\quad{{ end }}
+  {{- if eq $numbering.Position "center" }}\thepage{{ end }}}%
+{{- end }}
+{{- if or .Cfg.BuildFooter (eq $numbering.Position "right") }}
+  \fancyfoot[R]{
+  {{- if .Cfg.BuildFooter }}\tiny\color{gray}PlannerGen {{buildInfo}}{{ end }}
+  {{- if and .Cfg.BuildFooter (eq $numbering.Position "right") }}\quad{{ end }}
+  {{- if eq $numbering.Position "right" }}\small\color{PageText}\thepage{{ end }}}%
+{{- end }}
+}
+\setlength{\footskip}{0.6\dimexpr {{.Cfg.Layout.Paper.Margin.Bottom}}\relax}
+{{- if $head }}
+\setlength{\headheight}{0pt}
+\setlength{\headsep}{0.4\dimexpr {{.Cfg.Layout.Paper.Margin.Top}}\relax}
+{{- end }}
+\pagestyle{plannerbuild}
+{{- else}}
 \pagestyle{empty}
+{{- end}}
 {{if $.Cfg.Layout.Paper.ReverseMargins}}\reversemarginpar{{end}}
 \newcolumntype{Y}{>{\centering\arraybackslash}X}
 \parindent={{.Cfg.Layout.LaTeX.Document.ParIndent}}
@@ -97,9 +195,40 @@
 \hoffset=0pt
 \voffset=0pt
 
+{{- if $numbering.Marks }}
+% Month pages open a section with \MonthSection{month year}, setting the
+% running head{{ if $numbering.MonthSections }} and numbering and bookmarking the month{{ end }}
+{{- if $numbering.MonthSections }}
+\newcounter{monthsection}
+\newcommand{\MonthSection}[1]{\refstepcounter{monthsection}\pdfbookmark[0]{\themonthsection\ #1}{monthsection.\themonthsection}\markboth{\themonthsection\quad #1}{}}
+{{- else }}
+\newcommand{\MonthSection}[1]{\markboth{#1}{}}
+{{- end }}
+{{- end }}
+{{- with .Preamble }}
+
+% Extra preamble from layout.latex.document.preamble
+{{ . }}
+{{- end }}
+
 \begin{document}
+{{- with $numbering.Style }}
+\pagenumbering{ {{- . -}} }
+{{- end }}
 
 {{template "macros.tpl" .}}
+{{- with .Macros }}
+% User macros from layout.latex.document.macros_file
+{{ . }}
+{{- end }}
+{{- with .Cfg.Layout.PageColors}}
+{{- if .Background}}
+\pagecolor{PageBackground}
+{{- end}}
+{{- if .Text}}
+\color{PageText}\arrayrulecolor{PageText}
+{{- end}}
+{{- end}}
 
   {{range .Pages -}}
     \include{ {{- .Name -}} .tex}
@@ -0,0 +1,22 @@
+{{- hypertarget "earned-value" "" -}}
+{\noindent\PageTitleFont\textbf{ {{- text .Cfg "earned_value" -}} }}
+\par\myLineThick
+\medskip
+
+{\noindent\small {{ text .Cfg "earned_value_intro" (printf "%s %d" (.Body.Locale.ShortDate .Body.EarnedValue.AsOf) .Body.EarnedValue.AsOf.Year) }}}
+\bigskip
+
+{\footnotesize
+\noindent\begin{tabularx}{\linewidth}{@{}Xrrrrrrr@{}}
+\textbf{ {{- text .Cfg "category" -}} } & \textbf{ {{- text .Cfg "tasks" -}} } & \textbf{PV} & \textbf{EV} & \textbf{AC} & \textbf{SPI} & \textbf{CPI} & \textbf{ {{- text .Cfg "done" -}} } \\
+\hline
+{{- range .Body.EarnedValue.Rows }}
+{{ .Name }} & {{ .Tasks }} & {{ .PV }} & {{ .EV }} & {{ .AC }} & {{ if .Behind }}\textcolor{red!70!black}{ {{- .SPI -}} }{{ else }}{{ .SPI }}{{ end }} & {{ .CPI }} & {{ .Complete }} \\
+{{- end }}
+\hline
+{{- with .Body.EarnedValue.Overall }}
+\textbf{ {{- text $.Cfg "overall" -}} } & {{ .Tasks }} & {{ .PV }} & {{ .EV }} & {{ .AC }} & {{ if .Behind }}\textcolor{red!70!black}{ {{- .SPI -}} }{{ else }}{{ .SPI }}{{ end }} & {{ .CPI }} & {{ .Complete }} \\
+{{- end }}
+\end{tabularx}}
+
+\pagebreak
@@ -0,0 +1,14 @@
+{{- if eq .Body.Page 1 }}{{ hypertarget "gantt" "" }}{{ end -}}
+{\noindent\PageTitleFont\textbf{ {{- text .Cfg "project_timeline" -}} }
+{{- if gt .Body.PageCount 1 }}\hfill{\normalsize {{ .Body.Page }}/{{ .Body.PageCount }}}{{ end }}}
+\par\myLineThick
+{{- if .Body.Scenario }}
+\par\noindent{\scriptsize\textcolor{orange!80!black}{What-if:} dashed outlines show the proposed dates; shaded months change.}
+{{- end }}
+\bigskip
+
+% Gantt chart: tasks as rows, proportional time axis
+\noindent\hspace*{0.3\linewidth}%
+{{ .Body.Chart.TikZ }}
+
+\pagebreak
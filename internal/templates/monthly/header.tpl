@@ -1,5 +1,5 @@
 {{- hypertarget .Body.MonthRef "" -}}
-{\noindent\normalsize\renewcommand{\arraystretch}{1.0}
+{\noindent\normalsize\HeaderFontFamily\renewcommand{\arraystretch}{1.0}
 {{- .Body.Breadcrumb -}}
 \hfill%
 {{ .Body.Extra.Table false -}}
@@ -0,0 +1,18 @@
+{{- hypertarget "legend" "" -}}
+{\noindent\PageTitleFont\textbf{ {{- text .Cfg "legend" -}} }}
+\par\myLineThick
+\medskip
+
+{\noindent\small {{ text .Cfg "legend_intro" }}}
+\bigskip
+
+{\small
+\noindent\begin{tabularx}{\linewidth}{@{}lXrr@{}}
+ & \textbf{ {{- text .Cfg "category" -}} } & \textbf{ {{- text .Cfg "tasks" -}} } & \textbf{ {{- text .Cfg "days" -}} } \\
+\hline
+{{- range .Body.Categories }}
+\LegendSwatch{ {{- .RGB -}} }{ {{- .Pattern -}} } & {{ with .Icon }}\CategoryIcon{ {{- escapeLatex . -}} }{{ end }}{{ escapeLatex .Label }} & {{ .Tasks }} & {{ .Days }} \\
+{{- end }}
+\end{tabularx}}
+
+\pagebreak
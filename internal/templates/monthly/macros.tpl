@@ -6,8 +6,18 @@
 \ExplSyntaxOff
 
 {{- $numbers := .Cfg.Layout.Numbers -}}
+{{- $patterns := .Cfg.Layout.Palette.Patterns -}}
 
-% Task colors are now generated algorithmically - no need for predefined colors
+% Category colors, declared in the config or assigned from the palette, by
+% name for custom templates (category- and the name in lower case with dashes)
+{{- range .Cfg.Layout.TaskCategoryColors }}
+\definecolor{ {{- .XColor -}} }{RGB}{ {{- .RGB -}} }
+{{- end }}
+
+% Page background and text; task fills and frames mix with the page color so
+% they stay dark on a dark page
+\definecolor{PageBackground}{RGB}{ {{- or .Cfg.Layout.PageColors.Background "255,255,255" -}} }
+\definecolor{PageText}{RGB}{ {{- or .Cfg.Layout.PageColors.Text "0,0,0" -}} }
 
 \newlength{\myLenTabColSep}
 \newlength{\myLenLineThicknessDefault}
@@ -16,6 +26,7 @@
 \newlength{\myLenColSep}
 \newlength{\myLenCol}
 \newlength{\myLenMonthlyCellHeight}
+\newlength{\myLenMonthlyRowHeight}
 
 \newlength{\myLenHeaderResizeBox}
 \newlength{\myLenHeaderSideMonthsWidth}
@@ -34,11 +45,28 @@
 % Simple task bar definitions
 % * Define fixed font size macros for task title and body
 \newcommand{\TaskTitleSize}{ {{.Cfg.Layout.TaskStyling.FontSize}} }
-\newcommand{\TaskFontSize}{\footnotesize}
+\newcommand{\TaskFontSize}{ {{- or .Cfg.Layout.TaskStyling.BodyFontSize "\\footnotesize" -}} }
+
+% Page title and header fonts (layout.latex.document.header_size and header_font)
+\newcommand{\HeaderFontFamily}{ {{- if .Cfg.Layout.LaTeX.Document.HeaderFont }}\plannerheaderfont{{ end -}} }
+\newcommand{\PageTitleFont}{ {{- or .Cfg.Layout.LaTeX.Document.HeaderSize "\\Large" -}} \HeaderFontFamily}
 \newlength{\TaskBarHeight}
 \setlength{\TaskBarHeight}{ {{.Cfg.Layout.TaskStyling.BarHeight}} }
 \newlength{\TaskBorderWidth}
 \setlength{\TaskBorderWidth}{ {{.Cfg.Layout.TaskStyling.BorderWidth}} }
+% Task bar borders (task_styling.border_style, or a category's own via
+% "task border style=dashed") and drop shadows (task_styling.shadow);
+% "task bar=<rule width>" applies both. "task category" is set per bar to
+% the category's own fill opacity (categories[].opacity)
+\tcbset{
+  task category/.style={},
+  task border solid/.style={},
+  task border dashed/.style={frame hidden, borderline={#1}{0pt}{tcbcolframe, dashed}},
+  task border dotted/.style={frame hidden, borderline={#1}{0pt}{tcbcolframe, dotted}},
+  task border/.style={task border {{ or .Cfg.Layout.TaskStyling.BorderStyle "solid" }}=#1},
+  task border style/.code={\tcbset{task border/.style={task border #1=##1}}},
+  task bar/.style={task border=#1{{ if .Cfg.Layout.TaskStyling.Shadow }}, drop shadow{{ end }}},
+}
 \newlength{\TaskPaddingH}
 \setlength{\TaskPaddingH}{ {{.Cfg.Layout.TaskStyling.Spacing.PaddingHorizontal}} }
 \newlength{\TaskPaddingV}
@@ -53,6 +81,9 @@
 % Line thickness macro
 \newcommand{\myLineThick}{\rule{\linewidth}{\myLenLineThicknessThick}}
 
+% Shaded day cell for year-at-a-glance mini calendars
+\newcommand{\MiniDayCell}[2]{\definecolor{minidaycolor}{RGB}{#1}\cellcolor{minidaycolor!55}#2}
+
 % Category palette setup macro
 \newcommand{\SetupDefaultCategoryPalette}[1]{#1}
 
@@ -66,15 +97,24 @@
   }}%
 }
 
+{{- if $patterns }}
+% Hatching of bars whose category color is close to another's
+% (layout.palette.patterns); \HatchedTask{pattern}{task box}
+\newcommand{\TaskHatch}{}
+\newcommand{\HatchedTask}[2]{\begingroup\renewcommand{\TaskHatch}{\begin{tcbclipinterior}\fill[pattern=#1, pattern color=taskbgcolor!60!PageBackground] (interior.south west) rectangle (interior.north east);\end{tcbclipinterior}}#2\endgroup}
+{{- end }}
+
 % Task overlay box macros - pill shaped with rounded corners
 % Uses TikZ overlay to draw on top of table gridlines
 \newcommand{\TaskOverlayBox}[3]{%
   \definecolor{taskbgcolor}{RGB}{#1}%
   \definecolor{taskfgcolor}{RGB}{#1}%
   \vfill
-  \begin{tcolorbox}[enhanced, boxrule={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.BoxRule}}, arc={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Arc}},
+  \begin{tcolorbox}[enhanced, boxrule={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.BoxRule}}, task bar={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.BoxRule}}, arc={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Arc}},
     left={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Left}}, right={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Right}}, top=0pt, bottom={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Bottom}},
-    colback=taskbgcolor!{{.Cfg.Layout.TaskStyling.BackgroundOpacity}}, colframe=taskfgcolor!{{.Cfg.Layout.TaskStyling.BorderOpacity}},
+    colback=taskbgcolor!{{.Cfg.Layout.TaskStyling.BackgroundOpacity}}!PageBackground, colframe=taskfgcolor!{{.Cfg.Layout.TaskStyling.BorderOpacity}}!PageBackground, task category,
+    {{- if $patterns }}
+    underlay={\TaskHatch},{{ end }}
     width=\linewidth, halign=left, before skip=0pt, after skip=0pt]
     {\sloppy\hyphenpenalty={{.Cfg.Layout.LaTeX.Typography.HyphenPenalty}}\tolerance={{.Cfg.Layout.LaTeX.Typography.Tolerance}}\emergencystretch={{.Cfg.Layout.LaTeX.Typography.EmergencyStretch}}%
      \TaskTitleSize\textbf{\hyperlink{task-index}{#2}}\par
@@ -83,14 +123,76 @@
   \end{tcolorbox}%
 }
 
+% Month-boundary continuation markers on carried-over task bars
+\newcommand{\ContinuedMarker}{\begingroup\tiny\ensuremath{\leftarrow}\,{{ text .Cfg "continued" }}\endgroup\ }
+\newcommand{\ContinuesMarker}{\ \begingroup\tiny {{ text .Cfg "continues" }}\,\ensuremath{\rightarrow}\endgroup}
+
+% Warning on tasks finishing later than their baseline; #1 is the slip, e.g. +5d
+\newcommand{\SlipWarning}[1]{\begingroup\tiny\textcolor{orange!90!black}{\ensuremath{\blacktriangle}\,#1}\endgroup\ }
+
+% Holiday name printed beside the day number; zero width so the cell layout is unchanged
+\newcommand{\HolidayLabel}[1]{\makebox[0pt][l]{\raisebox{0.2ex}{\tiny\textcolor{red!60!black}{\textit{#1}}}}}
+
+% Generation-date marking: highlighted as-of day, lightly shaded past days
+\newcommand{\TodayCell}{\cellcolor[RGB]{ {{- .Cfg.Layout.Shading.Today -}} }}
+\newcommand{\PastCell}{\cellcolor[gray]{ {{- .Cfg.Layout.Shading.Past -}} }}
+\newcommand{\OverCapacityCell}{\cellcolor[RGB]{ {{- .Cfg.Layout.Shading.OverCapacity -}} }}
+\newcommand{\BlackoutCell}{\cellcolor[gray]{ {{- .Cfg.Layout.Shading.Blackout -}} }}
+
+% Academic calendar event name, printed below the holiday line
+\newcommand{\AcademicLabel}[1]{\makebox[0pt][l]{\raisebox{-1.4ex}{\tiny\textcolor{blue!50!black}{#1}}}}
+
+% Intra-day task bar covering part of a day cell
+% Args: 1=offset and 2=width as fractions of the cell, 3=task box
+\newcommand{\TimedTaskBar}[3]{\hspace*{#1\linewidth}\begin{minipage}[t]{#2\linewidth}#3\end{minipage}}
+
+% Phase rollup band across the top of a day cell (calendar.phase_bands)
+% Args: 1=RGB color, 2=lane
+\newcommand{\PhaseBand}[2]{\makebox[0pt][l]{\definecolor{phasebandcolor}{RGB}{#1}\tikz[overlay]\fill[phasebandcolor, opacity=0.45] (0,{2.3ex-#2*1.6pt}) rectangle (\linewidth,{2.3ex-#2*1.6pt-1.2pt});}}
+
+% Red tick at the left edge of a day cell holding hard deadlines; #1 names the tasks due
+\newcommand{\DeadlineTick}[1]{\makebox[0pt][l]{\textcolor{red!80!black}{\rule[-3.2ex]{0.8pt}{4.4ex}}\,\raisebox{-2.6ex}{\tiny\textcolor{red!80!black}{#1}}}}
+
+% Dependency arrows: anchors are placed in day cells, arrows drawn on the page overlay
+\newcommand{\DepAnchor}[1]{\tikz[remember picture, overlay]\coordinate (#1);}
+\newcommand{\DepArrow}[2]{\draw[->, >=stealth, black!60, thick] ([yshift=-1.5ex]#1) to[out=-30, in=210] ([yshift=-1.5ex]#2);}
+\newcommand{\DepArrowLag}[3]{\draw[->, >=stealth, black!60, thick] ([yshift=-1.5ex]#1) to[out=-30, in=210] node[midway, below, font=\tiny, inner sep=1pt] {#3} ([yshift=-1.5ex]#2);}
+\newcommand{\DepFromEarlier}{\ensuremath{\hookrightarrow}\,}
+\newcommand{\DepToLater}{\,\ensuremath{\hookrightarrow}}
+
+% Fixed-height lane for the swimlane layout
+% Args: 1=lane height, 2=lane content (may be empty)
+\newcommand{\SwimlaneSlot}[2]{%
+  \parbox[t][#1][t]{\linewidth}{\vspace{0pt}#2}\par
+}
+
 % Milestone task overlay box - thicker borders for emphasis
 \newcommand{\MilestoneTaskOverlayBox}[3]{%
   \definecolor{taskbgcolor}{RGB}{#1}%
   \definecolor{taskfgcolor}{RGB}{#1}%
   \vfill
-  \begin{tcolorbox}[enhanced, boxrule={{.Cfg.Layout.TaskStyling.Milestone.BorderWidth}}, arc={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Arc}},
+  \begin{tcolorbox}[enhanced, boxrule={{.Cfg.Layout.TaskStyling.Milestone.BorderWidth}}, task bar={{.Cfg.Layout.TaskStyling.Milestone.BorderWidth}}, arc={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Arc}},
+    left={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Left}}, right={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Right}}, top=0pt, bottom={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Bottom}},
+    colback=taskbgcolor!{{.Cfg.Layout.TaskStyling.Milestone.BackgroundOpacity}}!PageBackground, colframe=taskfgcolor!{{.Cfg.Layout.TaskStyling.Milestone.BorderOpacity}}!PageBackground, task category,
+    {{- if $patterns }}
+    underlay={\TaskHatch},{{ end }}
+    width=\linewidth, halign=left, before skip=0pt, after skip=0pt]
+    {\sloppy\hyphenpenalty={{.Cfg.Layout.LaTeX.Typography.HyphenPenalty}}\tolerance={{.Cfg.Layout.LaTeX.Typography.Tolerance}}\emergencystretch={{.Cfg.Layout.LaTeX.Typography.EmergencyStretch}}%
+     \TaskTitleSize\textbf{\hyperlink{task-index}{#2}}\par
+     \vspace{ {{.Cfg.Layout.TaskStyling.Spacing.ContentVspace}} }%
+     {\TaskFontSize\raggedright #3\par}}%
+  \end{tcolorbox}%
+}
+
+% Critical path task overlay box - red frame marks tasks that drive the end date
+\newcommand{\CriticalTaskOverlayBox}[3]{%
+  \definecolor{taskbgcolor}{RGB}{#1}%
+  \vfill
+  \begin{tcolorbox}[enhanced, boxrule={{.Cfg.Layout.TaskStyling.Milestone.BorderWidth}}, task bar={{.Cfg.Layout.TaskStyling.Milestone.BorderWidth}}, arc={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Arc}},
     left={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Left}}, right={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Right}}, top=0pt, bottom={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Bottom}},
-    colback=taskbgcolor!{{.Cfg.Layout.TaskStyling.Milestone.BackgroundOpacity}}, colframe=taskfgcolor!{{.Cfg.Layout.TaskStyling.Milestone.BorderOpacity}},
+    colback=taskbgcolor!{{.Cfg.Layout.TaskStyling.BackgroundOpacity}}!PageBackground, colframe=red!75!black, task category,
+    {{- if $patterns }}
+    underlay={\TaskHatch},{{ end }}
     width=\linewidth, halign=left, before skip=0pt, after skip=0pt]
     {\sloppy\hyphenpenalty={{.Cfg.Layout.LaTeX.Typography.HyphenPenalty}}\tolerance={{.Cfg.Layout.LaTeX.Typography.Tolerance}}\emergencystretch={{.Cfg.Layout.LaTeX.Typography.EmergencyStretch}}%
      \TaskTitleSize\textbf{\hyperlink{task-index}{#2}}\par
@@ -99,15 +201,58 @@
   \end{tcolorbox}%
 }
 
+% Task overlay box with a darker fill showing completion
+% Args: 1=RGB color, 2=title, 3=description, 4=completed fraction (0-1)
+\newlength{\TaskProgressWidth}
+\newcommand{\TaskProgressBox}[4]{%
+  \definecolor{taskbgcolor}{RGB}{#1}%
+  \definecolor{taskfgcolor}{RGB}{#1}%
+  \setlength{\TaskProgressWidth}{#4\linewidth}%
+  \vfill
+  \begin{tcolorbox}[enhanced, boxrule={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.BoxRule}}, task bar={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.BoxRule}}, arc={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Arc}},
+    left={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Left}}, right={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Right}}, top=0pt, bottom={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Bottom}},
+    colback=taskbgcolor!{{.Cfg.Layout.TaskStyling.BackgroundOpacity}}!PageBackground, colframe=taskfgcolor!{{.Cfg.Layout.TaskStyling.BorderOpacity}}!PageBackground, task category,
+    underlay={ {{- if $patterns }}\TaskHatch{{ end }}\begin{tcbclipinterior}\fill[taskfgcolor!45] (interior.south west) rectangle ([xshift=\TaskProgressWidth]interior.north west);\end{tcbclipinterior}},
+    width=\linewidth, halign=left, before skip=0pt, after skip=0pt]
+    {\sloppy\hyphenpenalty={{.Cfg.Layout.LaTeX.Typography.HyphenPenalty}}\tolerance={{.Cfg.Layout.LaTeX.Typography.Tolerance}}\emergencystretch={{.Cfg.Layout.LaTeX.Typography.EmergencyStretch}}%
+     \TaskTitleSize\textbf{\hyperlink{task-index}{#2}}\par
+     \vspace{ {{.Cfg.Layout.TaskStyling.Spacing.ContentVspace}} }%
+     {\TaskFontSize\raggedright #3\par}}%
+  \end{tcolorbox}%
+}
+
+% "+N more" label for tasks collapsed past calendar.max_tasks_per_day
+\newcommand{\TaskOverflow}[1]{\par\vspace{0.5mm}{\TaskFontSize\textit{#1}}\par}
+
+% Month page footnote for tasks collapsed in a crowded cell (overflow_mode: footnotes)
+% Args: 1=note number, 2=linked date, 3=task names
+\newcommand{\OverflowFootnote}[3]{\par\noindent{\footnotesize\textsuperscript{#1}\,\textbf{#2}: #3}}
+
+% Milestone markers pinned to a date (task_styling.milestone.style: diamond/star)
+% Args: 1=RGB color, 2=title
+\newcommand{\MilestoneDiamond}[2]{%
+  \definecolor{milestonecolor}{RGB}{#1}%
+  \par\noindent\textcolor{milestonecolor}{\ensuremath{\blacklozenge}}~{\TaskTitleSize\textbf{\hyperlink{task-index}{#2}}}\par
+}
+\newcommand{\MilestoneStar}[2]{%
+  \definecolor{milestonecolor}{RGB}{#1}%
+  \par\noindent\textcolor{milestonecolor}{\ensuremath{\bigstar}}~{\TaskTitleSize\textbf{\hyperlink{task-index}{#2}}}\par
+}
+% Recurring milestones (recurring_milestones in config), in a lighter weight
+\newcommand{\RecurringMilestone}[2]{%
+  \definecolor{milestonecolor}{RGB}{#1}%
+  \par\noindent\textcolor{milestonecolor}{\ensuremath{\circlearrowright}}~{\TaskTitleSize\textit{#2}}\par
+}
+
 % Task overlay box with Y-offset for stacking on top of other tasks
 % Args: 1=RGB color, 2=title, 3=description, 4=Y offset in pt
 \newcommand{\TaskOverlayBoxWithOffset}[4]{%
   \definecolor{taskbgcolor}{RGB}{#1}%
   \definecolor{taskfgcolor}{RGB}{#1}%
   \raisebox{#4}{%
-    \begin{tcolorbox}[enhanced, boxrule={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.BoxRule}}, arc={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Arc}},
+    \begin{tcolorbox}[enhanced, boxrule={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.BoxRule}}, task bar={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.BoxRule}}, arc={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Arc}},
       left={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Left}}, right={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Right}}, top=0pt, bottom={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Bottom}},
-      colback=taskbgcolor!{{.Cfg.Layout.TaskStyling.BackgroundOpacity}}, colframe=taskfgcolor!{{.Cfg.Layout.TaskStyling.BorderOpacity}},
+      colback=taskbgcolor!{{.Cfg.Layout.TaskStyling.BackgroundOpacity}}!PageBackground, colframe=taskfgcolor!{{.Cfg.Layout.TaskStyling.BorderOpacity}}!PageBackground, task category,
       width=\linewidth, halign=left, before skip=0pt, after skip=0pt]
       {\sloppy\hyphenpenalty={{.Cfg.Layout.LaTeX.Typography.HyphenPenalty}}\tolerance={{.Cfg.Layout.LaTeX.Typography.Tolerance}}\emergencystretch={{.Cfg.Layout.LaTeX.Typography.EmergencyStretch}}%
        \TaskTitleSize\textbf{\hyperlink{task-index}{#2}}\par
@@ -121,9 +266,9 @@
 \newcommand{\TaskOverlayBoxNoOffset}[3]{%
   \definecolor{taskbgcolor}{RGB}{#1}%
   \definecolor{taskfgcolor}{RGB}{#1}%
-  \begin{tcolorbox}[enhanced, boxrule={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.BoxRule}}, arc={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Arc}},
+  \begin{tcolorbox}[enhanced, boxrule={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.BoxRule}}, task bar={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.BoxRule}}, arc={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Arc}},
     left={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Left}}, right={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Right}}, top=0pt, bottom=0pt,
-    colback=taskbgcolor!{{.Cfg.Layout.TaskStyling.BackgroundOpacity}}, colframe=taskfgcolor!{{.Cfg.Layout.TaskStyling.BorderOpacity}},
+    colback=taskbgcolor!{{.Cfg.Layout.TaskStyling.BackgroundOpacity}}!PageBackground, colframe=taskfgcolor!{{.Cfg.Layout.TaskStyling.BorderOpacity}}!PageBackground, task category,
     width=\linewidth, halign=left, before skip=0pt, after skip=0pt]
     {\sloppy\hyphenpenalty={{.Cfg.Layout.LaTeX.Typography.HyphenPenalty}}\tolerance={{.Cfg.Layout.LaTeX.Typography.Tolerance}}\emergencystretch={{.Cfg.Layout.LaTeX.Typography.EmergencyStretch}}%
      \TaskTitleSize\textbf{\hyperlink{task-index}{#2}}\par
@@ -138,9 +283,9 @@
   \definecolor{taskbgcolor}{RGB}{#1}%
   \definecolor{taskfgcolor}{RGB}{#1}%
   \raisebox{#4}{%
-    \begin{tcolorbox}[enhanced, boxrule={{.Cfg.Layout.TaskStyling.Milestone.BorderWidth}}, arc={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Arc}},
+    \begin{tcolorbox}[enhanced, boxrule={{.Cfg.Layout.TaskStyling.Milestone.BorderWidth}}, task bar={{.Cfg.Layout.TaskStyling.Milestone.BorderWidth}}, arc={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Arc}},
       left={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Left}}, right={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Right}}, top=0pt, bottom={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Bottom}},
-      colback=taskbgcolor!{{.Cfg.Layout.TaskStyling.Milestone.BackgroundOpacity}}, colframe=taskfgcolor!{{.Cfg.Layout.TaskStyling.Milestone.BorderOpacity}},
+      colback=taskbgcolor!{{.Cfg.Layout.TaskStyling.Milestone.BackgroundOpacity}}!PageBackground, colframe=taskfgcolor!{{.Cfg.Layout.TaskStyling.Milestone.BorderOpacity}}!PageBackground, task category,
       width=\linewidth, halign=left, before skip=0pt, after skip=0pt]
       {\sloppy\hyphenpenalty={{.Cfg.Layout.LaTeX.Typography.HyphenPenalty}}\tolerance={{.Cfg.Layout.LaTeX.Typography.Tolerance}}\emergencystretch={{.Cfg.Layout.LaTeX.Typography.EmergencyStretch}}%
        \TaskTitleSize\textbf{\hyperlink{task-index}{#2}}\par
@@ -154,9 +299,9 @@
 \newcommand{\MilestoneTaskOverlayBoxNoOffset}[3]{%
   \definecolor{taskbgcolor}{RGB}{#1}%
   \definecolor{taskfgcolor}{RGB}{#1}%
-  \begin{tcolorbox}[enhanced, boxrule={{.Cfg.Layout.TaskStyling.Milestone.BorderWidth}}, arc={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Arc}},
+  \begin{tcolorbox}[enhanced, boxrule={{.Cfg.Layout.TaskStyling.Milestone.BorderWidth}}, task bar={{.Cfg.Layout.TaskStyling.Milestone.BorderWidth}}, arc={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Arc}},
     left={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Left}}, right={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Right}}, top=0pt, bottom=0pt,
-    colback=taskbgcolor!{{.Cfg.Layout.TaskStyling.BackgroundOpacity}}, colframe=taskfgcolor!{{.Cfg.Layout.TaskStyling.BorderOpacity}},
+    colback=taskbgcolor!{{.Cfg.Layout.TaskStyling.BackgroundOpacity}}!PageBackground, colframe=taskfgcolor!{{.Cfg.Layout.TaskStyling.BorderOpacity}}!PageBackground, task category,
     width=\linewidth, halign=left, before skip=0pt, after skip=0pt]
     {\sloppy\hyphenpenalty={{.Cfg.Layout.LaTeX.Typography.HyphenPenalty}}\tolerance={{.Cfg.Layout.LaTeX.Typography.Tolerance}}\emergencystretch={{.Cfg.Layout.LaTeX.Typography.EmergencyStretch}}%
      \TaskTitleSize\textbf{\hyperlink{task-index}{#2}}\par
@@ -186,9 +331,9 @@
   \definecolor{taskoverlaypbgcolor}{RGB}{#2}%
   \definecolor{taskoverlaypfgcolor}{RGB}{#2}%
   \vspace*{\TaskVerticalOffset}%
-  \begin{tcolorbox}[enhanced, boxrule={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.BoxRule}}, arc={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Arc}},
+  \begin{tcolorbox}[enhanced, boxrule={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.BoxRule}}, task bar={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.BoxRule}}, arc={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Arc}},
     left={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Left}}, right={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Right}}, top={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Top}}, bottom={{.Cfg.Layout.TaskStyling.TColorBox.Overlay.Bottom}},
-    colback=taskoverlaypbgcolor!{{.Cfg.Layout.TaskStyling.BackgroundOpacity}}, colframe=taskoverlaypfgcolor!{{.Cfg.Layout.TaskStyling.BorderOpacity}},
+    colback=taskoverlaypbgcolor!{{.Cfg.Layout.TaskStyling.BackgroundOpacity}}!PageBackground, colframe=taskoverlaypfgcolor!{{.Cfg.Layout.TaskStyling.BorderOpacity}}!PageBackground,
     width=\linewidth, halign=left]
     {\sloppy\hyphenpenalty={{.Cfg.Layout.LaTeX.Typography.HyphenPenalty}}\tolerance={{.Cfg.Layout.LaTeX.Typography.Tolerance}}\emergencystretch={{.Cfg.Layout.LaTeX.Typography.EmergencyStretch}}%
      \TaskTitleSize\textbf{#1}\par
@@ -212,29 +357,19 @@
 
 
 
-% Color legend macro for task categories - uses algorithmic colors
+% Category swatch on the legend page; Args: 1=RGB color, 2=TikZ pattern or empty
+% Category icon before a task name (categories[].icon), in layout.latex.document.icon_font
+\newcommand{\CategoryIcon}[1]{\begingroup{{ if .Cfg.Layout.LaTeX.Document.IconFont }}\plannericonfont{{ end }} #1\endgroup\,}
+
+\newcommand{\LegendSwatch}[2]{\begingroup\definecolor{swatchcolor}{RGB}{#1}\tikz[baseline=-0.6ex]{\fill[swatchcolor, rounded corners=1pt] (0,0) rectangle (1.2em,0.9em);\if\relax\detokenize{#2}\relax\else\fill[pattern=#2, pattern color=white!70!black] (0,0) rectangle (1.2em,0.9em);\fi}\endgroup}
+
+% Color legend macro for the categories declared in the config
 \newcommand{\ColorLegend}{%
   {\small
+{{- range $i, $c := .Cfg.Layout.CategoryColors }}{{ if $i }}\quad{{ end }}
     \BeginAccSupp{method=pdfstringdef,unicode,ActualText={}}%
-    \textcolor[RGB]{ {{- .Cfg.Layout.AlgorithmicColors.Proposal -}} }{\Large$\bullet$}%
-    \EndAccSupp{}~\small{Proposal}\quad
-    \BeginAccSupp{method=pdfstringdef,unicode,ActualText={}}%
-    \textcolor[RGB]{ {{- .Cfg.Layout.AlgorithmicColors.Laser -}} }{\Large$\bullet$}%
-    \EndAccSupp{}~\small{Laser}\quad
-    \BeginAccSupp{method=pdfstringdef,unicode,ActualText={}}%
-    \textcolor[RGB]{ {{- .Cfg.Layout.AlgorithmicColors.Imaging -}} }{\Large$\bullet$}%
-    \EndAccSupp{}~\small{Imaging}\quad
-    \BeginAccSupp{method=pdfstringdef,unicode,ActualText={}}%
-    \textcolor[RGB]{ {{- .Cfg.Layout.AlgorithmicColors.Admin -}} }{\Large$\bullet$}%
-    \EndAccSupp{}~\small{Admin}\quad
-    \BeginAccSupp{method=pdfstringdef,unicode,ActualText={}}%
-    \textcolor[RGB]{ {{- .Cfg.Layout.AlgorithmicColors.Dissertation -}} }{\Large$\bullet$}%
-    \EndAccSupp{}~\small{Dissertation}\quad
-    \BeginAccSupp{method=pdfstringdef,unicode,ActualText={}}%
-    \textcolor[RGB]{ {{- .Cfg.Layout.AlgorithmicColors.Research -}} }{\Large$\bullet$}%
-    \EndAccSupp{}~\small{Research}\quad
-    \BeginAccSupp{method=pdfstringdef,unicode,ActualText={}}%
-    \textcolor[RGB]{ {{- .Cfg.Layout.AlgorithmicColors.Publication -}} }{\Large$\bullet$}%
-    \EndAccSupp{}~\small{Publication}%
+    \textcolor[RGB]{ {{- $c.RGB -}} }{\Large$\bullet$}%
+    \EndAccSupp{}~\small{ {{- escapeLatex $c.Label -}} }
+{{- end -}}%
   \par}
 }
@@ -0,0 +1,16 @@
+{{- hypertarget .Body.Strip.Ref "" -}}
+{\noindent\normalsize\renewcommand{\arraystretch}{1.0}
+{{- .Body.Breadcrumb -}}
+\hfill%
+{{ .Body.Extra.Table false -}}
+}
+\myLineThick
+\medskip
+
+{\noindent\PageTitleFont\textbf{ {{- .Body.Strip.Title -}} }}
+\par\medskip
+
+% Month strip: one day per row, task bars run down the page
+\noindent{{ .Body.Strip.TikZ }}
+
+\pagebreak
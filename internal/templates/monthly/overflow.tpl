@@ -0,0 +1,19 @@
+{{- hypertarget "overflow" "" -}}
+{\noindent\PageTitleFont\textbf{ {{- text .Cfg "more_tasks" -}} }}
+\par\myLineThick
+\medskip
+
+% Tasks hidden behind "+N more" in the month grid
+{{- range $day := .Body.Days }}
+{{ hypertarget $day.OverflowRef "" -}}
+\noindent\textbf{\hyperlink{ {{- $day.CellRef -}} }{ {{- $day.Title -}} }}\par
+\begin{itemize}[leftmargin=*, itemsep=2pt, topsep=2pt, label={}]
+{{- range $task := $day.OverflowTasks }}
+\item \ColorCircle{ {{- $task.ColorRGB -}} }{ {{- if $task.IsMilestone }}\ensuremath{\bigstar}~{{ end }}{{ $task.EscapedName -}} }
+\hfill{\small {{ $.Body.Locale.ShortDate $task.StartDate }} -- {{ $.Body.Locale.ShortDate $task.EndDate }}}
+{{- end }}
+\end{itemize}
+\medskip
+{{- end }}
+
+\pagebreak
@@ -0,0 +1,23 @@
+{{- hypertarget .Body.QuarterRef "" -}}
+{\noindent\normalsize\renewcommand{\arraystretch}{1.0}
+{{- .Body.Breadcrumb -}}
+\hfill%
+{\PageTitleFont\textbf{Q{{ .Body.View.Quarter.Number }} {{ .Body.View.Quarter.Year.Number }}}}%
+}
+\myLineThick
+\medskip
+
+% Quarter grid: one column per week, bars continue across months
+{%
+\setlength{\tabcolsep}{\myLenTabColSep}
+\renewcommand{\arraystretch}{1.3}
+\noindent
+\begin{tabularx}{\linewidth}{ {{- .Body.View.ColumnSpec -}} }
+\hline
+{{ .Body.View.MonthHeader }} \\ \hline
+{{ .Body.View.WeekHeader }} \\ \noalign{\hrule height \myLenLineThicknessThick}
+{{ .Body.View.TaskRows -}}
+\end{tabularx}
+}
+
+\pagebreak
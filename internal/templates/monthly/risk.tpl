@@ -0,0 +1,27 @@
+{{- hypertarget "risk" "" -}}
+{\noindent\PageTitleFont\textbf{ {{- text .Cfg "schedule_risk" -}} }}
+\par\myLineThick
+\medskip
+
+{\noindent\small {{ text .Cfg "risk_intro" .Body.Risk.Result.Trials }}}
+\bigskip
+
+% Milestone confidence dates
+{\footnotesize
+\noindent\begin{tabularx}{\linewidth}{ {{- .Body.Risk.ColumnSpec -}} }
+\textbf{ {{- text .Cfg "milestone" -}} } & \textbf{ {{- text .Cfg "planned" -}} } & \textbf{ {{- text .Cfg "on_time" -}} }
+{{- range .Body.Risk.PercentileHeaders }} & \textbf{ {{- . -}} }{{ end }} \\
+\hline
+{{- range .Body.Risk.Rows }}
+{{ .Name }} & {{ $.Body.Locale.ShortDate .Planned }} {{ .Planned.Year }} & {{ .OnTime }}
+{{- range .Dates }} & {{ $.Body.Locale.ShortDate . }} {{ .Year }}{{ end }} \\
+{{- end }}
+\end{tabularx}}
+
+\bigskip
+% Finish distribution of the last milestone
+{\noindent {{- text .Cfg "finish_distribution" (printf "\\textbf{%s}" .Body.Risk.FinalName) -}} }
+\par\bigskip\bigskip
+\noindent{{ .Body.Risk.Histogram }}
+
+\pagebreak
@@ -0,0 +1,10 @@
+{{- hypertarget "timeline" "" -}}
+{\noindent\PageTitleFont\textbf{ {{- text .Cfg "roadmap" -}} }\hfill{\normalsize {{ .Body.Timeline.Span }}}}
+\par\myLineThick
+\vspace*{\fill}
+
+% Roadmap: phase bands above the axis, milestones below
+\noindent{{ .Body.Timeline.TikZ }}
+
+\vspace*{\fill}
+\pagebreak
@@ -1,14 +1,17 @@
 % Table of Contents - Clickable Task Index
 \hypertarget{task-index}{}
-{\Large\textbf{Task Index}}
+{\PageTitleFont\textbf{ {{- text .Cfg "task_index" -}} }}
 
 \vspace{0.4cm}
 
 % Data Sources Summary Table
 \noindent\begin{tabularx}{\linewidth}{@{}lX@{}}
-\textbf{Data Sources:} & {{.Body.CSVFileCount}} CSV file(s) merged \\[2pt]
-\textbf{Files:} & {\footnotesize {{range $i, $file := .Body.CSVFiles}}{{if $i}}, {{end}}{{$file}}{{end}}} \\[2pt]
-\textbf{Total Tasks:} & {{.Body.TotalTasks}} tasks{{if .Body.MilestoneCount}} ({{.Body.MilestoneCount}} milestones){{end}}{{if .Body.CompletedCount}} | {{.Body.CompletedCount}} completed{{end}} \\
+\textbf{ {{- text .Cfg "data_sources" -}}:} & {{ text .Cfg "csv_merged" .Body.CSVFileCount }} \\[2pt]
+\textbf{ {{- text .Cfg "files" -}}:} & {\footnotesize {{range $i, $file := .Body.CSVFiles}}{{if $i}}, {{end}}{{$file}}{{end}}} \\[2pt]
+\textbf{ {{- text .Cfg "total_tasks" -}}:} & {{ text .Cfg "task_count" .Body.TotalTasks }}{{if .Body.MilestoneCount}} ({{ text .Cfg "milestones" .Body.MilestoneCount }}){{end}}{{if .Body.CompletedCount}} | {{ text .Cfg "completed_n" .Body.CompletedCount }}{{end}} \\
+{{- with .Body.CPM }}
+\textbf{ {{- text $.Cfg "critical_path" -}}:} & {{ text $.Cfg "no_float" .CriticalCount }} | {{ text $.Cfg "plan_finishes" (printf "%s %d" ($.Body.Locale.ShortDate .Finish) .Finish.Year) }} \\
+{{- end }}
 \end{tabularx}
 
 \vspace{0.4cm}
@@ -27,24 +30,28 @@
 
 % Section: {{$section}}
 \vspace{0.4cm}
-\noindent\colorbox[gray]{0.95}{\parbox{\linewidth}{\vspace{0.15cm}\hspace{0.2cm}{\LARGE\textbf{ {{- $section -}} }}\vspace{0.15cm}}}
+\noindent\colorbox{PageText!5!PageBackground}{\parbox{\linewidth}{\vspace{0.15cm}\hspace{0.2cm}{\LARGE\HeaderFontFamily\textbf{ {{- $section -}} }}\vspace{0.15cm}}}
 \vspace{0.15cm}
 {{- end}}
 
 % Phase: {{$phaseName}}
 \vspace{0.2cm}
-\noindent\colorbox[RGB]{ {{- $phaseColor -}} }{\parbox{0.98\linewidth}{\vspace{2pt}\textbf{\large {{$phaseName}}}\hfill{\small {{$stats.total}} tasks{{if $stats.milestones}}, {{$stats.milestones}} milestones{{end}}{{if $stats.completed}}, {{$stats.progress}}\% complete{{end}}}\vspace{2pt}}}
+\noindent\colorbox[RGB]{ {{- $phaseColor -}} }{\parbox{0.98\linewidth}{\vspace{2pt}\textbf{\large {{$phaseName}}}\hfill{\small {{ text $.Cfg "task_count" $stats.total }}{{if $stats.milestones}}, {{ text $.Cfg "milestones" $stats.milestones }}{{end}}{{if $stats.progress}}, {{ text $.Cfg "complete_pct" $stats.progress }}{{end}}}\vspace{2pt}}}
 
 \vspace{0.1cm}
 
-\noindent\begin{tabularx}{\linewidth}{@{\hspace{0.5em}}c@{\hspace{0.8em}}>{\RaggedRight}X@{\hspace{0.8em}}l@{\hspace{0.8em}}l@{\hspace{0.5em}}}
+\noindent\begin{tabularx}{\linewidth}{@{\hspace{0.5em}}c@{\hspace{0.8em}}>{\RaggedRight}X@{\hspace{0.8em}}l@{\hspace{0.8em}}l{{if $.Body.ShowCPM}}@{\hspace{0.8em}}l@{\hspace{0.8em}}r{{end}}@{\hspace{0.5em}}}
+{{- if $.Body.ShowCPM }}
+ & & {\scriptsize {{ text $.Cfg "start" }}} & {\scriptsize {{ text $.Cfg "end" }}} & {\scriptsize {{ text $.Cfg "late_start" }}} & {\scriptsize {{ text $.Cfg "float" }}} \\
+{{- end }}
 \hline
     {{- range $i, $task := index $.Body.TaskIndex $phase}}
         {{- $taskName := $task.Name }}
         {{- $taskIcon := "" }}
-        {{- if $task.IsMilestone}}{{- $taskIcon = "\\BeginAccSupp{method=pdfstringdef,unicode,ActualText={Milestone: } }$\\star$\\EndAccSupp{}" }}{{- $taskName = printf "\\textbf{%s}" $taskName}}{{- end}}
-        {{- if eq ($task.Status | lower) "completed"}}{{- $taskIcon = "\\BeginAccSupp{method=pdfstringdef,unicode,ActualText={Completed: } }$\\checkmark$\\EndAccSupp{}" }}{{- $taskName = printf "\\textcolor{gray}{%s}" $taskName}}{{- end}}
-{{plus $i 1}} & \hyperlink{ {{- $task.StartDate.Local.Format "2006-01-02T15:04:05-07:00" -}} }{ {{- $taskName -}} } {{$taskIcon}} & {\footnotesize {{$task.StartDate.Format "Jan 02"}}} & {\footnotesize {{$task.EndDate.Format "Jan 02"}}} \\
+        {{- if $task.IsMilestone}}{{- $taskIcon = printf "\\BeginAccSupp{method=pdfstringdef,unicode,ActualText={%s: } }$\\star$\\EndAccSupp{}" (text $.Cfg "milestone") }}{{- $taskName = printf "\\textbf{%s}" $taskName}}{{- end}}
+        {{- if eq ($task.Status | lower) "completed"}}{{- $taskIcon = printf "\\BeginAccSupp{method=pdfstringdef,unicode,ActualText={%s: } }$\\checkmark$\\EndAccSupp{}" (text $.Cfg "completed") }}{{- $taskName = printf "\\textcolor{gray}{%s}" $taskName}}{{- end}}
+{{plus $i 1}} & \hyperlink{ {{- $task.StartDate.Local.Format "2006-01-02T15:04:05-07:00" -}} }{ {{- $taskName -}} } {{$taskIcon}}{{ if and $.Body.CPM ($.Body.CPM.IsCritical $task.ID) }} {\scriptsize\textcolor{red!75!black}{ {{- text $.Cfg "zero_slack" -}} }}{{ end }} & {\footnotesize {{$.Body.Locale.ShortDate $task.StartDate}}} & {\footnotesize {{$.Body.Locale.ShortDate $task.EndDate}}}
+{{- if $.Body.ShowCPM }}{{ $cpm := index $.Body.CPM.Tasks $task.ID }}{{ if $cpm.ID }} & {\footnotesize {{$.Body.Locale.ShortDate $cpm.LateStart}}} & {\footnotesize {{$cpm.TotalFloat}}d}{{ else }} & & {{ end }}{{ end }} \\
     {{- end}}
 \hline
 \end{tabularx}
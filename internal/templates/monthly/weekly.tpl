@@ -0,0 +1,50 @@
+{{- hypertarget .Body.WeekRef "" -}}
+{\noindent\normalsize\renewcommand{\arraystretch}{1.0}
+{{- .Body.Breadcrumb -}}
+\hfill%
+{{ .Body.Extra.Table false -}}
+}
+\myLineThick
+\smallskip
+
+{\noindent\large\HeaderFontFamily\textbf{ {{- .Body.Week.DateRange -}} }}
+\medskip
+
+% Task bars for the week (same rendering as the monthly grid)
+{%
+\setlength{\tabcolsep}{\myLenTabColSep}
+\begin{tabularx}{\linewidth}{|l!{\vrule width \myLenLineThicknessThick}*{7}{@{}X@{}|}}
+\hline
+{{ .Body.Week.DayHeader }} \\ \noalign{\hrule height \myLenLineThicknessThick}
+{{ .Body.Week.WeekNumber true }} &
+{{- range $j, $day := .Body.Week.Days }}
+  {{ $day.Day $.Body.Today true }}
+  {{- if eq $j 6 }} \\[\myLenMonthlyCellHeight] \hline {{ else }} & {{ end }}
+{{- end }}
+\end{tabularx}
+}
+\medskip
+
+{{ if eq .Body.Style "hours" -}}
+% Hour rows for each day
+{%
+\renewcommand{\arraystretch}{1.5}
+\setlength{\tabcolsep}{\myLenColSep}
+\begin{tabularx}{\linewidth}{|r|*{7}{X|}}
+\hline
+{{- range .Body.Week.WeekdayNames }} & {\footnotesize\textbf{ {{- . -}} }}{{ end }} \\ \hline
+{{- range .Body.Hours }}
+{\scriptsize {{ . }}} & & & & & & & \\ \hline
+{{- end }}
+\end{tabularx}
+}
+{{- else -}}
+% Free writing space for each day
+{{- range .Body.Week.WeekdayNames }}
+\noindent\textbf{ {{- . -}} }\par
+\vspace{\fill}
+\noindent\rule{\linewidth}{\myLenLineThicknessDefault}
+{{- end }}
+{{- end }}
+
+\pagebreak
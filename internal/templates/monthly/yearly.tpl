@@ -0,0 +1,30 @@
+{{- hypertarget .Body.YearRef "" -}}
+{\noindent\normalsize\renewcommand{\arraystretch}{1.0}
+{{- .Body.Breadcrumb -}}
+\hfill%
+{\PageTitleFont\textbf{ {{- text .Cfg "at_a_glance" .Body.Year.Number -}} }}%
+}
+\myLineThick
+\medskip
+
+% Twelve mini-months, three per row
+{%
+\setlength{\tabcolsep}{1.5pt}
+\renewcommand{\arraystretch}{1.1}
+\noindent
+{{- range $i, $month := .Body.Year.Months }}
+\begin{minipage}[t]{0.32\linewidth}\centering
+{{ $month.MiniCalendar }}
+\end{minipage}
+{{- if eq (mod (incr $i) 3) 0 }}\par\bigskip\noindent{{ else }}\hfill{{ end }}
+{{- end }}
+}
+
+% Phase legend
+\vfill
+{{- with .Body.Legend }}
+{\small\noindent\textbf{ {{- text $.Cfg "phases" -}} }\\
+{{- range . -}}\ColorCircle{ {{- .Color -}} }{ {{- .Name -}} }\quad{{- end -}}}
+{{- end }}
+
+\pagebreak
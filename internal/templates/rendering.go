@@ -127,14 +127,18 @@ type MonthItem struct {
 	Val     time.Month
 	ref     bool
 	shorten bool
+	label   string
 }
 
 func (m MonthItem) Display() string {
 	ref := m.Val.String()
 	text := ref
+	if m.label != "" {
+		text = m.label
+	}
 
 	if m.shorten {
-		text = text[:3]
+		text = string([]rune(text)[:3])
 	}
 
 	if m.ref {
@@ -149,6 +153,13 @@ func (m MonthItem) Ref() MonthItem {
 	return m
 }
 
+// Label overrides the displayed month name (e.g. with a localized name) while
+// keeping the English month name as the hyperlink reference
+func (m MonthItem) Label(label string) MonthItem {
+	m.label = label
+	return m
+}
+
 func (m MonthItem) Shorten(f bool) MonthItem {
 	m.shorten = f
 	return m
@@ -8,9 +8,14 @@
 )
 
 func main() {
-	app := app.New()
-	if err := app.Run(os.Args); err != nil {
-		fmt.Fprintf(os.Stderr, "❌ Fatal error: %v\n", err)
-		os.Exit(1)
+	planner := app.New()
+	if err := planner.Run(os.Args); err != nil {
+		code := app.ExitCode(err)
+		if code == app.ExitFatal {
+			fmt.Fprintf(os.Stderr, "❌ Fatal error: %v\n", err)
+		} else {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+		}
+		os.Exit(code)
 	}
 }